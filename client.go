@@ -3,19 +3,22 @@ package qe_connector
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/Quantum-Execute/qe-connector-go/handlers"
 	"github.com/bitly/go-simplejson"
 )
 
@@ -34,11 +37,73 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
-	do         doFunc
+	// Signer, when set, signs requests instead of SecretKey (see SetSigner).
+	Signer   Signer
+	slogger  *slog.Logger
+	instr    Instrumentation
+	do       doFunc
+	recorder *Recorder
+	cassette *Cassette
+	skew     *clockSkew
+	cache    *ReadCache
+	hedge    *hedger
+	retry    *RetryPolicy
+	rest     *endpointPool
+	wsHosts  *endpointPool
+	// Set by New.
+	wsHost     string
+	recvWindow int64
+	uaSuffix   string
+	// needWSHost is set by New for EnvProd, which has no default
+	// WebSocket host.
+	needWSHost bool
+	// fixed is set by New; see settings.
+	fixed *clientSettings
+	// creds is set by RotateCredentials.
+	creds    atomic.Pointer[credentialSet]
+	logCache atomic.Pointer[cachedLogger]
+	wsMu     sync.Mutex
+	sockets  map[*WebSocketService]struct{}
 }
 
 type doFunc func(req *http.Request) (*http.Response, error)
 
+// clientSettings are the exported fields requests are sent with.
+type clientSettings struct {
+	baseURL    string
+	httpClient *http.Client
+	debug      bool
+	logger     *log.Logger
+	timeOffset int64
+}
+
+// settings returns what requests are sent with: the exported fields or,
+// for a client built by New, the values New set them to.
+func (c *Client) settings() clientSettings {
+	if c.fixed != nil {
+		return *c.fixed
+	}
+	return clientSettings{
+		baseURL:    c.BaseURL,
+		httpClient: c.HTTPClient,
+		debug:      c.Debug,
+		logger:     c.Logger,
+		timeOffset: c.TimeOffset,
+	}
+}
+
+// frozen reports whether c was built by New, whose configuration setters
+// leave unchanged; the ignored call is logged.
+func (c *Client) frozen(setter string) bool {
+	if c.fixed == nil {
+		return false
+	}
+	if l := c.logger(); l != nil {
+		l.Warn("qe: setter ignored on a client built by New", slog.String("setter", setter))
+	}
+	return true
+}
+
 // Globals
 const (
 	timestampKey  = "timestamp"
@@ -60,12 +125,6 @@ func PrettyPrint(i interface{}) string {
 	return string(s)
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
-	if c.Debug {
-		c.Logger.Printf(format, v...)
-	}
-}
-
 // newDefaultHTTPClient 返回一个针对 QE 服务端做了连接复用优化的 *http.Client。
 //
 // 调整动机：标准库的 http.DefaultClient/DefaultTransport 默认 MaxIdleConnsPerHost=2，
@@ -130,7 +189,7 @@ func NewTestClient(apiKey string, secretKey string, baseURL ...string) *Client {
 	}
 }
 
-func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
+func (c *Client) parseRequest(ctx context.Context, r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
 		opt(r)
@@ -140,12 +199,21 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		return err
 	}
 
-	fullURL := fmt.Sprintf("%s%s", c.BaseURL, r.endpoint)
+	if r.baseURL == "" {
+		r.baseURL = c.baseURL()
+	}
+	if r.creds == nil {
+		r.creds = c.credentials()
+	}
+	fullURL := fmt.Sprintf("%s%s", r.baseURL, r.endpoint)
+	if r.recvWindow == 0 && r.secType == secTypeSigned {
+		r.recvWindow = c.recvWindow
+	}
 	if r.recvWindow > 0 {
 		r.setParam(recvWindowKey, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+		r.setParam(timestampKey, c.timestamp())
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -154,24 +222,23 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if r.header != nil {
 		header = r.header.Clone()
 	}
-	header.Set("User-Agent", fmt.Sprintf("%s/%s", Name, Version))
+	header.Set("User-Agent", c.userAgent())
 	if bodyString != "" {
 		header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body = bytes.NewBufferString(bodyString)
 	}
 	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
+		header.Set("X-MBX-APIKEY", r.creds.APIKey)
 	}
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		signature, err := r.creds.sign(ctx, []byte(raw))
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, signature)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -181,7 +248,6 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
 	r.fullURL = fullURL
 	r.header = header
 	r.body = body
@@ -189,66 +255,178 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 }
 
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
-	err = c.parseRequest(r, opts...)
+	env := new(Envelope[json.RawMessage])
+	if err := c.callAPIEnvelope(ctx, r, env, opts...); err != nil {
+		return nil, err
+	}
+	return env.Message, nil
+}
+
+// callAPIEnvelope performs a V1-style request and decodes the response into
+// env.
+func (c *Client) callAPIEnvelope(ctx context.Context, r *request, env envelope, opts ...RequestOption) error {
+	err := c.parseRequest(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return err
+	}
+	rl := newRequestLog(r.method, r.endpoint)
+	rl.url, rl.params, rl.meta = r.fullURL, r.query, r.meta
+	rl.masterOrderID = r.query.Get("masterOrderId")
+	if rl.masterOrderID == "" {
+		rl.masterOrderID = r.form.Get("masterOrderId")
 	}
-	req, err := http.NewRequest(r.method, r.fullURL, r.body)
+	rl.base, rl.pinned = r.baseURL, r.pinned
+	if r.method == http.MethodGet {
+		rl.resign = c.resignURL(ctx, r)
+	}
+	if b, ok := r.body.(*bytes.Buffer); ok {
+		rl.body = b.Bytes()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, r.fullURL, r.body)
 	if err != nil {
-		return []byte{}, err
+		return err
 	}
-	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
-	f := c.do
-	if f == nil {
-		f = c.HTTPClient.Do
+	var cacheKey string
+	if r.method == http.MethodGet && !r.noCache {
+		cacheKey = readCacheKey(r.creds.APIKey, r.endpoint, r.query)
 	}
-	res, err := f(req)
-	if err != nil {
-		return []byte{}, err
+	return c.send(ctx, rl, req, env, cacheKey)
+}
+
+// callAPIV2Envelope signs and performs a V2 request with a JSON body and
+// decodes the response into env. The request is signed the same way the
+// backend's `apiAuth.CollectParamsAndBodyForSign` middleware verifies it:
+// signature = HMAC-SHA256(secret, urlValues.Encode()) where urlValues is the
+// merge of URL query keys (timestamp, recvWindow, ...) and the JSON body's
+// top-level keys, sorted by key.
+func (c *Client) callAPIV2Envelope(ctx context.Context, method, endpoint string, body params, env envelope, opts ...RequestOption) error {
+	r := &request{secType: secTypeSigned}
+	for _, opt := range opts {
+		opt(r)
 	}
-	data, err = io.ReadAll(res.Body)
-	if err != nil {
-		return []byte{}, err
-	}
-	defer func() {
-		cerr := res.Body.Close()
-		// Only overwrite the retured error if the original error was nil and an
-		// error occurred while closing the body.
-		if err == nil && cerr != nil {
-			err = cerr
+	if r.recvWindow == 0 {
+		r.recvWindow = c.recvWindow
+	}
+	creds := c.credentials()
+
+	timestamp := c.timestamp()
+	tsStr := strconv.FormatInt(timestamp, 10)
+
+	// Build JSON body from non-nil params.
+	var bodyBytes []byte
+	if len(body) > 0 {
+		// json.Marshal preserves int/float/bool/string/array/map shapes; the
+		// backend uses dec.UseNumber() so numbers we emit as numbers are
+		// converted via json.Number.String() for signing — exactly what we
+		// reproduce below.
+		var err error
+		bodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Re-parse body bytes to mirror the backend's signing logic. This is the
+	// safest way to keep the SDK and server in lock-step even when the body
+	// contains nested arrays/objects (e.g. batch-cancel `masterOrderIds`).
+	signValues := url.Values{}
+	if len(bodyBytes) > 0 {
+		var obj map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(bodyBytes))
+		dec.UseNumber()
+		if err := dec.Decode(&obj); err != nil {
+			return err
 		}
-	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
-	if res.StatusCode >= http.StatusBadRequest {
-		apiErr := new(handlers.APIError)
-		e := json.Unmarshal(data, apiErr)
-		if e != nil {
-			c.debug("failed to unmarshal json: %s", e)
+		for k, v := range obj {
+			if strings.EqualFold(k, "signature") || strings.EqualFold(k, "timestamp") {
+				continue
+			}
+			if s, ok := scalarToSignString(v); ok {
+				signValues.Add(k, s)
+				continue
+			}
+			// Arrays / nested objects — backend stringifies via json.Marshal.
+			if encoded, err := json.Marshal(v); err == nil {
+				signValues.Add(k, string(encoded))
+			}
 		}
-		return nil, apiErr
 	}
-	var respData *handlers.APISuccess
-	err = json.Unmarshal(data, &respData)
+	signValues.Set("timestamp", tsStr)
+	if r.recvWindow > 0 {
+		signValues.Set(recvWindowKey, strconv.FormatInt(r.recvWindow, 10))
+	}
+
+	signature, err := creds.sign(ctx, []byte(signValues.Encode()))
 	if err != nil {
-		c.debug("failed to unmarshal json: %s", err)
-		return nil, err
+		return err
+	}
+
+	// Compose URL — timestamp/recvWindow/signature go into the query string
+	// alongside the JSON body, matching the backend signing middleware which
+	// reads timestamp from query first.
+	q := url.Values{}
+	q.Set(timestampKey, tsStr)
+	if r.recvWindow > 0 {
+		q.Set(recvWindowKey, strconv.FormatInt(r.recvWindow, 10))
+	}
+	q.Set(signatureKey, signature)
+
+	if r.baseURL == "" {
+		r.baseURL = c.baseURL()
+	}
+	fullURL := fmt.Sprintf("%s%s?%s", r.baseURL, endpoint, q.Encode())
+
+	var bodyReader io.Reader
+	if len(bodyBytes) > 0 {
+		bodyReader = bytes.NewReader(bodyBytes)
 	}
-	if respData.Code != 200 {
-		c.debug("response status code: %d", respData.Code)
-		return nil, &handlers.APIError{
-			Code:       respData.Code,
-			Reason:     respData.Reason,
-			Message:    respData.Message,
-			TraceId:    respData.TraceId,
-			ServerTime: respData.ServerTime,
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+	req.Header.Set("X-MBX-APIKEY", creds.APIKey)
+	if len(bodyBytes) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rl := newRequestLog(method, endpoint)
+	rl.url, rl.body, rl.meta = fullURL, bodyBytes, r.meta
+	rl.masterOrderID, _ = body["masterOrderId"].(string)
+	rl.base, rl.pinned = r.baseURL, r.pinned
+	return c.send(ctx, rl, req, env, "")
+}
+
+// scalarToSignString mirrors backend `scalarToString` (gin/middleware.go) so
+// the SDK and server agree on the canonical form of every scalar JSON value.
+// Returns false for non-scalars (arrays, objects) — callers fall back to
+// json.Marshal in that case.
+func scalarToSignString(v interface{}) (string, bool) {
+	switch tv := v.(type) {
+	case string:
+		return tv, true
+	case json.Number:
+		return tv.String(), true
+	case float64:
+		if math.IsNaN(tv) || math.IsInf(tv, 0) {
+			return "", false
 		}
+		return strconv.FormatFloat(tv, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(tv), true
+	case int:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int32:
+		return strconv.FormatInt(int64(tv), 10), true
+	case int64:
+		return strconv.FormatInt(tv, 10), true
+	case nil:
+		return fmt.Sprint(tv), true
+	default:
+		return "", false
 	}
-	return json.Marshal(respData.Message)
 }
 
 func newJSON(data []byte) (j *simplejson.Json, err error) {
@@ -259,42 +437,6 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-func (c *Client) NewListExchangeApisService() *ListExchangeApisService {
-	return &ListExchangeApisService{c: c}
-}
-func (c *Client) NewGetMasterOrdersService() *GetMasterOrdersService {
-	return &GetMasterOrdersService{c: c}
-}
-func (c *Client) NewGetOrderFillsService() *GetOrderFillsService {
-	return &GetOrderFillsService{c: c}
-}
-func (c *Client) NewGetMasterOrderDetailService() *GetMasterOrderDetailService {
-	return &GetMasterOrderDetailService{c: c}
-}
-func (c *Client) NewCreateMasterOrderService() *CreateMasterOrderService {
-	return &CreateMasterOrderService{c: c}
-}
-func (c *Client) NewCancelMasterOrderService() *CancelMasterOrderService {
-	return &CancelMasterOrderService{c: c}
-}
-func (c *Client) NewPauseMasterOrderService() *PauseMasterOrderService {
-	return &PauseMasterOrderService{c: c}
-}
-func (c *Client) NewResumeMasterOrderService() *ResumeMasterOrderService {
-	return &ResumeMasterOrderService{c: c}
-}
-func (c *Client) NewUpdateMasterOrderParamsService() *UpdateMasterOrderParamsService {
-	return &UpdateMasterOrderParamsService{c: c}
-}
-func (c *Client) NewCreateListenKeyService() *CreateListenKeyService {
-	return &CreateListenKeyService{c: c}
-}
-func (c *Client) NewCreateListenKeyV2Service() *CreateListenKeyV2Service {
-	return &CreateListenKeyV2Service{c: c}
-}
-func (c *Client) NewGetTcaAnalysisService() *GetTcaAnalysisService {
-	return &GetTcaAnalysisService{c: c}
-}
 func (c *Client) NewTradingPairsService() *TradingPairsService {
 	return &TradingPairsService{c: c}
 }
@@ -309,182 +451,3 @@ func (c *Client) NewTimestampService() *TimestampService {
 func (c *Client) NewWebSocketService(host ...string) *WebSocketService {
 	return NewWebSocketService(c, host...)
 }
-
-// NewGetAccountBalanceService create service for getting Binance spot account balance
-func (c *Client) NewGetAccountBalanceService() *GetAccountBalanceService {
-	return &GetAccountBalanceService{c: c}
-}
-
-// NewGetMarginBalanceService create service for getting Binance futures account balance
-func (c *Client) NewGetMarginBalanceService() *GetMarginBalanceService {
-	return &GetMarginBalanceService{c: c}
-}
-
-// NewGetPv1BalanceService create service for getting Binance PAPI PV1 balance
-func (c *Client) NewGetPv1BalanceService() *GetPv1BalanceService {
-	return &GetPv1BalanceService{c: c}
-}
-
-// NewGetOkxAccountBalanceService create service for getting OKX account balance
-func (c *Client) NewGetOkxAccountBalanceService() *GetOkxAccountBalanceService {
-	return &GetOkxAccountBalanceService{c: c}
-}
-
-// NewGetFapiPositionSideDialService create service for getting Binance FAPI position side dual status
-func (c *Client) NewGetFapiPositionSideDialService() *GetFapiPositionSideDialService {
-	return &GetFapiPositionSideDialService{c: c}
-}
-
-// NewGetPapiUmPositionSideDualService create service for getting Binance PAPI UM position side dual status
-func (c *Client) NewGetPapiUmPositionSideDualService() *GetPapiUmPositionSideDualService {
-	return &GetPapiUmPositionSideDualService{c: c}
-}
-
-// NewGetOkxAccountPositionsService create service for getting OKX account positions
-func (c *Client) NewGetOkxAccountPositionsService() *GetOkxAccountPositionsService {
-	return &GetOkxAccountPositionsService{c: c}
-}
-
-// NewGetOkxAccountMaxSizeService create service for getting OKX account max order size
-func (c *Client) NewGetOkxAccountMaxSizeService() *GetOkxAccountMaxSizeService {
-	return &GetOkxAccountMaxSizeService{c: c}
-}
-
-// NewGetLtpPositionService create service for getting LTP account positions
-func (c *Client) NewGetLtpPositionService() *GetLtpPositionService {
-	return &GetLtpPositionService{c: c}
-}
-
-// NewGetDeribitPositionService create service for getting Deribit account positions
-func (c *Client) NewGetDeribitPositionService() *GetDeribitPositionService {
-	return &GetDeribitPositionService{c: c}
-}
-
-// NewGetUmAccountService create service for getting Binance PAPI UM account
-func (c *Client) NewGetUmAccountService() *GetUmAccountService {
-	return &GetUmAccountService{c: c}
-}
-
-// NewGetCmAccountService create service for getting Binance PAPI CM account
-func (c *Client) NewGetCmAccountService() *GetCmAccountService {
-	return &GetCmAccountService{c: c}
-}
-
-// NewGetPv1AccountService create service for getting Binance PAPI PV1 account
-func (c *Client) NewGetPv1AccountService() *GetPv1AccountService {
-	return &GetPv1AccountService{c: c}
-}
-
-// NewGetDapiAccountService create service for getting Binance DAPI account
-func (c *Client) NewGetDapiAccountService() *GetDapiAccountService {
-	return &GetDapiAccountService{c: c}
-}
-
-// NewGetFapiAccountService create service for getting Binance FAPI account
-func (c *Client) NewGetFapiAccountService() *GetFapiAccountService {
-	return &GetFapiAccountService{c: c}
-}
-
-// NewGetCrossMarginAccountDetailService create service for getting Binance cross margin account detail
-func (c *Client) NewGetCrossMarginAccountDetailService() *GetCrossMarginAccountDetailService {
-	return &GetCrossMarginAccountDetailService{c: c}
-}
-
-// NewGetLtpAccountService create service for getting LTP account info
-func (c *Client) NewGetLtpAccountService() *GetLtpAccountService {
-	return &GetLtpAccountService{c: c}
-}
-
-// NewGetLtpPortfolioAssetService create service for getting LTP portfolio assets
-func (c *Client) NewGetLtpPortfolioAssetService() *GetLtpPortfolioAssetService {
-	return &GetLtpPortfolioAssetService{c: c}
-}
-
-// NewGetDeribitAccountService create service for getting Deribit account info
-func (c *Client) NewGetDeribitAccountService() *GetDeribitAccountService {
-	return &GetDeribitAccountService{c: c}
-}
-
-// NewGetHyperliquidSpotBalanceService create service for getting Hyperliquid spot balance
-func (c *Client) NewGetHyperliquidSpotBalanceService() *GetHyperliquidSpotBalanceService {
-	return &GetHyperliquidSpotBalanceService{c: c}
-}
-
-// NewGetHyperliquidPositionsService create service for getting Hyperliquid perpetual positions
-func (c *Client) NewGetHyperliquidPositionsService() *GetHyperliquidPositionsService {
-	return &GetHyperliquidPositionsService{c: c}
-}
-
-// ============================================================================
-//  V2 service constructors (`/strategy-api/.../v2/...`).
-//
-//  V2 is additive: V1 services keep working unchanged. See user_v2.go for
-//  the V2 request/response types and `frontend-v2-api-upgrade.md` for the
-//  field-by-field reference.
-// ============================================================================
-
-// NewListExchangeApisV2Service creates a service for `GET /user/exchange/v2/exchange-apis`.
-func (c *Client) NewListExchangeApisV2Service() *ListExchangeApisV2Service {
-	return &ListExchangeApisV2Service{c: c}
-}
-
-// NewCreateMasterOrderV2Service creates a service for `POST /user/trading/v2/master-orders`.
-func (c *Client) NewCreateMasterOrderV2Service() *CreateMasterOrderV2Service {
-	return &CreateMasterOrderV2Service{c: c}
-}
-
-// NewGetMasterOrdersV2Service creates a service for `GET /user/trading/v2/master-orders`.
-func (c *Client) NewGetMasterOrdersV2Service() *GetMasterOrdersV2Service {
-	return &GetMasterOrdersV2Service{c: c}
-}
-
-// NewGetMasterOrderDetailV2Service creates a service for `GET /user/trading/v2/master-orders/{masterOrderId}`.
-func (c *Client) NewGetMasterOrderDetailV2Service() *GetMasterOrderDetailV2Service {
-	return &GetMasterOrderDetailV2Service{c: c}
-}
-
-// NewGetMasterOrderDetailByClientOrderIdV2Service creates a service for
-// `GET /user/trading/v2/master-orders/by-client-order-id/{clientOrderId}`.
-func (c *Client) NewGetMasterOrderDetailByClientOrderIdV2Service() *GetMasterOrderDetailByClientOrderIdV2Service {
-	return &GetMasterOrderDetailByClientOrderIdV2Service{c: c}
-}
-
-// NewGetOrderFillsV2Service creates a service for `GET /user/trading/v2/order-fills`.
-func (c *Client) NewGetOrderFillsV2Service() *GetOrderFillsV2Service {
-	return &GetOrderFillsV2Service{c: c}
-}
-
-// NewGetTCAAnalysisV2Service creates a service for `GET /user/trading/v2/tca-analysis`.
-func (c *Client) NewGetTCAAnalysisV2Service() *GetTCAAnalysisV2Service {
-	return &GetTCAAnalysisV2Service{c: c}
-}
-
-// NewCancelMasterOrderV2Service creates a service for
-// `PUT /user/trading/v2/master-orders/{masterOrderId}/cancel`.
-func (c *Client) NewCancelMasterOrderV2Service() *CancelMasterOrderV2Service {
-	return &CancelMasterOrderV2Service{c: c}
-}
-
-// NewPauseMasterOrderV2Service creates a service for
-// `PUT /user/trading/v2/master-orders/{masterOrderId}/pause`.
-func (c *Client) NewPauseMasterOrderV2Service() *PauseMasterOrderV2Service {
-	return &PauseMasterOrderV2Service{c: c}
-}
-
-// NewResumeMasterOrderV2Service creates a service for
-// `PUT /user/trading/v2/master-orders/{masterOrderId}/resume`.
-func (c *Client) NewResumeMasterOrderV2Service() *ResumeMasterOrderV2Service {
-	return &ResumeMasterOrderV2Service{c: c}
-}
-
-// NewUpdateMasterOrderParamsV2Service creates a service for
-// `PUT /user/trading/v2/master-orders/{masterOrderId}/update`.
-func (c *Client) NewUpdateMasterOrderParamsV2Service() *UpdateMasterOrderParamsV2Service {
-	return &UpdateMasterOrderParamsV2Service{c: c}
-}
-
-// NewBatchCancelMasterOrdersV2Service creates a service for
-// `PUT /user/trading/v2/master-orders/batch-cancel`.
-func (c *Client) NewBatchCancelMasterOrdersV2Service() *BatchCancelMasterOrdersV2Service {
-	return &BatchCancelMasterOrdersV2Service{c: c}
-}
@@ -0,0 +1,381 @@
+package qe_connector
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Environment selects the default endpoints of New.
+type Environment string
+
+const (
+	EnvProd Environment = "prod"
+	EnvTest Environment = "test"
+)
+
+// Default endpoints per Environment. EnvProd has no default WebSocket
+// host: set one with WithWSHost or FailoverConfig.WSHosts.
+const (
+	ProdBaseURL = "https://api.quantumexecute.com"
+	TestBaseURL = "https://testapi.quantumexecute.com"
+	TestWSHost  = "wss://test.quantumexecute.com"
+)
+
+// maxRecvWindow is the largest recvWindow the API accepts, in milliseconds.
+const maxRecvWindow = 60000
+
+// ClientOption configures New.
+type ClientOption func(*clientConfig) error
+
+type clientConfig struct {
+	env        Environment
+	apiKey     string
+	secretKey  string
+	signer     Signer
+	baseURL    string
+	wsHost     string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	slogger    *slog.Logger
+	recvWindow int64
+	uaSuffix   string
+	autoOffset bool
+	timeOffset int64
+	retry      *RetryPolicy
+	limiter    *RateLimiter
+	failover   *FailoverConfig
+	instr      Instrumentation
+	cache      *ReadCache
+	hedge      *HedgeConfig
+}
+
+// New creates a Client from options and validates the result:
+//
+//	client, err := qe.New(
+//		qe.WithEnvironment(qe.EnvTest),
+//		qe.WithCredentials(apiKey, secretKey),
+//		qe.WithDefaultRecvWindow(10*time.Second),
+//		qe.WithRetry(qe.RetryPolicy{MaxAttempts: 3}),
+//	)
+//
+// Unlike NewClient, everything is configured and checked before the client
+// is shared, and then fixed: requests keep using what New set even if the
+// exported fields are changed, and setters such as SetRetry or SetSigner
+// are ignored (with a warning on the client's logger), so the client is
+// safe to share between goroutines. Credentials can still be switched with
+// RotateCredentials. Without WithCredentials or WithSigner only public
+// endpoints work.
+func New(opts ...ClientOption) (*Client, error) {
+	cfg := clientConfig{env: EnvProd}
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	switch cfg.env {
+	case EnvProd:
+		if cfg.baseURL == "" {
+			cfg.baseURL = ProdBaseURL
+		}
+	case EnvTest:
+		if cfg.baseURL == "" {
+			cfg.baseURL = TestBaseURL
+		}
+		if cfg.wsHost == "" {
+			cfg.wsHost = TestWSHost
+		}
+	default:
+		return nil, fmt.Errorf("qe: unknown environment %q", cfg.env)
+	}
+	if cfg.apiKey == "" && (cfg.secretKey != "" || cfg.signer != nil) {
+		return nil, errors.New("qe: a secret key or signer needs an API key")
+	}
+	if cfg.apiKey != "" && cfg.secretKey == "" && cfg.signer == nil {
+		return nil, errors.New("qe: an API key needs a secret key or signer")
+	}
+	if cfg.httpClient != nil && cfg.transport != nil {
+		return nil, errors.New("qe: WithHTTPClient and WithTransport are mutually exclusive")
+	}
+	if cfg.failover != nil {
+		for _, u := range slices.Concat(cfg.failover.BaseURLs, cfg.failover.WSHosts) {
+			if err := checkURL(u); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	hc := cfg.httpClient
+	if hc == nil {
+		hc = newDefaultHTTPClient()
+		if cfg.transport != nil {
+			hc.Transport = cfg.transport
+		}
+	}
+	if cfg.limiter != nil {
+		rt := hc.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		limited := *hc
+		limited.Transport = &rateLimitedTransport{base: rt, limiter: cfg.limiter}
+		hc = &limited
+	}
+
+	c := &Client{
+		APIKey:     cfg.apiKey,
+		SecretKey:  cfg.secretKey,
+		BaseURL:    cfg.baseURL,
+		HTTPClient: hc,
+		Logger:     log.New(os.Stderr, Name, log.LstdFlags),
+		TimeOffset: cfg.timeOffset,
+		Signer:     cfg.signer,
+		slogger:    cfg.slogger,
+		instr:      cfg.instr,
+		cache:      cfg.cache,
+		wsHost:     cfg.wsHost,
+		recvWindow: cfg.recvWindow,
+		uaSuffix:   cfg.uaSuffix,
+		needWSHost: cfg.env == EnvProd,
+	}
+	c.SetAutoTimeOffset(cfg.autoOffset).SetRetry(cfg.retry).SetHedging(cfg.hedge)
+	if cfg.failover != nil {
+		c.SetFailover(*cfg.failover)
+	}
+	c.creds.Store(&credentialSet{Credentials: Credentials{APIKey: c.APIKey, SecretKey: c.SecretKey, Signer: c.Signer}})
+	s := c.settings()
+	c.fixed = &s
+	return c, nil
+}
+
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("qe: invalid URL %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("qe: URL %q needs an http(s) or ws(s) scheme", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("qe: URL %q has no host", raw)
+	}
+	return nil
+}
+
+// WithEnvironment selects the default base URL and WebSocket host:
+// EnvProd (the default) or EnvTest. Under EnvProd WebSocketServices need
+// a host from WithWSHost, WithFailover or NewWebSocketService.
+func WithEnvironment(env Environment) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.env = env
+		return nil
+	}
+}
+
+// WithCredentials sets the API key and the secret it is signed with.
+func WithCredentials(apiKey, secretKey string) ClientOption {
+	return func(cfg *clientConfig) error {
+		if apiKey == "" || secretKey == "" {
+			return errors.New("qe: WithCredentials needs an API key and a secret key")
+		}
+		cfg.apiKey, cfg.secretKey = apiKey, secretKey
+		return nil
+	}
+}
+
+// WithSigner sets the API key and a Signer that signs its requests in place
+// of a secret key (see SetSigner).
+func WithSigner(apiKey string, s Signer) ClientOption {
+	return func(cfg *clientConfig) error {
+		if apiKey == "" || s == nil {
+			return errors.New("qe: WithSigner needs an API key and a signer")
+		}
+		cfg.apiKey, cfg.signer = apiKey, s
+		return nil
+	}
+}
+
+// WithBaseURL overrides the environment's REST base URL.
+func WithBaseURL(u string) ClientOption {
+	return func(cfg *clientConfig) error {
+		if err := checkURL(u); err != nil {
+			return err
+		}
+		cfg.baseURL = strings.TrimSuffix(u, "/")
+		return nil
+	}
+}
+
+// WithWSHost sets the host WebSocketServices created without one connect
+// to.
+func WithWSHost(host string) ClientOption {
+	return func(cfg *clientConfig) error {
+		if err := checkURL(host); err != nil {
+			return err
+		}
+		cfg.wsHost = strings.TrimSuffix(host, "/")
+		return nil
+	}
+}
+
+// WithHTTPClient sends requests with hc instead of a client tuned for the
+// QE gateways.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(cfg *clientConfig) error {
+		if hc == nil {
+			return errors.New("qe: WithHTTPClient needs a client")
+		}
+		cfg.httpClient = hc
+		return nil
+	}
+}
+
+// WithTransport keeps the default HTTP client settings but sends requests
+// through rt.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) error {
+		if rt == nil {
+			return errors.New("qe: WithTransport needs a transport")
+		}
+		cfg.transport = rt
+		return nil
+	}
+}
+
+// WithLogger sends the client's logs to l (see SetSlogLogger).
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.slogger = l
+		return nil
+	}
+}
+
+// WithDefaultRecvWindow sets the recvWindow of signed requests that do not
+// pass WithRecvWindow. It must be positive and at most 60 seconds.
+func WithDefaultRecvWindow(d time.Duration) ClientOption {
+	return func(cfg *clientConfig) error {
+		ms := d.Milliseconds()
+		if ms <= 0 || ms > maxRecvWindow {
+			return fmt.Errorf("qe: recvWindow %v out of range (0, 60s]", d)
+		}
+		cfg.recvWindow = ms
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix, e.g. "my-desk/2.1", to the
+// User-Agent header.
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(cfg *clientConfig) error {
+		if strings.ContainsAny(suffix, "\r\n") {
+			return errors.New("qe: invalid User-Agent suffix")
+		}
+		cfg.uaSuffix = suffix
+		return nil
+	}
+}
+
+// WithTimeOffset sets a fixed clock offset, local minus server time in
+// milliseconds (see Client.TimeOffset).
+func WithTimeOffset(ms int64) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.timeOffset = ms
+		return nil
+	}
+}
+
+// WithClockSync estimates the clock offset from response serverTimes (see
+// SetAutoTimeOffset).
+func WithClockSync() ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.autoOffset = true
+		return nil
+	}
+}
+
+// WithRetry retries failed calls according to p (see SetRetry).
+func WithRetry(p RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) error {
+		if p.MaxAttempts < 0 || p.Backoff < 0 || p.MaxBackoff < 0 {
+			return errors.New("qe: negative retry policy value")
+		}
+		cfg.retry = &p
+		return nil
+	}
+}
+
+// WithRateLimit allows rate requests per second on average with bursts of
+// up to burst requests.
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return func(cfg *clientConfig) error {
+		if rate <= 0 {
+			return fmt.Errorf("qe: rate limit %v must be positive", rate)
+		}
+		cfg.limiter = NewRateLimiter(rate, burst)
+		return nil
+	}
+}
+
+// WithRateLimiter shares l with other clients.
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(cfg *clientConfig) error {
+		if l == nil {
+			return errors.New("qe: WithRateLimiter needs a limiter")
+		}
+		cfg.limiter = l
+		return nil
+	}
+}
+
+// WithFailover spreads the client over several gateways (see SetFailover).
+// The first of f.BaseURLs overrides the environment's base URL.
+func WithFailover(f FailoverConfig) ClientOption {
+	return func(cfg *clientConfig) error {
+		f.BaseURLs, f.WSHosts = trimSlashes(f.BaseURLs), trimSlashes(f.WSHosts)
+		cfg.failover = &f
+		return nil
+	}
+}
+
+// WithInstrumentation reports calls to i (see SetInstrumentation).
+func WithInstrumentation(i Instrumentation) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.instr = i
+		return nil
+	}
+}
+
+// WithReadCache routes GETs through rc (see SetReadCache).
+func WithReadCache(rc *ReadCache) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.cache = rc
+		return nil
+	}
+}
+
+// WithHedging hedges slow reads (see SetHedging).
+func WithHedging(h HedgeConfig) ClientOption {
+	return func(cfg *clientConfig) error {
+		cfg.hedge = &h
+		return nil
+	}
+}
+
+// userAgent returns the User-Agent header value.
+func (c *Client) userAgent() string {
+	ua := fmt.Sprintf("%s/%s", Name, Version)
+	if c.uaSuffix != "" {
+		ua += " " + c.uaSuffix
+	}
+	return ua
+}
@@ -0,0 +1,139 @@
+package qe_connector_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func TestNewDefaults(t *testing.T) {
+	c, err := qe.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseURL != qe.ProdBaseURL || c.HTTPClient == nil {
+		t.Fatalf("BaseURL = %q, HTTPClient = %v", c.BaseURL, c.HTTPClient)
+	}
+	c, err = qe.New(qe.WithEnvironment(qe.EnvTest), qe.WithCredentials("k", "s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseURL != qe.TestBaseURL || c.APIKey != "k" || c.SecretKey != "s" {
+		t.Fatalf("client = %+v", c)
+	}
+}
+
+func TestNewValidates(t *testing.T) {
+	for name, opts := range map[string][]qe.ClientOption{
+		"environment":   {qe.WithEnvironment("staging")},
+		"base URL":      {qe.WithBaseURL("api.quantumexecute.com")},
+		"WS host":       {qe.WithWSHost("ftp://example.com")},
+		"credentials":   {qe.WithCredentials("k", "")},
+		"signer":        {qe.WithSigner("k", nil)},
+		"recvWindow":    {qe.WithDefaultRecvWindow(2 * time.Minute)},
+		"rate":          {qe.WithRateLimit(0, 1)},
+		"retry":         {qe.WithRetry(qe.RetryPolicy{MaxAttempts: -1})},
+		"user agent":    {qe.WithUserAgentSuffix("a\r\nX-Evil: 1")},
+		"failover":      {qe.WithFailover(qe.FailoverConfig{BaseURLs: []string{"nope"}})},
+		"http client":   {qe.WithHTTPClient(nil)},
+		"client+transp": {qe.WithHTTPClient(http.DefaultClient), qe.WithTransport(http.DefaultTransport)},
+	} {
+		if _, err := qe.New(opts...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNewAppliesOptions(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c, err := qe.New(
+		qe.WithEnvironment(qe.EnvTest),
+		qe.WithBaseURL(srv.URL),
+		qe.WithWSHost(srv.WSHost()),
+		qe.WithCredentials(testAPIKey, testSecretKey),
+		qe.WithDefaultRecvWindow(7*time.Second),
+		qe.WithUserAgentSuffix("desk/1.0"),
+		qe.WithRateLimit(1000, 10),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lk, err := c.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithRecvWindow(3000)); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("got %d requests", len(reqs))
+	}
+	if got := reqs[0].Query.Get("recvWindow"); got != "7000" {
+		t.Errorf("default recvWindow = %q", got)
+	}
+	if got := reqs[1].Query.Get("recvWindow"); got != "3000" {
+		t.Errorf("per-request recvWindow = %q", got)
+	}
+	if ua := reqs[0].Header.Get("User-Agent"); !strings.HasSuffix(ua, " desk/1.0") {
+		t.Errorf("User-Agent = %q", ua)
+	}
+
+	// The WebSocket connects to WithWSHost without being told.
+	ws := c.NewWebSocketService()
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := srv.WaitForWSClients(1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClientIgnoresLaterChanges(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	var logs bytes.Buffer
+	c, err := qe.New(
+		qe.WithBaseURL(srv.URL),
+		qe.WithCredentials(testAPIKey, testSecretKey),
+		qe.WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests in flight are unaffected by writes to the fields, and the
+	// setters leave the client as New built it.
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 5; i++ {
+			if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	c.BaseURL, c.SecretKey, c.HTTPClient, c.TimeOffset = "http://127.0.0.1:1", "wrong-secret", nil, 1e9
+	c.SetSigner(failingSigner{}).SetSlogLogger(nil)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatalf("after changes: %v", err)
+	}
+	for _, setter := range []string{"SetSigner", "SetSlogLogger"} {
+		if !strings.Contains(logs.String(), `"setter":"`+setter+`"`) {
+			t.Errorf("no warning for %s:\n%s", setter, logs.String())
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package qe_connector
 
 import (
+	"bytes"
 	"context"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"testing"
@@ -239,3 +244,86 @@ func TestTca(t *testing.T) {
 	}
 	t.Logf("%#v", do)
 }
+
+func TestClientLoggerIsCachedUntilSettingsChange(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewClient("api-key-old", "secret-key-old").SetSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	first := c.logger()
+	if c.logger() != first {
+		t.Fatal("logger rebuilt without a change")
+	}
+
+	if err := c.RotateCredentials(context.Background(), Credentials{APIKey: "api-key-new", SecretKey: "secret-key-new"}); err != nil {
+		t.Fatal(err)
+	}
+	rotated := c.logger()
+	if rotated == first {
+		t.Fatal("logger not rebuilt after RotateCredentials")
+	}
+	rotated.Info("secret-key-new api-key-old")
+	if strings.Contains(buf.String(), "secret-key-new") || strings.Contains(buf.String(), "api-key-old") {
+		t.Fatalf("rotated logger leaks credentials: %s", buf.String())
+	}
+
+	c.SetSlogLogger(nil)
+	c.Debug = false
+	if c.logger() != nil {
+		t.Fatal("logging should be off")
+	}
+	c.Debug = true
+	if c.logger() == nil {
+		t.Fatal("legacy logger not picked up")
+	}
+}
+
+func TestConcurrentRotationsKeepEverySecret(t *testing.T) {
+	c := NewClient("api-key-gen-0", "secret-key-gen-0")
+	ctx := context.Background()
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 1; i <= 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			gen := strconv.Itoa(i)
+			if err := c.RotateCredentials(ctx, Credentials{APIKey: "api-key-gen-" + gen, SecretKey: "secret-key-gen-" + gen}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	got := c.credentials().secrets()
+	for i := 0; i <= 16; i++ {
+		gen := strconv.Itoa(i)
+		if !slices.Contains(got, "api-key-gen-"+gen) || !slices.Contains(got, "secret-key-gen-"+gen) {
+			t.Fatalf("secrets = %v, want generation %s redacted", got, gen)
+		}
+	}
+}
+
+func TestRetiredSecretsStayRedactedAcrossRotations(t *testing.T) {
+	c := NewClient("api-key-gen-1", "secret-key-gen-1")
+	ctx := context.Background()
+	for _, gen := range []string{"2", "3"} {
+		if err := c.RotateCredentials(ctx, Credentials{APIKey: "api-key-gen-" + gen, SecretKey: "secret-key-gen-" + gen}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := strings.Join(c.credentials().secrets(), ",")
+	for _, gen := range []string{"1", "2", "3"} {
+		if !strings.Contains(got, "secret-key-gen-"+gen) || !strings.Contains(got, "api-key-gen-"+gen) {
+			t.Fatalf("secrets = %s, want generation %s redacted", got, gen)
+		}
+	}
+
+	// Past the grace period a retired secret is dropped.
+	cs := c.credentials()
+	cs.retired[0].until = time.Now().Add(-time.Second)
+	next := cs.replace(Credentials{APIKey: "api-key-gen-4", SecretKey: "secret-key-gen-4"})
+	if got := next.secrets(); slices.Contains(got, "api-key-gen-1") || !slices.Contains(got, "secret-key-gen-1") {
+		t.Fatalf("secrets = %v, want only generation 1's API key expired", got)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+func (a *app) fills(ctx context.Context, args []string) error {
+	fs := a.flags("fills")
+	masterOrderId := fs.String("master-order-id", "", "master order ID")
+	orderId := fs.String("order-id", "", "child order ID")
+	clientOrderId := fs.String("client-order-id", "", "client order ID of the master order")
+	symbol := fs.String("symbol", "", "symbol")
+	status := fs.String("status", "", "child order status")
+	start := fs.String("start", "", "start time")
+	end := fs.String("end", "", "end time")
+	page := fs.Int("page", 1, "page")
+	pageSize := fs.Int("page-size", 50, "page size")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	s := a.client.NewGetOrderFillsV2Service().Page(int32(*page)).PageSize(int32(*pageSize))
+	for v, set := range map[*string]func(string) *qe.GetOrderFillsV2Service{
+		masterOrderId: s.MasterOrderId, orderId: s.OrderId, clientOrderId: s.ClientOrderId,
+		symbol: s.Symbol, status: s.Status, start: s.StartTime, end: s.EndTime,
+	} {
+		if *v != "" {
+			set(*v)
+		}
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, fillColumns)
+}
+
+func (a *app) tca(ctx context.Context, args []string) error {
+	fs := a.flags("tca")
+	symbol := fs.String("symbol", "", "symbol")
+	category := fs.String("category", "", "spot, perp or perp_cm")
+	strategy := fs.String("strategy", "", "strategy, e.g. TWAP")
+	apiKeyId := fs.String("api-key-id", "", "exchange API key ID")
+	since := fs.Duration("since", 0, "only orders from this long ago, e.g. 24h")
+	start := fs.Int64("start-ms", 0, "start time in epoch milliseconds")
+	end := fs.Int64("end-ms", 0, "end time in epoch milliseconds")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	s := a.client.NewGetTCAAnalysisV2Service()
+	if *symbol != "" {
+		s.Symbol(*symbol)
+	}
+	if *category != "" {
+		s.Category(*category)
+	}
+	if *strategy != "" {
+		s.Strategy(*strategy)
+	}
+	if *apiKeyId != "" {
+		s.ApiKeyId(*apiKeyId)
+	}
+	if *since > 0 {
+		*start = time.Now().Add(-*since).UnixMilli()
+	}
+	if *start > 0 {
+		s.StartTime(*start)
+	}
+	if *end > 0 {
+		s.EndTime(*end)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, []string{
+		"masterOrderId", "date", "strategy", "category", "filledNotional", "arrivalPrice", "averageFillPrice",
+		"slippagePct", "twapSlippagePct", "vwapSlippagePct", "makerRate", "executionRate",
+	})
+}
+
+func (a *app) pairs(ctx context.Context, args []string) error {
+	fs := a.flags("pairs")
+	exchange := fs.String("exchange", "", "exchange")
+	marketType := fs.String("market-type", "", "SPOT or FUTURES")
+	isCoin := fs.Bool("coin", false, "coin-margined contracts only")
+	page := fs.Int("page", 1, "page")
+	pageSize := fs.Int("page-size", 100, "page size")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	s := a.client.NewTradingPairsService().Page(int32(*page)).PageSize(int32(*pageSize))
+	if *exchange != "" {
+		s.Exchange(trading_enums.Exchange(*exchange))
+	}
+	if *marketType != "" {
+		s.MarketType(trading_enums.TradingPairMarketType(*marketType))
+	}
+	if *isCoin {
+		s.IsCoin(true)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, nil)
+}
+
+func (a *app) keys(ctx context.Context, args []string) error {
+	fs := a.flags("keys")
+	exchange := fs.String("exchange", "", "exchange")
+	page := fs.Int("page", 1, "page")
+	pageSize := fs.Int("page-size", 50, "page size")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	s := a.client.NewListExchangeApisV2Service().Page(int32(*page)).PageSize(int32(*pageSize))
+	if *exchange != "" {
+		s.Exchange(trading_enums.Exchange(*exchange))
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, []string{"apiKeyId", "accountName", "exchange", "apiKey", "status", "isValid", "isTradingEnabled", "isDefault"})
+}
+
+type balanceFunc func(c *qe.Client, ctx context.Context, bindingId string) (interface{}, error)
+
+// balances maps exchange → account kind → query. defaultAccount names the
+// kind used when --account is omitted.
+var (
+	balances = map[trading_enums.Exchange]map[string]balanceFunc{
+		trading_enums.ExchangeBinance: {
+			"spot": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetAccountBalanceService().BindingId(id).Do(ctx)
+			},
+			"margin": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetMarginBalanceService().BindingId(id).Do(ctx)
+			},
+			"cross-margin": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetCrossMarginAccountDetailService().BindingId(id).Do(ctx)
+			},
+			"pv1": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetPv1BalanceService().BindingId(id).Do(ctx)
+			},
+			"um": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetUmAccountService().BindingId(id).Do(ctx)
+			},
+			"cm": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetCmAccountService().BindingId(id).Do(ctx)
+			},
+			"fapi": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetFapiAccountService().BindingId(id).Do(ctx)
+			},
+			"dapi": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetDapiAccountService().BindingId(id).Do(ctx)
+			},
+		},
+		trading_enums.ExchangeOKX: {
+			"balance": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetOkxAccountBalanceService().BindingId(id).Do(ctx)
+			},
+			"positions": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetOkxAccountPositionsService().BindingId(id).Do(ctx)
+			},
+		},
+		trading_enums.ExchangeLTP: {
+			"account": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetLtpAccountService().BindingId(id).Do(ctx)
+			},
+			"portfolio": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetLtpPortfolioAssetService().BindingId(id).Do(ctx)
+			},
+		},
+		trading_enums.ExchangeDeribit: {
+			"account": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetDeribitAccountService().BindingId(id).Do(ctx)
+			},
+			"positions": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetDeribitPositionService().BindingId(id).Do(ctx)
+			},
+		},
+		trading_enums.ExchangeHyperliquid: {
+			"spot": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetHyperliquidSpotBalanceService().BindingId(id).Do(ctx)
+			},
+			"positions": func(c *qe.Client, ctx context.Context, id string) (interface{}, error) {
+				return c.NewGetHyperliquidPositionsService().BindingId(id).Do(ctx)
+			},
+		},
+	}
+	defaultAccount = map[trading_enums.Exchange]string{
+		trading_enums.ExchangeBinance:     "spot",
+		trading_enums.ExchangeOKX:         "balance",
+		trading_enums.ExchangeLTP:         "account",
+		trading_enums.ExchangeDeribit:     "account",
+		trading_enums.ExchangeHyperliquid: "spot",
+	}
+)
+
+func (a *app) balance(ctx context.Context, args []string) error {
+	fs := a.flags("balance")
+	bindingId := fs.String("binding-id", "", "exchange API key ID (required)")
+	account := fs.String("account", "", "account kind; defaults per exchange")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 || *bindingId == "" {
+		fmt.Fprintln(a.stderr, "Usage: qe balance <exchange> --binding-id ID [--account KIND]")
+		return errUsage
+	}
+	var exchange trading_enums.Exchange
+	for ex := range balances {
+		if strings.EqualFold(string(ex), pos[0]) {
+			exchange = ex
+		}
+	}
+	kinds, ok := balances[exchange]
+	if !ok {
+		return fmt.Errorf("no balance query for exchange %q", pos[0])
+	}
+	kind := *account
+	if kind == "" {
+		kind = defaultAccount[exchange]
+	}
+	query, ok := kinds[kind]
+	if !ok {
+		names := make([]string, 0, len(kinds))
+		for k := range kinds {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("%s accounts are %s", exchange, strings.Join(names, ", "))
+	}
+	res, err := query(a.client, ctx, *bindingId)
+	if err != nil {
+		return err
+	}
+	return a.print(res, nil)
+}
+
+func (a *app) ping(ctx context.Context, args []string) error {
+	start := time.Now()
+	if err := a.client.NewPingServer().Do(ctx); err != nil {
+		return err
+	}
+	return a.print(map[string]string{"status": "ok", "latency": time.Since(start).Round(time.Millisecond).String()}, nil)
+}
+
+func (a *app) serverTime(ctx context.Context, args []string) error {
+	ms, err := a.client.NewTimestampService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	server := time.UnixMilli(ms)
+	return a.print(map[string]interface{}{
+		"serverTime": server.UTC().Format(time.RFC3339Nano),
+		"epochMs":    ms,
+		"offset":     time.Until(server).Round(time.Millisecond).String(),
+	}, nil)
+}
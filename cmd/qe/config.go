@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config holds connection settings for one profile.
+type config struct {
+	APIKey    string `yaml:"apiKey"`
+	SecretKey string `yaml:"secretKey"`
+	BaseURL   string `yaml:"baseURL"`
+	WSHost    string `yaml:"wsHost"`
+}
+
+// configFile is the profile file layout:
+//
+//	default: prod
+//	profiles:
+//	  prod:
+//	    apiKey: ...
+//	    secretKey: ...
+//	  test:
+//	    apiKey: ...
+//	    secretKey: ...
+//	    baseURL: https://testapi.quantumexecute.com
+//	    wsHost: wss://test.quantumexecute.com
+type configFile struct {
+	Default  string            `yaml:"default"`
+	Profiles map[string]config `yaml:"profiles"`
+}
+
+// merge returns c with every non-empty field of o applied on top.
+func (c config) merge(o config) config {
+	for dst, src := range map[*string]string{
+		&c.APIKey: o.APIKey, &c.SecretKey: o.SecretKey, &c.BaseURL: o.BaseURL, &c.WSHost: o.WSHost,
+	} {
+		if src != "" {
+			*dst = src
+		}
+	}
+	return c
+}
+
+// loadConfig resolves the profile settings overlaid with the environment.
+// A missing config file is only an error when it or a profile was asked for
+// explicitly.
+func loadConfig(getenv func(string) string, path, profile string) (config, error) {
+	explicit := path != "" || getenv("QE_CONFIG") != ""
+	if path == "" {
+		path = getenv("QE_CONFIG")
+	}
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "qe", "config.yaml")
+		}
+	}
+	if profile == "" {
+		profile = getenv("QE_PROFILE")
+	}
+
+	var cfg config
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var f configFile
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return cfg, fmt.Errorf("config %s: %w", path, err)
+		}
+		name := profile
+		if name == "" {
+			name = f.Default
+		}
+		if name != "" {
+			p, ok := f.Profiles[name]
+			if !ok {
+				return cfg, fmt.Errorf("config %s: no profile %q", path, name)
+			}
+			cfg = p
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicit && profile == "":
+	default:
+		return cfg, fmt.Errorf("config: %w", err)
+	}
+
+	return cfg.merge(config{
+		APIKey:    getenv("QE_API_KEY"),
+		SecretKey: getenv("QE_SECRET_KEY"),
+		BaseURL:   getenv("QE_BASE_URL"),
+		WSHost:    getenv("QE_WS_HOST"),
+	}), nil
+}
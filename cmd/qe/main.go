@@ -0,0 +1,192 @@
+// Command qe manages Quantum Execute master orders from the shell.
+//
+// Usage:
+//
+//	qe [global flags] <command> [flags] [args]
+//
+// Commands:
+//
+//	orders list|get|create|cancel|pause|resume|update|batch-cancel
+//	fills         list child order fills
+//	tca           TCA analysis rows
+//	pairs         trading pairs
+//	keys          exchange API keys
+//	balance <ex>  exchange account balance (Binance, OKX, LTP, Deribit, Hyperliquid)
+//	ping          check connectivity
+//	time          server time
+//	stream        tail master order and fill pushes over WebSocket
+//	watch         full-screen dashboard with pause / resume / cancel hotkeys
+//
+// Credentials are read from flags, then the QE_API_KEY / QE_SECRET_KEY /
+// QE_BASE_URL / QE_WS_HOST environment variables, then the selected profile
+// of the config file (see loadConfig).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+// errUsage marks errors that already printed usage.
+var errUsage = errors.New("usage")
+
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+	getenv func(string) string
+
+	cfg    config
+	format string
+	dryRun bool
+	yes    bool
+	client *qe.Client
+}
+
+type command struct {
+	summary string
+	run     func(a *app, ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"orders":  {"list, inspect and manage master orders", (*app).orders},
+	"fills":   {"list child order fills", (*app).fills},
+	"tca":     {"TCA analysis rows", (*app).tca},
+	"pairs":   {"list trading pairs", (*app).pairs},
+	"keys":    {"list exchange API keys", (*app).keys},
+	"balance": {"exchange account balance: balance <exchange> --binding-id ID", (*app).balance},
+	"ping":    {"check connectivity", (*app).ping},
+	"time":    {"print server time", (*app).serverTime},
+	"stream":  {"tail master order and fill pushes", (*app).stream},
+	"watch":   {"full-screen dashboard of running master orders", (*app).watch},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	a := &app{stdout: os.Stdout, stderr: os.Stderr, stdin: os.Stdin, getenv: os.Getenv}
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "qe:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("qe", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	var ov config
+	profile := fs.String("profile", "", "config profile (default $QE_PROFILE or the file's default)")
+	configPath := fs.String("config", "", "config file (default $QE_CONFIG or <user config dir>/qe/config.yaml)")
+	fs.StringVar(&ov.APIKey, "api-key", "", "API key")
+	fs.StringVar(&ov.SecretKey, "secret-key", "", "secret key")
+	fs.StringVar(&ov.BaseURL, "base-url", "", "REST base URL")
+	fs.StringVar(&ov.WSHost, "ws-host", "", "WebSocket host (default wss://test.quantumexecute.com)")
+	fs.StringVar(&a.format, "o", "table", "output format: table, json or csv")
+	fs.BoolVar(&a.dryRun, "dry-run", false, "print mutations instead of sending them")
+	fs.BoolVar(&a.yes, "y", false, "do not ask for confirmation")
+	fs.Usage = func() { a.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	switch a.format {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("unknown output format %q", a.format)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(a.stderr, "qe: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := loadConfig(a.getenv, *configPath, *profile)
+	if err != nil {
+		return err
+	}
+	a.cfg = cfg.merge(ov)
+	if a.cfg.APIKey == "" || a.cfg.SecretKey == "" {
+		return errors.New("missing credentials: set QE_API_KEY and QE_SECRET_KEY or configure a profile")
+	}
+	a.client = qe.NewClient(a.cfg.APIKey, a.cfg.SecretKey)
+	if a.cfg.BaseURL != "" {
+		a.client.BaseURL = a.cfg.BaseURL
+	}
+	return cmd.run(a, ctx, fs.Args()[1:])
+}
+
+func (a *app) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: qe [global flags] <command> [flags] [args]\n\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %-8s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(a.stderr, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
+
+// flags returns a sub-command flag set writing errors to stderr.
+func (a *app) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("qe "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parse parses args allowing flags after positional arguments, as in
+// `qe orders cancel ID --reason x`.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// confirm asks the user to approve a destructive action unless -y was given.
+func (a *app) confirm(prompt string) error {
+	if a.yes {
+		return nil
+	}
+	fmt.Fprintf(a.stderr, "%s [y/N]: ", prompt)
+	var answer string
+	fmt.Fscanln(a.stdin, &answer)
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.New("aborted")
+}
+
+// mutate prints what would be sent in dry-run mode and reports whether the
+// caller should go ahead.
+func (a *app) mutate(action string, payload interface{}) (bool, error) {
+	if !a.dryRun {
+		return true, nil
+	}
+	return false, a.print(map[string]interface{}{"dryRun": action, "request": payload}, nil)
+}
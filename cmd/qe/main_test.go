@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+type harness struct {
+	t   *testing.T
+	srv *qetest.Server
+	env map[string]string
+}
+
+func newHarness(t *testing.T) *harness {
+	srv := qetest.NewServer("cli-key", "cli-secret")
+	t.Cleanup(srv.Close)
+	empty := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(empty, nil, 0o600)
+	return &harness{t: t, srv: srv, env: map[string]string{
+		"QE_API_KEY":    "cli-key",
+		"QE_SECRET_KEY": "cli-secret",
+		"QE_BASE_URL":   srv.URL,
+		"QE_WS_HOST":    srv.WSHost(),
+		"QE_CONFIG":     empty,
+	}}
+}
+
+// qe runs the CLI with stdin and returns stdout, stderr and the error.
+func (h *harness) qe(stdin string, args ...string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	a := &app{stdout: &stdout, stderr: &stderr, stdin: strings.NewReader(stdin),
+		getenv: func(k string) string { return h.env[k] }}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := a.run(ctx, args)
+	return stdout.String(), stderr.String(), err
+}
+
+func (h *harness) ok(args ...string) string {
+	h.t.Helper()
+	out, errOut, err := h.qe("", args...)
+	if err != nil {
+		h.t.Fatalf("qe %s: %v\n%s", strings.Join(args, " "), err, errOut)
+	}
+	return out
+}
+
+func TestOrdersLifecycle(t *testing.T) {
+	h := newHarness(t)
+	h.ok("ping")
+
+	out := h.ok("-o", "json", "orders", "create", "--api-key-id", "b1", "--exchange", "Binance",
+		"--market-type", "SPOT", "--symbol", "BTCUSDT", "--side", "buy", "--algorithm", "TWAP",
+		"--duration", "600", "--qty", "1.5", "--client-order-id", "cli-1", "--must-complete=false")
+	var created qe.CreateMasterOrderV2Reply
+	if err := json.Unmarshal([]byte(out), &created); err != nil || created.MasterOrderId == "" {
+		t.Fatalf("create output %q: %v", out, err)
+	}
+	id := created.MasterOrderId
+	if mo, ok := h.srv.MasterOrder(id); !ok || mo.MustComplete == nil || *mo.MustComplete {
+		t.Fatalf("stored order = %#v", mo)
+	}
+
+	csvOut := h.ok("-o", "csv", "orders", "list", "--symbol", "BTCUSDT")
+	lines := strings.Split(strings.TrimSpace(csvOut), "\n")
+	if len(lines) != 2 || lines[0] != strings.Join(orderColumns, ",") || !strings.HasPrefix(lines[1], id+",cli-1,BTCUSDT,buy,TWAP,NEW,1.5") {
+		t.Fatalf("orders list csv:\n%s", csvOut)
+	}
+	if out := h.ok("orders", "get", "--client-order-id", "cli-1"); !strings.Contains(out, "masterOrderId") || !strings.Contains(out, id) {
+		t.Fatalf("orders get table:\n%s", out)
+	}
+
+	h.ok("orders", "update", id, "--pov-limit", "0.2")
+	h.ok("orders", "pause", id, "--reason", "ops")
+	if got, _ := h.srv.MasterOrder(id); *got.PovLimit != "0.2" || got.Status != "PAUSED" {
+		t.Fatalf("after update/pause: povLimit=%v status=%s", *got.PovLimit, got.Status)
+	}
+
+	// Cancels need confirmation; dry runs send nothing.
+	if _, _, err := h.qe("n\n", "orders", "cancel", id); err == nil || err.Error() != "aborted" {
+		t.Fatalf("declined cancel err = %v", err)
+	}
+	if out := h.ok("--dry-run", "orders", "cancel", id); !strings.Contains(out, "dryRun") {
+		t.Fatalf("dry run output:\n%s", out)
+	}
+	if got, _ := h.srv.MasterOrder(id); got.Status != "PAUSED" {
+		t.Fatal("cancel was sent without confirmation")
+	}
+	if _, errOut, err := h.qe("y\n", "orders", "cancel", id); err != nil {
+		t.Fatalf("confirmed cancel: %v\n%s", err, errOut)
+	}
+	if got, _ := h.srv.MasterOrder(id); got.Status != "CANCELLED" {
+		t.Fatal("order not cancelled")
+	}
+}
+
+func TestCreateFromTemplateDryRun(t *testing.T) {
+	h := newHarness(t)
+	tpl := filepath.Join(t.TempDir(), "twap.yaml")
+	os.WriteFile(tpl, []byte("apiKeyId: b1\nexchange: Binance\nmarketType: SPOT\nside: buy\n"+
+		"algorithm: TWAP\nexecutionDurationSeconds: 300\nsymbol: ${symbol}\ntotalQuantity: ${qty}\n"), 0o600)
+
+	out := h.ok("-o", "json", "--dry-run", "orders", "create", "-f", tpl, "--var", "symbol=ETHUSDT", "--var", "qty=3", "--pov-limit", "0.1")
+	var got struct {
+		Request qe.OrderTemplate `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Request.Symbol != "ETHUSDT" || *got.Request.TotalQuantity != "3" || *got.Request.PovLimit != "0.1" {
+		t.Fatalf("dry-run request = %#v", got.Request)
+	}
+	if reqs := h.srv.Requests(); len(reqs) != 0 {
+		t.Fatalf("dry run sent %d requests", len(reqs))
+	}
+}
+
+func TestProfilesAndStream(t *testing.T) {
+	h := newHarness(t)
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(cfg, []byte("default: local\nprofiles:\n  local:\n    apiKey: cli-key\n    secretKey: cli-secret\n"+
+		"    baseURL: "+h.srv.URL+"\n    wsHost: "+h.srv.WSHost()+"\n  broken:\n    apiKey: x\n"), 0o600)
+	h.env = map[string]string{"QE_CONFIG": cfg}
+
+	if out := h.ok("time"); !strings.Contains(out, "serverTime") {
+		t.Fatalf("time output:\n%s", out)
+	}
+	if _, _, err := h.qe("", "--profile", "broken", "ping"); err == nil || !strings.Contains(err.Error(), "missing credentials") {
+		t.Fatalf("broken profile err = %v", err)
+	}
+
+	done := make(chan string)
+	go func() {
+		out, _, _ := h.qe("", "-o", "json", "stream", "--count", "1")
+		done <- out
+	}()
+	if err := h.srv.WaitForWSClients(1, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.srv.PushOrderFill(&qe.OrderFillV2Info{MasterOrderId: "mo-9", FilledQuantity: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case out := <-done:
+		if !strings.Contains(out, `"type":"fill"`) || !strings.Contains(out, "mo-9") {
+			t.Fatalf("stream output:\n%s", out)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("stream did not exit after one event")
+	}
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+var orderColumns = []string{
+	"masterOrderId", "clientOrderId", "symbol", "side", "algorithm", "status",
+	"totalQuantity", "orderNotional", "cumFilledQty", "avgFilledPrice", "createdAt",
+}
+
+var fillColumns = []string{
+	"orderCreatedTime", "masterOrderId", "orderId", "symbol", "side", "orderType",
+	"filledQuantity", "averagePrice", "filledNotional", "status",
+}
+
+func (a *app) orders(ctx context.Context, args []string) error {
+	sub := map[string]func(context.Context, []string) error{
+		"list":         a.ordersList,
+		"get":          a.ordersGet,
+		"create":       a.ordersCreate,
+		"cancel":       a.ordersCancel,
+		"pause":        a.ordersPause,
+		"resume":       a.ordersResume,
+		"update":       a.ordersUpdate,
+		"batch-cancel": a.ordersBatchCancel,
+	}
+	if len(args) == 0 || sub[args[0]] == nil {
+		fmt.Fprintln(a.stderr, "Usage: qe orders list|get|create|cancel|pause|resume|update|batch-cancel [flags]")
+		return errUsage
+	}
+	return sub[args[0]](ctx, args[1:])
+}
+
+func (a *app) ordersList(ctx context.Context, args []string) error {
+	fs := a.flags("orders list")
+	status := fs.String("status", "", "NEW (running) or COMPLETED (finished)")
+	symbol := fs.String("symbol", "", "symbol")
+	exchange := fs.String("exchange", "", "exchange")
+	algorithm := fs.String("algorithm", "", "TWAP, VWAP or POV")
+	apiKeyId := fs.String("api-key-id", "", "exchange API key ID")
+	start := fs.String("start", "", "start time")
+	end := fs.String("end", "", "end time")
+	page := fs.Int("page", 1, "page")
+	pageSize := fs.Int("page-size", 20, "page size")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	s := a.client.NewGetMasterOrdersV2Service().Page(int32(*page)).PageSize(int32(*pageSize))
+	if *status != "" {
+		s.Status(qe.MasterOrderStatusV2(*status))
+	}
+	if *symbol != "" {
+		s.Symbol(*symbol)
+	}
+	if *exchange != "" {
+		s.Exchange(*exchange)
+	}
+	if *algorithm != "" {
+		s.Algorithm(trading_enums.Algorithm(*algorithm))
+	}
+	if *apiKeyId != "" {
+		s.ApiKeyId(*apiKeyId)
+	}
+	if *start != "" {
+		s.StartTime(*start)
+	}
+	if *end != "" {
+		s.EndTime(*end)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, orderColumns)
+}
+
+func (a *app) ordersGet(ctx context.Context, args []string) error {
+	fs := a.flags("orders get")
+	clientOrderId := fs.String("client-order-id", "", "look up by client order ID instead of master order ID")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	var res *qe.GetMasterOrderDetailV2Reply
+	switch {
+	case *clientOrderId != "":
+		res, err = a.client.NewGetMasterOrderDetailByClientOrderIdV2Service().ClientOrderId(*clientOrderId).Do(ctx)
+	case len(pos) == 1:
+		res, err = a.client.NewGetMasterOrderDetailV2Service().MasterOrderId(pos[0]).Do(ctx)
+	default:
+		fmt.Fprintln(a.stderr, "Usage: qe orders get <masterOrderId> | --client-order-id ID")
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+	return a.print(&res.MasterOrder, nil)
+}
+
+func (a *app) ordersCreate(ctx context.Context, args []string) error {
+	fs := a.flags("orders create")
+	file := fs.String("f", "", "order template file (.yaml, .yml or .json)")
+	vars := varsFlag{}
+	fs.Var(vars, "var", "template variable name=value (repeatable)")
+	t := &qe.OrderTemplate{}
+	fs.StringVar(&t.ApiKeyId, "api-key-id", "", "exchange API key ID")
+	fs.Var(enumFlag[trading_enums.Exchange]{&t.Exchange}, "exchange", "exchange, e.g. Binance")
+	fs.Var(enumFlag[trading_enums.MarketType]{&t.MarketType}, "market-type", "SPOT or PERP")
+	fs.StringVar(&t.Symbol, "symbol", "", "symbol, e.g. BTCUSDT")
+	fs.Var(enumFlag[trading_enums.OrderSide]{&t.Side}, "side", "buy or sell")
+	fs.Var(enumFlag[trading_enums.Algorithm]{&t.Algorithm}, "algorithm", "TWAP, VWAP or POV")
+	fs.Var(optInt{&t.ExecutionDurationSeconds}, "duration", "execution duration in seconds")
+	fs.Var(optInt{&t.StartTimeMs}, "start-ms", "start time in epoch milliseconds")
+	fs.Var(optString{&t.TotalQuantity}, "qty", "total quantity")
+	fs.Var(optString{&t.OrderNotional}, "notional", "order notional")
+	fs.Var(optEnum[trading_enums.MarginType]{&t.MarginType}, "margin-type", "U or C")
+	fs.Var(optBool{&t.ReduceOnly}, "reduce-only", "reduce only")
+	fs.Var(optBool{&t.IsMargin}, "is-margin", "spot margin")
+	fs.Var(optBool{&t.IsTargetPosition}, "target-position", "quantity is a target position")
+	fs.Var(optString{&t.ClientOrderId}, "client-order-id", "client order ID")
+	fs.Var(optString{&t.Notes}, "notes", "notes")
+	orderParamFlags(fs, t)
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	base := &qe.OrderTemplate{}
+	if *file != "" {
+		loaded, err := qe.LoadOrderTemplate(*file)
+		if err != nil {
+			return err
+		}
+		base = loaded
+	}
+	svc, err := a.client.NewCreateMasterOrderV2ServiceFromTemplate(base.With(t), vars)
+	if err != nil {
+		return err
+	}
+	if ok, err := a.mutate("create", svc.Template()); !ok {
+		return err
+	}
+	res, err := svc.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, nil)
+}
+
+// orderParamFlags registers the execution parameters shared by create and
+// update.
+func orderParamFlags(fs *flag.FlagSet, t *qe.OrderTemplate) {
+	fs.Var(optString{&t.WorstPrice}, "worst-price", "stop trading beyond this price")
+	fs.Var(optString{&t.PovLimit}, "pov-limit", "max share of market volume")
+	fs.Var(optString{&t.PovMinLimit}, "pov-min-limit", "min share of market volume")
+	fs.Var(optString{&t.MakerRateLimit}, "maker-rate-limit", "min maker share, -1 lets the algorithm decide")
+	fs.Var(optString{&t.UpTolerance}, "up-tolerance", "allowed lead over the schedule, -1 for none")
+	fs.Var(optString{&t.LowTolerance}, "low-tolerance", "allowed lag behind the schedule, -1 for none")
+	fs.Var(optBool{&t.MustComplete}, "must-complete", "catch up to finish within the duration")
+	fs.Var(optBool{&t.StrictUpBound}, "strict-up-bound", "never exceed the upper bound")
+	fs.Var(optBool{&t.TailOrderProtection}, "tail-order-protection", "fill a below-minimum tail with the last child")
+	fs.Var(optBool{&t.EnableMake}, "enable-make", "allow maker child orders")
+}
+
+func (a *app) ordersUpdate(ctx context.Context, args []string) error {
+	fs := a.flags("orders update")
+	t := &qe.OrderTemplate{}
+	fs.Var(optString{&t.TotalQuantity}, "qty", "total quantity")
+	fs.Var(optString{&t.OrderNotional}, "notional", "order notional")
+	fs.Var(optInt{&t.ExecutionDurationSeconds}, "duration", "execution duration in seconds")
+	orderParamFlags(fs, t)
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		fmt.Fprintln(a.stderr, "Usage: qe orders update <masterOrderId> [--pov-limit X ...]")
+		return errUsage
+	}
+	s := a.client.NewUpdateMasterOrderParamsV2Service().MasterOrderId(pos[0])
+	changes := map[string]interface{}{}
+	setString := func(name string, v *string, f func(string) *qe.UpdateMasterOrderParamsV2Service) {
+		if v != nil {
+			f(*v)
+			changes[name] = *v
+		}
+	}
+	setBool := func(name string, v *bool, f func(bool) *qe.UpdateMasterOrderParamsV2Service) {
+		if v != nil {
+			f(*v)
+			changes[name] = *v
+		}
+	}
+	setString("totalQuantity", t.TotalQuantity, s.TotalQuantity)
+	setString("orderNotional", t.OrderNotional, s.OrderNotional)
+	setString("worstPrice", t.WorstPrice, s.WorstPrice)
+	setString("povLimit", t.PovLimit, s.PovLimit)
+	setString("povMinLimit", t.PovMinLimit, s.PovMinLimit)
+	setString("makerRateLimit", t.MakerRateLimit, s.MakerRateLimit)
+	setString("upTolerance", t.UpTolerance, s.UpTolerance)
+	setString("lowTolerance", t.LowTolerance, s.LowTolerance)
+	setBool("mustComplete", t.MustComplete, s.MustComplete)
+	setBool("strictUpBound", t.StrictUpBound, s.StrictUpBound)
+	setBool("tailOrderProtection", t.TailOrderProtection, s.TailOrderProtection)
+	setBool("enableMake", t.EnableMake, s.EnableMake)
+	if t.ExecutionDurationSeconds != nil {
+		s.ExecutionDurationSeconds(*t.ExecutionDurationSeconds)
+		changes["executionDurationSeconds"] = *t.ExecutionDurationSeconds
+	}
+	if len(changes) == 0 {
+		return errors.New("nothing to update")
+	}
+	changes["masterOrderId"] = pos[0]
+	if ok, err := a.mutate("update", changes); !ok {
+		return err
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(res, nil)
+}
+
+// orderAction runs pause / resume / cancel for one master order.
+func (a *app) orderAction(ctx context.Context, name string, args []string, confirm bool,
+	do func(id, reason string) (*qe.MasterOrderActionV2Reply, error)) error {
+	fs := a.flags("orders " + name)
+	reason := fs.String("reason", "", "reason recorded with the action")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		fmt.Fprintf(a.stderr, "Usage: qe orders %s <masterOrderId> [--reason TEXT]\n", name)
+		return errUsage
+	}
+	if ok, err := a.mutate(name, map[string]string{"masterOrderId": pos[0], "reason": *reason}); !ok {
+		return err
+	}
+	if confirm {
+		if err := a.confirm(fmt.Sprintf("%s master order %s?", name, pos[0])); err != nil {
+			return err
+		}
+	}
+	res, err := do(pos[0], *reason)
+	if err != nil {
+		return err
+	}
+	return a.print(res, nil)
+}
+
+func (a *app) ordersCancel(ctx context.Context, args []string) error {
+	return a.orderAction(ctx, "cancel", args, true, func(id, reason string) (*qe.MasterOrderActionV2Reply, error) {
+		return a.client.NewCancelMasterOrderV2Service().MasterOrderId(id).Reason(reason).Do(ctx)
+	})
+}
+
+func (a *app) ordersPause(ctx context.Context, args []string) error {
+	return a.orderAction(ctx, "pause", args, false, func(id, reason string) (*qe.MasterOrderActionV2Reply, error) {
+		return a.client.NewPauseMasterOrderV2Service().MasterOrderId(id).Reason(reason).Do(ctx)
+	})
+}
+
+func (a *app) ordersResume(ctx context.Context, args []string) error {
+	return a.orderAction(ctx, "resume", args, false, func(id, reason string) (*qe.MasterOrderActionV2Reply, error) {
+		return a.client.NewResumeMasterOrderV2Service().MasterOrderId(id).Reason(reason).Do(ctx)
+	})
+}
+
+func (a *app) ordersBatchCancel(ctx context.Context, args []string) error {
+	fs := a.flags("orders batch-cancel")
+	reason := fs.String("reason", "", "reason recorded with the cancellation")
+	ids, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(a.stderr, "Usage: qe orders batch-cancel <masterOrderId>... [--reason TEXT]")
+		return errUsage
+	}
+	if ok, err := a.mutate("batch-cancel", map[string]interface{}{"masterOrderIds": ids, "reason": *reason}); !ok {
+		return err
+	}
+	if err := a.confirm(fmt.Sprintf("cancel %d master orders (%s)?", len(ids), strings.Join(ids, ", "))); err != nil {
+		return err
+	}
+	res, err := a.client.NewBatchCancelMasterOrdersV2Service().MasterOrderIds(ids).Reason(*reason).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if a.format == "json" || len(res.FailedOrders) == 0 {
+		return a.print(res, nil)
+	}
+	fmt.Fprintf(a.stderr, "cancelled %d, failed %d\n", res.SuccessCount, len(res.FailedOrders))
+	return a.print(res.FailedOrders, nil)
+}
+
+// Optional flag values: they stay nil unless the flag is given, so only
+// explicitly set parameters are sent.
+
+type optString struct{ p **string }
+
+func (o optString) String() string {
+	if o.p == nil || *o.p == nil {
+		return ""
+	}
+	return **o.p
+}
+
+func (o optString) Set(s string) error {
+	*o.p = &s
+	return nil
+}
+
+type optBool struct{ p **bool }
+
+func (o optBool) String() string {
+	if o.p == nil || *o.p == nil {
+		return ""
+	}
+	return strconv.FormatBool(**o.p)
+}
+
+func (o optBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*o.p = &v
+	return nil
+}
+
+func (o optBool) IsBoolFlag() bool { return true }
+
+type optInt struct{ p **int64 }
+
+func (o optInt) String() string {
+	if o.p == nil || *o.p == nil {
+		return ""
+	}
+	return strconv.FormatInt(**o.p, 10)
+}
+
+func (o optInt) Set(s string) error {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*o.p = &v
+	return nil
+}
+
+type optEnum[T ~string] struct{ p **T }
+
+func (o optEnum[T]) String() string {
+	if o.p == nil || *o.p == nil {
+		return ""
+	}
+	return string(**o.p)
+}
+
+func (o optEnum[T]) Set(s string) error {
+	v := T(s)
+	*o.p = &v
+	return nil
+}
+
+type enumFlag[T ~string] struct{ p *T }
+
+func (e enumFlag[T]) String() string {
+	if e.p == nil {
+		return ""
+	}
+	return string(*e.p)
+}
+
+func (e enumFlag[T]) Set(s string) error {
+	*e.p = T(s)
+	return nil
+}
+
+// varsFlag collects repeated name=value pairs.
+type varsFlag map[string]string
+
+func (v varsFlag) String() string { return "" }
+
+func (v varsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("want name=value, got %q", s)
+	}
+	v[name] = value
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// print renders v in the selected format. JSON output is the full value;
+// table and CSV output flatten it to rows (the `items` of paged replies,
+// the elements of slices, or field/value pairs of a single struct) and keep
+// only columns when it is non-nil.
+func (a *app) print(v interface{}, columns []string) error {
+	if a.format == "json" {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	headers, rows := tabulate(v)
+	if columns != nil && len(headers) > 0 && headers[0] != "field" {
+		headers, rows = project(headers, rows, columns)
+	}
+	if a.format == "csv" {
+		w := csv.NewWriter(a.stdout)
+		w.Write(headers)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(headers, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func tabulate(v interface{}) (headers []string, rows [][]string) {
+	rv := indirect(reflect.ValueOf(v))
+	if rv.Kind() == reflect.Struct {
+		if items, ok := fieldByJSONName(rv, "items"); ok && items.Kind() == reflect.Slice {
+			rv = items
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		var elemType reflect.Type
+		for t := rv.Type().Elem(); ; t = t.Elem() {
+			if t.Kind() != reflect.Ptr {
+				elemType = t
+				break
+			}
+		}
+		if elemType.Kind() != reflect.Struct {
+			for i := 0; i < rv.Len(); i++ {
+				rows = append(rows, []string{cell(rv.Index(i))})
+			}
+			return []string{"value"}, rows
+		}
+		fields := jsonFields(elemType)
+		for _, f := range fields {
+			headers = append(headers, f.name)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem := indirect(rv.Index(i))
+			row := make([]string, len(fields))
+			for j, f := range fields {
+				if elem.IsValid() {
+					row[j] = cell(elem.FieldByIndex(f.index))
+				}
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows
+	case reflect.Struct:
+		for _, f := range jsonFields(rv.Type()) {
+			rows = append(rows, []string{f.name, cell(rv.FieldByIndex(f.index))})
+		}
+		return []string{"field", "value"}, rows
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			rows = append(rows, []string{fmt.Sprint(k), cell(rv.MapIndex(k))})
+		}
+		return []string{"field", "value"}, rows
+	}
+	return []string{"value"}, [][]string{{cell(rv)}}
+}
+
+func project(headers []string, rows [][]string, columns []string) ([]string, [][]string) {
+	pos := map[string]int{}
+	for i, h := range headers {
+		pos[h] = i
+	}
+	var keep []int
+	var out []string
+	for _, c := range columns {
+		if i, ok := pos[c]; ok {
+			keep = append(keep, i)
+			out = append(out, c)
+		}
+	}
+	projected := make([][]string, len(rows))
+	for r, row := range rows {
+		projected[r] = make([]string, len(keep))
+		for j, i := range keep {
+			projected[r][j] = row[i]
+		}
+	}
+	return out, projected
+}
+
+type jsonField struct {
+	name  string
+	index []int
+}
+
+// jsonFields lists the exported fields of t under their JSON names,
+// flattening embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var out []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for _, sub := range jsonFields(ft) {
+				out = append(out, jsonField{sub.name, append([]int{i}, sub.index...)})
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, jsonField{name, []int{i}})
+	}
+	return out
+}
+
+func fieldByJSONName(rv reflect.Value, name string) (reflect.Value, bool) {
+	for _, f := range jsonFields(rv.Type()) {
+		if f.name == name {
+			return rv.FieldByIndex(f.index), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func cell(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprint(v.Interface())
+		}
+		return string(b)
+	}
+	return fmt.Sprint(v.Interface())
+}
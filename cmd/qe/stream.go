@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+// stream tails master_data and order_data pushes. With -o json each event
+// is one JSON line; otherwise one human-readable line.
+func (a *app) stream(ctx context.Context, args []string) error {
+	fs := a.flags("stream")
+	masterOrderId := fs.String("master-order-id", "", "only events of this master order")
+	count := fs.Int("count", 0, "exit after this many events (0 = until interrupted)")
+	v1 := fs.Bool("v1", false, "use the legacy /api/ws endpoint")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var listenKey string
+	if *v1 {
+		res, err := a.client.NewCreateListenKeyService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		listenKey = res.ListenKey
+	} else {
+		res, err := a.client.NewCreateListenKeyV2Service().Do(ctx)
+		if err != nil {
+			return err
+		}
+		listenKey = res.ListenKey
+	}
+
+	lines := make(chan string, 64)
+	emit := func(kind, id, text string, v interface{}) {
+		if *masterOrderId != "" && id != *masterOrderId {
+			return
+		}
+		if a.format == "json" {
+			b, _ := json.Marshal(map[string]interface{}{"type": kind, "data": v})
+			text = string(b)
+		} else {
+			text = fmt.Sprintf("%s  %-6s  %s", time.Now().Format("15:04:05.000"), kind, text)
+		}
+		select {
+		case lines <- text:
+		case <-ctx.Done():
+		}
+	}
+	ws := a.client.NewWebSocketService(a.cfg.WSHost).SetHandlers(&qe.WebSocketEventHandlers{
+		OnMasterOrderDetail: func(m *qe.WsMasterOrderDetail) error {
+			emit("master", m.MasterOrderID, fmt.Sprintf("%s %s %s %s %s filled=%s avg=%s",
+				m.MasterOrderID, m.Symbol, m.Side, m.Algorithm, m.Status, m.CumFilledQty, m.AvgFilledPrice), m)
+			return nil
+		},
+		OnOrderFillDetail: func(f *qe.WsOrderFillDetail) error {
+			emit("fill", f.MasterOrderID, fmt.Sprintf("%s %s %s %s %s@%s %s",
+				f.MasterOrderID, f.OrderID, f.Symbol, f.Side, f.FilledQuantity, f.AveragePrice, f.Status), f)
+			return nil
+		},
+		OnError: func(err error) {
+			fmt.Fprintln(a.stderr, "qe: stream:", err)
+		},
+		OnDisconnected: func() {
+			fmt.Fprintln(a.stderr, "qe: stream disconnected, reconnecting")
+		},
+	})
+	if *v1 {
+		ws.UseV1()
+	}
+	if err := ws.Connect(listenKey); err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	for n := 0; *count == 0 || n < *count; n++ {
+		select {
+		case line := <-lines:
+			fmt.Fprintln(a.stdout, line)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
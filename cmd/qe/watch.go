@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"golang.org/x/term"
+)
+
+const (
+	watchMaxFills = 200
+	watchHelp     = "↑/↓ select  p pause  r resume  c cancel  q quit"
+)
+
+// watchOrder is the dashboard row of one master order.
+type watchOrder struct {
+	id, symbol, side, algorithm, status string
+	filled, total                       string
+	avgPrice, makerRate                 string
+	createdAt                           string
+}
+
+// watchModel is the dashboard state. It is fed by REST refreshes and WS
+// pushes and turns key presses into actions; rendering is a pure function
+// of the state so it can be tested without a terminal.
+type watchModel struct {
+	mu       sync.Mutex
+	orders   map[string]*watchOrder
+	fills    []string
+	selected string
+	status   string
+	confirm  *watchAction
+}
+
+// watchAction is a pause / resume / cancel request for one order.
+type watchAction struct {
+	verb string
+	id   string
+}
+
+func newWatchModel() *watchModel {
+	return &watchModel{orders: map[string]*watchOrder{}}
+}
+
+// replace sets the running orders from a REST listing.
+func (m *watchModel) replace(items []qe.MasterOrderV2Info) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders = map[string]*watchOrder{}
+	for _, o := range items {
+		row := &watchOrder{
+			id: o.MasterOrderId, symbol: o.Symbol, side: string(o.Side), algorithm: string(o.Algorithm),
+			status: string(o.Status), createdAt: o.CreatedAt,
+			filled: deref(o.CumFilledQty), total: deref(o.TotalQuantity),
+			avgPrice: deref(o.AvgFilledPrice), makerRate: deref(o.MakerRate),
+		}
+		if o.TotalQuantity == nil && o.OrderNotional != nil {
+			row.filled, row.total = deref(o.CumFilledNotional), *o.OrderNotional
+		}
+		m.orders[row.id] = row
+	}
+}
+
+// applyMaster upserts an order from a master_data push.
+func (m *watchModel) applyMaster(d *qe.WsMasterOrderDetail) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	row := &watchOrder{
+		id: d.MasterOrderID, symbol: d.Symbol, side: string(d.Side), algorithm: string(d.Algorithm),
+		status: string(d.Status), createdAt: d.CreatedAt,
+		filled: d.CumFilledQty.String(), total: d.TotalQuantity.String(),
+		avgPrice: d.AvgFilledPrice.String(), makerRate: d.MakerRate.String(),
+	}
+	if row.total == "" && d.OrderNotional != "" {
+		row.filled, row.total = d.CumFilledNotional.String(), d.OrderNotional.String()
+	}
+	m.orders[row.id] = row
+}
+
+// addFill appends an order_data push to the fills pane.
+func (m *watchModel) addFill(f *qe.WsOrderFillDetail) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	at := f.UpdatedAt
+	if t, err := time.Parse(time.RFC3339, at); err == nil {
+		at = t.Local().Format("15:04:05")
+	}
+	m.fills = append(m.fills, fmt.Sprintf("%s  %-10s %-4s %s @ %s  %s  %s",
+		at, f.Symbol, f.Side, f.FilledQuantity, f.AveragePrice, f.OrderType, f.MasterOrderID))
+	if len(m.fills) > watchMaxFills {
+		m.fills = m.fills[len(m.fills)-watchMaxFills:]
+	}
+}
+
+func (m *watchModel) setStatus(format string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = fmt.Sprintf(format, args...)
+}
+
+// rows returns the orders newest first. Callers hold m.mu.
+func (m *watchModel) rows() []*watchOrder {
+	rows := make([]*watchOrder, 0, len(m.orders))
+	for _, o := range m.orders {
+		rows = append(rows, o)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].createdAt != rows[j].createdAt {
+			return rows[i].createdAt > rows[j].createdAt
+		}
+		return rows[i].id < rows[j].id
+	})
+	return rows
+}
+
+// key handles one key press. It returns an action to run, or quit=true.
+func (m *watchModel) key(k string) (act *watchAction, quit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.confirm != nil {
+		act, m.confirm = m.confirm, nil
+		if k == "y" || k == "Y" {
+			return act, false
+		}
+		m.status = act.verb + " aborted"
+		return nil, false
+	}
+	rows := m.rows()
+	idx := -1
+	for i, r := range rows {
+		if r.id == m.selected {
+			idx = i
+		}
+	}
+	switch k {
+	case "q", "ctrl-c":
+		return nil, true
+	case "up", "k":
+		if idx > 0 {
+			m.selected = rows[idx-1].id
+		} else if len(rows) > 0 {
+			m.selected = rows[0].id
+		}
+	case "down", "j":
+		if idx+1 < len(rows) {
+			m.selected = rows[idx+1].id
+		}
+	case "p", "r", "c":
+		if idx < 0 {
+			m.status = "no order selected"
+			return nil, false
+		}
+		act = &watchAction{verb: map[string]string{"p": "pause", "r": "resume", "c": "cancel"}[k], id: m.selected}
+		if act.verb == "cancel" {
+			m.confirm = act
+			m.status = fmt.Sprintf("cancel %s? [y/N]", act.id)
+			return nil, false
+		}
+		return act, false
+	}
+	return nil, false
+}
+
+// render draws the dashboard for a width × height screen.
+func (m *watchModel) render(width, height int) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rows := m.rows()
+	if m.selected == "" && len(rows) > 0 {
+		m.selected = rows[0].id
+	}
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("qe watch — %d running master orders   %s", len(rows), time.Now().Format("15:04:05")))
+	lines = append(lines, fmt.Sprintf("  %-20s %-12s %-4s %-5s %-24s %7s %-14s %-6s %s",
+		"MASTER ORDER", "SYMBOL", "SIDE", "ALGO", "FILLED / TOTAL", "PROG", "AVG PRICE", "MAKER", "STATUS"))
+	// Orders get the top half; fills the rest.
+	orderRows := height/2 - 2
+	if orderRows < 1 {
+		orderRows = 1
+	}
+	for i, o := range rows {
+		if i >= orderRows {
+			lines = append(lines, fmt.Sprintf("  … %d more", len(rows)-i))
+			break
+		}
+		line := fmt.Sprintf("%-20s %-12s %-4s %-5s %-24s %7s %-14s %-6s %s",
+			o.id, o.symbol, o.side, o.algorithm, o.filled+" / "+o.total, progress(o.filled, o.total),
+			o.avgPrice, percent(o.makerRate), o.status)
+		if o.id == m.selected {
+			lines = append(lines, "\x1b[7m"+clip("> "+line, width)+"\x1b[0m")
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	lines = append(lines, "", "FILLS")
+	fillRows := height - len(lines) - 2
+	if fillRows < 0 {
+		fillRows = 0
+	}
+	fills := m.fills
+	if len(fills) > fillRows {
+		fills = fills[len(fills)-fillRows:]
+	}
+	for _, f := range fills {
+		lines = append(lines, "  "+f)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	status := m.status
+	if status == "" {
+		status = watchHelp
+	}
+	lines = append(lines, status)
+	for i, l := range lines {
+		if !strings.HasPrefix(l, "\x1b") {
+			lines[i] = clip(l, width)
+		}
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// clip cuts s to width runes.
+func clip(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width])
+}
+
+func progress(filled, total string) string {
+	f, err1 := strconv.ParseFloat(filled, 64)
+	t, err2 := strconv.ParseFloat(total, 64)
+	if err1 != nil || err2 != nil || t == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*f/t)
+}
+
+func percent(rate string) string {
+	r, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", 100*r)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// watch runs the full-screen dashboard until q or Ctrl-C.
+func (a *app) watch(ctx context.Context, args []string) error {
+	fs := a.flags("watch")
+	refresh := fs.Duration("refresh", 10*time.Second, "REST refresh interval for the order list")
+	symbol := fs.String("symbol", "", "only orders of this symbol")
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+	in, ok := a.stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) {
+		return fmt.Errorf("watch needs an interactive terminal")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m := newWatchModel()
+	load := func() {
+		s := a.client.NewGetMasterOrdersV2Service().Status(qe.MasterOrderStatusV2New).PageSize(100)
+		if *symbol != "" {
+			s.Symbol(*symbol)
+		}
+		res, err := s.Do(ctx)
+		if err != nil {
+			m.setStatus("refresh failed: %v", err)
+			return
+		}
+		m.replace(res.Items)
+	}
+	load()
+
+	lk, err := a.client.NewCreateListenKeyV2Service().Do(ctx)
+	if err != nil {
+		return err
+	}
+	redraw := make(chan struct{}, 1)
+	poke := func() {
+		select {
+		case redraw <- struct{}{}:
+		default:
+		}
+	}
+	ws := a.client.NewWebSocketService(a.cfg.WSHost).SetHandlers(&qe.WebSocketEventHandlers{
+		OnMasterOrderDetail: func(d *qe.WsMasterOrderDetail) error {
+			if *symbol == "" || d.Symbol == *symbol {
+				m.applyMaster(d)
+				poke()
+			}
+			return nil
+		},
+		OnOrderFillDetail: func(f *qe.WsOrderFillDetail) error {
+			if *symbol == "" || f.Symbol == *symbol {
+				m.addFill(f)
+				poke()
+			}
+			return nil
+		},
+		OnDisconnected: func() { m.setStatus("stream disconnected, reconnecting…"); poke() },
+		OnConnected:    func() { poke() },
+	})
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	fd := int(in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+	fmt.Fprint(a.stdout, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(a.stdout, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string)
+	go readKeys(in, keys)
+	ticker := time.NewTicker(*refresh)
+	defer ticker.Stop()
+	clock := time.NewTicker(time.Second)
+	defer clock.Stop()
+
+	for {
+		w, h, err := term.GetSize(fd)
+		if err != nil {
+			w, h = 120, 30
+		}
+		fmt.Fprint(a.stdout, "\x1b[H\x1b[2J"+m.render(w, h))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-redraw:
+		case <-clock.C:
+		case <-ticker.C:
+			go func() { load(); poke() }()
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			act, quit := m.key(k)
+			if quit {
+				return nil
+			}
+			if act != nil {
+				m.setStatus("%s %s…", act.verb, act.id)
+				go func() {
+					if err := a.runWatchAction(ctx, act); err != nil {
+						m.setStatus("%s %s failed: %v", act.verb, act.id, err)
+					} else {
+						m.setStatus("%s %s ok", act.verb, act.id)
+						load()
+					}
+					poke()
+				}()
+			}
+		}
+	}
+}
+
+func (a *app) runWatchAction(ctx context.Context, act *watchAction) error {
+	var err error
+	switch act.verb {
+	case "pause":
+		_, err = a.client.NewPauseMasterOrderV2Service().MasterOrderId(act.id).Reason("qe watch").Do(ctx)
+	case "resume":
+		_, err = a.client.NewResumeMasterOrderV2Service().MasterOrderId(act.id).Reason("qe watch").Do(ctx)
+	case "cancel":
+		_, err = a.client.NewCancelMasterOrderV2Service().MasterOrderId(act.id).Reason("qe watch").Do(ctx)
+	}
+	return err
+}
+
+// readKeys decodes raw terminal input into key names.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range decodeKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
+
+func decodeKeys(b []byte) []string {
+	var out []string
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == 0x03:
+			out = append(out, "ctrl-c")
+		case b[i] == 0x1b && i+2 < len(b) && b[i+1] == '[':
+			switch b[i+2] {
+			case 'A':
+				out = append(out, "up")
+			case 'B':
+				out = append(out, "down")
+			}
+			i += 2
+		default:
+			out = append(out, string(b[i]))
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+func TestWatchModelRendersOrdersAndFills(t *testing.T) {
+	m := newWatchModel()
+	total, filled, avg, maker := "2", "0.5", "100000", "0.4"
+	m.replace([]qe.MasterOrderV2Info{
+		{MasterOrderId: "mo-old", Symbol: "ETHUSDT", Side: "sell", Algorithm: "VWAP", Status: "PROCESSING",
+			CreatedAt: "2026-01-01T00:00:00Z", TotalQuantity: &total},
+		{MasterOrderId: "mo-new", Symbol: "BTCUSDT", Side: "buy", Algorithm: "TWAP", Status: "PROCESSING",
+			CreatedAt: "2026-01-02T00:00:00Z", TotalQuantity: &total, CumFilledQty: &filled, AvgFilledPrice: &avg, MakerRate: &maker},
+	})
+	m.applyMaster(&qe.WsMasterOrderDetail{MasterOrderID: "mo-old", Symbol: "ETHUSDT", Side: "sell", Algorithm: "VWAP",
+		Status: "PAUSED", CreatedAt: "2026-01-01T00:00:00Z", TotalQuantity: "2", CumFilledQty: "1.5"})
+	m.addFill(&qe.WsOrderFillDetail{MasterOrderID: "mo-new", Symbol: "BTCUSDT", Side: "buy",
+		FilledQuantity: "0.1", AveragePrice: "100001", OrderType: "LIMIT", UpdatedAt: "2026-01-02T00:00:05Z"})
+
+	screen := m.render(160, 20)
+	lines := strings.Split(screen, "\r\n")
+	if len(lines) != 20 {
+		t.Fatalf("screen has %d lines", len(lines))
+	}
+	// Newest first and selected by default.
+	if !strings.Contains(lines[2], "> mo-new") || !strings.Contains(lines[2], "25.0%") || !strings.Contains(lines[2], "40%") {
+		t.Fatalf("first row = %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "mo-old") || !strings.Contains(lines[3], "75.0%") || !strings.Contains(lines[3], "PAUSED") {
+		t.Fatalf("second row = %q", lines[3])
+	}
+	if !strings.Contains(screen, "0.1 @ 100001  LIMIT  mo-new") || !strings.HasSuffix(screen, watchHelp) {
+		t.Fatalf("screen:\n%s", screen)
+	}
+}
+
+func TestWatchModelKeys(t *testing.T) {
+	m := newWatchModel()
+	m.replace([]qe.MasterOrderV2Info{
+		{MasterOrderId: "a", CreatedAt: "2"},
+		{MasterOrderId: "b", CreatedAt: "1"},
+	})
+	m.render(80, 10)
+
+	if act, _ := m.key("down"); act != nil || m.selected != "b" {
+		t.Fatalf("down: act=%v selected=%s", act, m.selected)
+	}
+	if act, _ := m.key("p"); act == nil || *act != (watchAction{"pause", "b"}) {
+		t.Fatalf("pause act = %v", act)
+	}
+	m.key("up")
+	if act, _ := m.key("c"); act != nil || !strings.Contains(m.status, "cancel a?") {
+		t.Fatalf("cancel should ask first: act=%v status=%q", act, m.status)
+	}
+	if act, _ := m.key("n"); act != nil || m.status != "cancel aborted" {
+		t.Fatalf("declined cancel: act=%v status=%q", act, m.status)
+	}
+	m.key("c")
+	if act, _ := m.key("y"); act == nil || *act != (watchAction{"cancel", "a"}) {
+		t.Fatalf("confirmed cancel act = %v", act)
+	}
+	if _, quit := m.key("q"); !quit {
+		t.Fatal("q should quit")
+	}
+
+	if got := decodeKeys([]byte("j\x1b[A\x1b[Bq\x03")); !reflect.DeepEqual(got, []string{"j", "up", "down", "q", "ctrl-c"}) {
+		t.Fatalf("decodeKeys = %v", got)
+	}
+}
+
+func TestWatchActionsCallServices(t *testing.T) {
+	h := newHarness(t)
+	out := h.ok("-o", "json", "orders", "create", "--api-key-id", "b1", "--exchange", "Binance", "--market-type", "SPOT",
+		"--symbol", "BTCUSDT", "--side", "buy", "--algorithm", "TWAP", "--duration", "600", "--qty", "1")
+	var created qe.CreateMasterOrderV2Reply
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{client: qe.NewClient("cli-key", "cli-secret", h.srv.URL)}
+	for _, step := range []struct {
+		verb string
+		want qe.MasterOrderStatusV2
+	}{
+		{"pause", "PAUSED"}, {"resume", "PROCESSING"}, {"cancel", "CANCELLED"},
+	} {
+		if err := a.runWatchAction(context.Background(), &watchAction{step.verb, created.MasterOrderId}); err != nil {
+			t.Fatalf("%s: %v", step.verb, err)
+		}
+		if got, _ := h.srv.MasterOrder(created.MasterOrderId); got.Status != step.want {
+			t.Fatalf("after %s status = %s, want %s", step.verb, got.Status, step.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package trading_enums
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Every enum has a list of its values, a Parse function and IsValid, and
+// decodes case-insensitively: a value that matches a known one ignoring
+// case becomes that constant, anything else is kept as received so new
+// server values do not break decoding. Switches over an enum can be checked
+// for exhaustiveness against its list, or by linters against its const
+// block.
+
+// Match returns the value of all equal to s ignoring case. Without one it
+// returns s unchanged and false. It backs the enums of this package and
+// can back other string enums that decode the same way.
+func Match[T ~string](s string, all []T) (T, bool) {
+	for _, v := range all {
+		if strings.EqualFold(string(v), s) {
+			return v, true
+		}
+	}
+	return T(s), false
+}
+
+func parse[T ~string](kind, s string, all []T) (T, error) {
+	v, ok := Match(s, all)
+	if !ok {
+		return "", fmt.Errorf("trading_enums: unknown %s %q", kind, s)
+	}
+	return v, nil
+}
+
+var masterOrderStatuses = []MasterOrderStatus{MasterOrderStatusNew, MasterOrderStatusCompleted}
+
+// MasterOrderStatuses returns every MasterOrderStatus, in declaration order.
+func MasterOrderStatuses() []MasterOrderStatus {
+	return slices.Clone(masterOrderStatuses)
+}
+
+// ParseMasterOrderStatus returns the MasterOrderStatus equal to s ignoring case.
+func ParseMasterOrderStatus(s string) (MasterOrderStatus, error) {
+	return parse("master order status", s, masterOrderStatuses)
+}
+
+// IsValid reports whether s is a known MasterOrderStatus.
+func (s MasterOrderStatus) IsValid() bool {
+	return slices.Contains(masterOrderStatuses, s)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (s *MasterOrderStatus) UnmarshalText(b []byte) error {
+	*s, _ = Match(string(b), masterOrderStatuses)
+	return nil
+}
+
+var algorithms = []Algorithm{AlgorithmTWAP, AlgorithmVWAP, AlgorithmPOV}
+
+// Algorithms returns every Algorithm, in declaration order.
+func Algorithms() []Algorithm {
+	return slices.Clone(algorithms)
+}
+
+// ParseAlgorithm returns the Algorithm equal to s ignoring case.
+func ParseAlgorithm(s string) (Algorithm, error) {
+	return parse("algorithm", s, algorithms)
+}
+
+// IsValid reports whether a is a known Algorithm.
+func (a Algorithm) IsValid() bool {
+	return slices.Contains(algorithms, a)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (a *Algorithm) UnmarshalText(b []byte) error {
+	*a, _ = Match(string(b), algorithms)
+	return nil
+}
+
+var strategyTypes = []StrategyType{StrategyTypeTWAP1, StrategyTypeTWAP2, StrategyTypePOV}
+
+// StrategyTypes returns every StrategyType, in declaration order.
+func StrategyTypes() []StrategyType {
+	return slices.Clone(strategyTypes)
+}
+
+// ParseStrategyType returns the StrategyType equal to s ignoring case.
+func ParseStrategyType(s string) (StrategyType, error) {
+	return parse("strategy type", s, strategyTypes)
+}
+
+// IsValid reports whether t is a known StrategyType.
+func (t StrategyType) IsValid() bool {
+	return slices.Contains(strategyTypes, t)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (t *StrategyType) UnmarshalText(b []byte) error {
+	*t, _ = Match(string(b), strategyTypes)
+	return nil
+}
+
+var marketTypes = []MarketType{MarketTypeSpot, MarketTypePerp}
+
+// MarketTypes returns every MarketType, in declaration order.
+func MarketTypes() []MarketType {
+	return slices.Clone(marketTypes)
+}
+
+// ParseMarketType returns the MarketType equal to s ignoring case.
+func ParseMarketType(s string) (MarketType, error) {
+	return parse("market type", s, marketTypes)
+}
+
+// IsValid reports whether m is a known MarketType.
+func (m MarketType) IsValid() bool {
+	return slices.Contains(marketTypes, m)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (m *MarketType) UnmarshalText(b []byte) error {
+	*m, _ = Match(string(b), marketTypes)
+	return nil
+}
+
+var orderSides = []OrderSide{OrderSideBuy, OrderSideSell}
+
+// OrderSides returns every OrderSide, in declaration order.
+func OrderSides() []OrderSide {
+	return slices.Clone(orderSides)
+}
+
+// ParseOrderSide returns the OrderSide equal to s ignoring case.
+func ParseOrderSide(s string) (OrderSide, error) {
+	return parse("order side", s, orderSides)
+}
+
+// IsValid reports whether s is a known OrderSide.
+func (s OrderSide) IsValid() bool {
+	return slices.Contains(orderSides, s)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (s *OrderSide) UnmarshalText(b []byte) error {
+	*s, _ = Match(string(b), orderSides)
+	return nil
+}
+
+var marginTypes = []MarginType{MarginTypeU, MarginTypeC}
+
+// MarginTypes returns every MarginType, in declaration order.
+func MarginTypes() []MarginType {
+	return slices.Clone(marginTypes)
+}
+
+// ParseMarginType returns the MarginType equal to s ignoring case.
+func ParseMarginType(s string) (MarginType, error) {
+	return parse("margin type", s, marginTypes)
+}
+
+// IsValid reports whether m is a known MarginType.
+func (m MarginType) IsValid() bool {
+	return slices.Contains(marginTypes, m)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (m *MarginType) UnmarshalText(b []byte) error {
+	*m, _ = Match(string(b), marginTypes)
+	return nil
+}
+
+var exchanges = []Exchange{ExchangeBinance, ExchangeOKX, ExchangeLTP, ExchangeDeribit, ExchangeHyperliquid, ExchangeBybit}
+
+// Exchanges returns every Exchange, in declaration order.
+func Exchanges() []Exchange {
+	return slices.Clone(exchanges)
+}
+
+// ParseExchange returns the Exchange equal to s ignoring case.
+func ParseExchange(s string) (Exchange, error) {
+	return parse("exchange", s, exchanges)
+}
+
+// IsValid reports whether e is a known Exchange.
+func (e Exchange) IsValid() bool {
+	return slices.Contains(exchanges, e)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (e *Exchange) UnmarshalText(b []byte) error {
+	*e, _ = Match(string(b), exchanges)
+	return nil
+}
+
+var categories = []Category{CategorySpot, CategoryPerp, CategoryPerpCm}
+
+// Categories returns every Category, in declaration order.
+func Categories() []Category {
+	return slices.Clone(categories)
+}
+
+// ParseCategory returns the Category equal to s ignoring case.
+func ParseCategory(s string) (Category, error) {
+	return parse("category", s, categories)
+}
+
+// IsValid reports whether c is a known Category.
+func (c Category) IsValid() bool {
+	return slices.Contains(categories, c)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (c *Category) UnmarshalText(b []byte) error {
+	*c, _ = Match(string(b), categories)
+	return nil
+}
+
+var tradingPairMarketTypes = []TradingPairMarketType{TradingPairFutures, TradingPairSpot}
+
+// TradingPairMarketTypes returns every TradingPairMarketType, in declaration order.
+func TradingPairMarketTypes() []TradingPairMarketType {
+	return slices.Clone(tradingPairMarketTypes)
+}
+
+// ParseTradingPairMarketType returns the TradingPairMarketType equal to s ignoring case.
+func ParseTradingPairMarketType(s string) (TradingPairMarketType, error) {
+	return parse("trading pair market type", s, tradingPairMarketTypes)
+}
+
+// IsValid reports whether m is a known TradingPairMarketType.
+func (m TradingPairMarketType) IsValid() bool {
+	return slices.Contains(tradingPairMarketTypes, m)
+}
+
+// UnmarshalText decodes a known value case-insensitively and keeps an
+// unknown one as is.
+func (m *TradingPairMarketType) UnmarshalText(b []byte) error {
+	*m, _ = Match(string(b), tradingPairMarketTypes)
+	return nil
+}
@@ -0,0 +1,109 @@
+package trading_enums
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func names[T ~string](vs []T) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// TestListsAreExhaustive checks each list against the constants declared
+// for its type in trading.go.
+func TestListsAreExhaustive(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "trading.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := map[string][]string{}
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			v, _ := strconv.Unquote(vs.Values[0].(*ast.BasicLit).Value)
+			typ := vs.Type.(*ast.Ident).Name
+			declared[typ] = append(declared[typ], v)
+		}
+	}
+
+	lists := map[string][]string{
+		"MasterOrderStatus":     names(MasterOrderStatuses()),
+		"Algorithm":             names(Algorithms()),
+		"StrategyType":          names(StrategyTypes()),
+		"MarketType":            names(MarketTypes()),
+		"OrderSide":             names(OrderSides()),
+		"MarginType":            names(MarginTypes()),
+		"Exchange":              names(Exchanges()),
+		"Category":              names(Categories()),
+		"TradingPairMarketType": names(TradingPairMarketTypes()),
+	}
+	if len(lists) != len(declared) {
+		t.Errorf("%d lists for %d enum types", len(lists), len(declared))
+	}
+	for typ, want := range declared {
+		if got := lists[typ]; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s list = %v, declared %v", typ, got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, v := range Exchanges() {
+		for _, s := range []string{string(v), strings.ToUpper(string(v)), strings.ToLower(string(v))} {
+			if got, err := ParseExchange(s); err != nil || got != v {
+				t.Errorf("ParseExchange(%q) = %q, %v", s, got, err)
+			}
+		}
+	}
+	if got, err := ParseCategory("PERP_CM"); err != nil || got != CategoryPerpCm {
+		t.Errorf("ParseCategory = %q, %v", got, err)
+	}
+	if got, err := ParseStrategyType("twap-2"); err != nil || got != StrategyTypeTWAP2 {
+		t.Errorf("ParseStrategyType = %q, %v", got, err)
+	}
+	if v, ok := Match("PERP_CM", Categories()); !ok || v != CategoryPerpCm {
+		t.Errorf("Match = %q, %v", v, ok)
+	}
+	if v, ok := Match("option", Categories()); ok || v != "option" {
+		t.Errorf("Match(unknown) = %q, %v", v, ok)
+	}
+	for _, s := range []string{"", "ICEBERG", "TWAP "} {
+		if _, err := ParseAlgorithm(s); err == nil {
+			t.Errorf("ParseAlgorithm(%q): expected an error", s)
+		}
+	}
+}
+
+func TestDecodeKeepsUnknownValues(t *testing.T) {
+	var v struct {
+		Side      OrderSide  `json:"side"`
+		Algorithm Algorithm  `json:"algorithm"`
+		Market    MarketType `json:"market"`
+	}
+	if err := json.Unmarshal([]byte(`{"side":"SELL","algorithm":"Iceberg","market":"Spot"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Side != OrderSideSell || !v.Side.IsValid() {
+		t.Errorf("Side = %q", v.Side)
+	}
+	if v.Algorithm != "Iceberg" || v.Algorithm.IsValid() {
+		t.Errorf("Algorithm = %q", v.Algorithm)
+	}
+	if v.Market != MarketTypeSpot {
+		t.Errorf("Market = %q", v.Market)
+	}
+}
@@ -0,0 +1,149 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Credentials are an API key and what its requests are signed with: Signer
+// when set, otherwise HMAC-SHA256 with SecretKey.
+type Credentials struct {
+	APIKey    string
+	SecretKey string
+	Signer    Signer
+}
+
+// credentialSet is what a request is signed with. A request takes one set
+// when it is built and keeps it through retries and hedges.
+type credentialSet struct {
+	Credentials
+	// retired are the secrets of the sets this one replaced, still redacted
+	// from the logs of calls made with them.
+	retired []retiredSecret
+}
+
+// retiredSecretGrace is how long a replaced API key or secret stays
+// redacted. It outlasts the calls, retries included, still using it, even
+// when credentials are rotated several times in a row.
+const retiredSecretGrace = time.Hour
+
+type retiredSecret struct {
+	value string
+	until time.Time
+}
+
+// credentials returns the set new requests are signed with: the last one
+// passed to RotateCredentials or, before any rotation, the APIKey,
+// SecretKey and Signer fields.
+func (c *Client) credentials() *credentialSet {
+	if cs := c.creds.Load(); cs != nil {
+		return cs
+	}
+	return &credentialSet{Credentials: Credentials{APIKey: c.APIKey, SecretKey: c.SecretKey, Signer: c.Signer}}
+}
+
+// Credentials returns the credentials new requests are signed with.
+func (c *Client) Credentials() Credentials {
+	return c.credentials().Credentials
+}
+
+func (cs *credentialSet) sign(ctx context.Context, payload []byte) (string, error) {
+	if cs.Signer != nil {
+		return cs.Signer.Sign(ctx, payload)
+	}
+	return hmacHex([]byte(cs.SecretKey), payload), nil
+}
+
+// secrets returns the values logs must not show.
+func (cs *credentialSet) secrets() []string {
+	out := []string{cs.APIKey, cs.SecretKey}
+	now := time.Now()
+	for _, r := range cs.retired {
+		if now.Before(r.until) {
+			out = append(out, r.value)
+		}
+	}
+	return out
+}
+
+// replace returns the set that takes over from cs with cr, keeping the
+// secrets of cs and those it still remembers for retiredSecretGrace.
+func (cs *credentialSet) replace(cr Credentials) *credentialSet {
+	now := time.Now()
+	next := &credentialSet{Credentials: cr}
+	for _, r := range cs.retired {
+		if now.Before(r.until) {
+			next.retired = append(next.retired, r)
+		}
+	}
+	until := now.Add(retiredSecretGrace)
+	next.retired = append(next.retired, retiredSecret{cs.APIKey, until}, retiredSecret{cs.SecretKey, until})
+	return next
+}
+
+// RotateCredentials switches the client to cr while it is in use. Calls
+// already in flight, including their retries, finish with the old
+// credentials; calls started afterwards use cr. Every connected
+// WebSocketService of the client then gets a listen key created with cr
+// and moves to a new connection, which is dialed before the old one is
+// closed so no push is missed. Revoke the old key on the server only after
+// RotateCredentials returns.
+//
+// After the first rotation the APIKey, SecretKey and Signer fields are no
+// longer read; use Credentials to see the current ones. The returned error
+// reports WebSocketServices that could not be moved; they stay on their
+// old listen key.
+func (c *Client) RotateCredentials(ctx context.Context, cr Credentials) error {
+	if cr.APIKey == "" || (cr.SecretKey == "" && cr.Signer == nil) {
+		return errors.New("qe: credentials need an API key and a secret key or signer")
+	}
+	if rec := c.recorder; rec != nil {
+		rec.scrub.addSecrets(cr.APIKey, cr.SecretKey)
+	}
+	// Concurrent rotations each retire the set they replace, so no secret
+	// drops out of redaction early.
+	for {
+		cur := c.creds.Load()
+		old := cur
+		if old == nil {
+			old = c.credentials()
+		}
+		if c.creds.CompareAndSwap(cur, old.replace(cr)) {
+			break
+		}
+	}
+
+	var errs []error
+	for _, ws := range c.liveSockets() {
+		if err := ws.rekey(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("qe: move websocket to new credentials: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// liveSockets returns the client's connected WebSocketServices.
+func (c *Client) liveSockets() []*WebSocketService {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	out := make([]*WebSocketService, 0, len(c.sockets))
+	for ws := range c.sockets {
+		out = append(out, ws)
+	}
+	return out
+}
+
+func (c *Client) trackSocket(ws *WebSocketService, live bool) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if !live {
+		delete(c.sockets, ws)
+		return
+	}
+	if c.sockets == nil {
+		c.sockets = map[*WebSocketService]struct{}{}
+	}
+	c.sockets[ws] = struct{}{}
+}
@@ -0,0 +1,115 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+const (
+	rotatedAPIKey    = "rotated-api-key"
+	rotatedSecretKey = "rotated-secret-key"
+)
+
+func TestRotateCredentialsMovesWebSocket(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL)
+	lk, err := c.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fills := make(chan string, 4)
+	ws := c.NewWebSocketService(srv.WSHost()).SetHandlers(&qe.WebSocketEventHandlers{
+		OnOrderFillDetail: func(msg *qe.WsOrderFillDetail) error {
+			fills <- msg.MasterOrderID
+			return nil
+		},
+	})
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := srv.WaitForWSClients(1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.AddCredentials(rotatedAPIKey, rotatedSecretKey)
+	if err := c.RotateCredentials(context.Background(), qe.Credentials{APIKey: rotatedAPIKey, SecretKey: rotatedSecretKey}); err != nil {
+		t.Fatal(err)
+	}
+	srv.RevokeCredentials(testAPIKey)
+	if got := c.Credentials().APIKey; got != rotatedAPIKey {
+		t.Fatalf("Credentials().APIKey = %q", got)
+	}
+
+	// The old connection went away with the old key; the new one is live.
+	if !ws.IsConnected() || srv.WSClients() != 1 {
+		t.Fatalf("connected = %v, server clients = %d", ws.IsConnected(), srv.WSClients())
+	}
+	if err := srv.PushOrderFill(&qe.OrderFillV2Info{MasterOrderId: "mo-rotated"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case id := <-fills:
+		if id != "mo-rotated" {
+			t.Fatalf("fill for %q", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no push after rotation")
+	}
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRotateCredentialsKeepsInFlightCalls(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	srv.AddCredentials(rotatedAPIKey, rotatedSecretKey)
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetRetry(&qe.RetryPolicy{Backoff: 50 * time.Millisecond})
+	// The first attempt fails after the rotation; its retry is signed again
+	// and must still carry the old key.
+	srv.InjectFault(qetest.Fault{HTTPStatus: http.StatusServiceUnavailable, Delay: 100 * time.Millisecond, Times: 1})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := c.NewGetMasterOrdersService().Do(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if err := c.RotateCredentials(context.Background(), qe.Credentials{APIKey: rotatedAPIKey, SecretKey: rotatedSecretKey}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	keys := map[string]string{}
+	for _, r := range srv.Requests() {
+		keys[r.Path] = r.Header.Get("X-MBX-APIKEY")
+	}
+	if keys["/user/trading/master-orders"] != testAPIKey || keys[qe.MasterOrdersV2Endpoint] != rotatedAPIKey {
+		t.Fatalf("API keys by path = %v", keys)
+	}
+}
+
+func TestRotateCredentialsValidates(t *testing.T) {
+	c := qe.NewClient(testAPIKey, testSecretKey)
+	if err := c.RotateCredentials(context.Background(), qe.Credentials{APIKey: rotatedAPIKey}); err == nil {
+		t.Fatal("expected an error without a secret or signer")
+	}
+	if got := c.Credentials().APIKey; got != testAPIKey {
+		t.Fatalf("Credentials().APIKey = %q", got)
+	}
+}
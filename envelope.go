@@ -0,0 +1,179 @@
+package qe_connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+// Envelope is the wrapper every strategy-api response comes in, with the
+// endpoint's payload decoded as T. Services decode the body straight into
+// an Envelope of their reply type, so each response is parsed once; use
+// Envelope[json.RawMessage] to keep the payload undecoded.
+type Envelope[T any] struct {
+	Code       int    `json:"code"`
+	Reason     string `json:"reason"`
+	Message    T      `json:"message"`
+	TraceId    string `json:"traceId"`
+	ServerTime int64  `json:"serverTime"`
+}
+
+// envelope is implemented by *Envelope[T] for every T.
+type envelope interface {
+	header() (code int, traceID string, serverTime int64)
+	// masterOrderID is the decoded message's MasterOrderId, if it has one.
+	masterOrderID() string
+}
+
+func (e *Envelope[T]) header() (int, string, int64) { return e.Code, e.TraceId, e.ServerTime }
+
+func (e *Envelope[T]) masterOrderID() string {
+	v := reflect.ValueOf(&e.Message).Elem()
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := v.FieldByName("MasterOrderId"); f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// decodeEnvelope decodes a response body with HTTP status into env. HTTP
+// failures and envelopes whose code is not 200 are returned as
+// *handlers.APIError.
+func decodeEnvelope(status int, raw []byte, env envelope) error {
+	if status >= http.StatusBadRequest {
+		apiErr := new(handlers.APIError)
+		_ = json.Unmarshal(raw, apiErr)
+		return apiErr
+	}
+	if err := json.Unmarshal(raw, env); err != nil {
+		// A failed call carries an error string or object as its message,
+		// which need not fit the reply type.
+		apiErr := new(handlers.APIError)
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Code != 0 && apiErr.Code != 200 {
+			return apiErr
+		}
+		return err
+	}
+	if code, _, _ := env.header(); code != 200 {
+		// Rare path: decode again to keep the message as sent.
+		apiErr := new(handlers.APIError)
+		_ = json.Unmarshal(raw, apiErr)
+		return apiErr
+	}
+	return nil
+}
+
+// send performs req, decodes the response into env and reports the call to
+// the logger, the Instrumentation and the caller's ResponseMeta. A non-empty
+// cacheKey routes the request through the client's ReadCache.
+func (c *Client) send(ctx context.Context, rl *requestLog, req *http.Request, env envelope, cacheKey string) (err error) {
+	var x *exchange
+	defer func() {
+		var traceID string
+		var serverTime int64
+		var reply envelope
+		var apiErr *handlers.APIError
+		switch {
+		case err == nil:
+			reply = env
+			_, traceID, serverTime = env.header()
+		case errors.As(err, &apiErr):
+			traceID, serverTime = apiErr.TraceId, apiErr.ServerTime
+		}
+		if c.skew != nil && x.status != 0 && x.attempts > 0 {
+			c.skew.observe(x.start, x.rtt, serverTime)
+		}
+		if rl.meta != nil {
+			*rl.meta = ResponseMeta{
+				TraceId:    traceID,
+				ServerTime: serverTime,
+				StatusCode: x.status,
+				RateLimit:  rateLimitHeaders(x.header),
+				Attempts:   x.attempts,
+				Hedged:     x.hedged,
+				RoundTrip:  x.rtt,
+			}
+		}
+		c.finishRequest(ctx, rl, x.status, x.raw, reply, traceID, err)
+	}()
+
+	fetch := func() *exchange { return c.exchange(ctx, rl, req) }
+	if cacheKey == "" || c.cache == nil {
+		x = fetch()
+		if x.err != nil {
+			return x.err
+		}
+		return decodeEnvelope(x.status, x.raw, env)
+	}
+	cache := c.cache
+	x, leader := cache.do(ctx, cacheKey, fetch)
+	if x.err != nil {
+		return x.err
+	}
+	if err := decodeEnvelope(x.status, x.raw, env); err != nil {
+		return err
+	}
+	if leader {
+		cache.store(cacheKey, rl.endpoint, x)
+	}
+	return nil
+}
+
+// exchange sends req, hedged if configured, and reads the whole response.
+func (c *Client) exchange(ctx context.Context, rl *requestLog, req *http.Request) *exchange {
+	req = c.startRequest(ctx, rl, req)
+	if h := c.hedge; h != nil && rl.resign != nil && req.Method == http.MethodGet {
+		if prefix, ok := h.prefix(rl.endpoint); ok {
+			return c.hedged(h, prefix, rl, req)
+		}
+	}
+	return c.attempt(rl, req)
+}
+
+// attempt sends req, failing over and retrying if configured, and reads the
+// whole response.
+func (c *Client) attempt(rl *requestLog, req *http.Request) *exchange {
+	x := &exchange{start: time.Now()}
+	res := c.sendWithRetry(rl, req, x)
+	if res == nil {
+		return x
+	}
+	x.status, x.header = res.StatusCode, res.Header
+	x.raw, x.err = io.ReadAll(res.Body)
+	x.rtt = time.Since(x.start)
+	if cerr := res.Body.Close(); x.err == nil {
+		x.err = cerr
+	}
+	return x
+}
+
+// callAPIAs performs a V1-style request and returns the response message
+// decoded as T.
+func callAPIAs[T any](ctx context.Context, c *Client, r *request, opts ...RequestOption) (*T, error) {
+	env := new(Envelope[T])
+	if err := c.callAPIEnvelope(ctx, r, env, opts...); err != nil {
+		return nil, err
+	}
+	return &env.Message, nil
+}
+
+// callAPIV2As performs a V2 request with a JSON body and returns the
+// response message decoded as T.
+func callAPIV2As[T any](ctx context.Context, c *Client, method, endpoint string, body params, opts ...RequestOption) (*T, error) {
+	env := new(Envelope[T])
+	if err := c.callAPIV2Envelope(ctx, method, endpoint, body, env, opts...); err != nil {
+		return nil, err
+	}
+	return &env.Message, nil
+}
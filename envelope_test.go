@@ -0,0 +1,125 @@
+package qe_connector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+func TestDecodeEnvelopeErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		code   int
+	}{
+		{"http status", http.StatusUnauthorized, `{"code":401,"reason":"UNAUTHORIZED","message":"bad signature"}`, 401},
+		{"code with string message", http.StatusOK, `{"code":404,"reason":"NOT_FOUND","message":"no such order"}`, 404},
+		{"code with object message", http.StatusOK, `{"code":500,"reason":"INTERNAL","message":{"items":[]}}`, 500},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := decodeEnvelope(tc.status, []byte(tc.body), new(Envelope[GetOrderFillsV2Reply]))
+			var apiErr *handlers.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tc.code {
+				t.Fatalf("err = %v, want APIError code %d", err, tc.code)
+			}
+			if tc.code == 404 && apiErr.Message != "no such order" {
+				t.Fatalf("message = %v", apiErr.Message)
+			}
+		})
+	}
+
+	if err := decodeEnvelope(http.StatusOK, []byte(`{"code":200,"message":`), new(Envelope[json.RawMessage])); err == nil {
+		t.Fatal("expected a syntax error")
+	}
+}
+
+func TestEnvelopeKeepsLargeIntegers(t *testing.T) {
+	body := []byte(`{"code":200,"message":{"id":9007199254740993},"traceId":"t-1"}`)
+	env := new(Envelope[struct {
+		ID int64 `json:"id"`
+	}])
+	if err := decodeEnvelope(http.StatusOK, body, env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Message.ID != 9007199254740993 || env.TraceId != "t-1" {
+		t.Fatalf("envelope = %+v", env)
+	}
+
+	raw := new(Envelope[json.RawMessage])
+	if err := decodeEnvelope(http.StatusOK, body, raw); err != nil {
+		t.Fatal(err)
+	}
+	if string(raw.Message) != `{"id":9007199254740993}` {
+		t.Fatalf("raw message = %s", raw.Message)
+	}
+}
+
+// fillsPage is a GetOrderFillsV2 response of n fills.
+func fillsPage(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"code":200,"reason":"OK","message":{"items":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":"fill-%d","orderCreatedTime":"2025-01-01T00:00:00Z","masterOrderId":"mo-1",`+
+			`"exchange":"Binance","category":"spot","symbol":"BTCUSDT","side":"buy","filledNotional":"%d.5",`+
+			`"filledQuantity":"0.001","averagePrice":"65000.1","price":"65000.1","status":"FILLED"}`, i, i)
+	}
+	fmt.Fprintf(&b, `],"total":%d,"page":1,"pageSize":%d},"traceId":"bench","serverTime":1735689600000}`, n, n)
+	return []byte(b.String())
+}
+
+// decodeRoundTrip is how responses were decoded before Envelope: the message
+// went through interface{} and was marshalled again for the service.
+func decodeRoundTrip(raw []byte, res interface{}) error {
+	respData := new(handlers.APISuccess)
+	if err := json.Unmarshal(raw, respData); err != nil {
+		return err
+	}
+	data, err := json.Marshal(respData.Message)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, res)
+}
+
+func benchmarkDecode(b *testing.B, decode func([]byte) error) {
+	for _, n := range []int{1, 100, 1000} {
+		raw := fillsPage(n)
+		b.Run(fmt.Sprintf("fills=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(raw)))
+			for i := 0; i < b.N; i++ {
+				if err := decode(raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeRoundTrip(b *testing.B) {
+	benchmarkDecode(b, func(raw []byte) error {
+		return decodeRoundTrip(raw, new(GetOrderFillsV2Reply))
+	})
+}
+
+func BenchmarkDecodeEnvelope(b *testing.B) {
+	benchmarkDecode(b, func(raw []byte) error {
+		return decodeEnvelope(http.StatusOK, raw, new(Envelope[GetOrderFillsV2Reply]))
+	})
+}
+
+func BenchmarkDecodeEnvelopeRaw(b *testing.B) {
+	benchmarkDecode(b, func(raw []byte) error {
+		return decodeEnvelope(http.StatusOK, raw, new(Envelope[json.RawMessage]))
+	})
+}
@@ -1,8 +1,10 @@
+// Code generated by svcgen from strategy-api.yaml; DO NOT EDIT.
+
 package qe_connector
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"net/http"
 )
 
@@ -10,6 +12,11 @@ import (
 // 余额类
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetAccountBalanceService creates a service for `GET /user/exchange-apis/account-balance`.
+func (c *Client) NewGetAccountBalanceService() *GetAccountBalanceService {
+	return &GetAccountBalanceService{c: c}
+}
+
 // GetAccountBalanceService get Binance spot account balance
 type GetAccountBalanceService struct {
 	c         *Client
@@ -22,24 +29,26 @@ func (s *GetAccountBalanceService) BindingId(v string) *GetAccountBalanceService
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetAccountBalanceService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetAccountBalanceService) Do(ctx context.Context, opts ...RequestOption) (res *AccountBalanceReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/account-balance",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(AccountBalanceReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[AccountBalanceReply](ctx, s.c, r, opts...)
 }
 
 // AccountBalanceReply Binance spot account balance response
@@ -59,6 +68,11 @@ type SpotBalanceItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetMarginBalanceService creates a service for `GET /user/exchange-apis/margin-balance`.
+func (c *Client) NewGetMarginBalanceService() *GetMarginBalanceService {
+	return &GetMarginBalanceService{c: c}
+}
+
 // GetMarginBalanceService get Binance futures account balance
 type GetMarginBalanceService struct {
 	c         *Client
@@ -71,24 +85,26 @@ func (s *GetMarginBalanceService) BindingId(v string) *GetMarginBalanceService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetMarginBalanceService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetMarginBalanceService) Do(ctx context.Context, opts ...RequestOption) (res *MarginBalanceReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/margin-balance",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(MarginBalanceReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[MarginBalanceReply](ctx, s.c, r, opts...)
 }
 
 // MarginBalanceReply Binance futures account balance response
@@ -112,6 +128,11 @@ type FuturesBalanceItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetPv1BalanceService creates a service for `GET /user/exchange-apis/pv1-balance`.
+func (c *Client) NewGetPv1BalanceService() *GetPv1BalanceService {
+	return &GetPv1BalanceService{c: c}
+}
+
 // GetPv1BalanceService get Binance PAPI PV1 balance
 type GetPv1BalanceService struct {
 	c         *Client
@@ -124,30 +145,32 @@ func (s *GetPv1BalanceService) BindingId(v string) *GetPv1BalanceService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetPv1BalanceService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetPv1BalanceService) Do(ctx context.Context, opts ...RequestOption) (res *Pv1BalanceReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/pv1-balance",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(Pv1BalanceReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[Pv1BalanceReply](ctx, s.c, r, opts...)
 }
 
 // Pv1BalanceReply Binance PAPI PV1 balance response
 type Pv1BalanceReply struct {
-	Exchange    string         `json:"exchange"`
-	AccountType string         `json:"accountType"`
+	Exchange    string           `json:"exchange"`
+	AccountType string           `json:"accountType"`
 	Balances    []Pv1BalanceItem `json:"balances"`
 }
 
@@ -169,6 +192,11 @@ type Pv1BalanceItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetOkxAccountBalanceService creates a service for `GET /user/exchange-apis/okx-account-balance`.
+func (c *Client) NewGetOkxAccountBalanceService() *GetOkxAccountBalanceService {
+	return &GetOkxAccountBalanceService{c: c}
+}
+
 // GetOkxAccountBalanceService get OKX account balance
 type GetOkxAccountBalanceService struct {
 	c         *Client
@@ -181,24 +209,26 @@ func (s *GetOkxAccountBalanceService) BindingId(v string) *GetOkxAccountBalanceS
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetOkxAccountBalanceService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetOkxAccountBalanceService) Do(ctx context.Context, opts ...RequestOption) (res *OkxAccountBalanceReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/okx-account-balance",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(OkxAccountBalanceReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[OkxAccountBalanceReply](ctx, s.c, r, opts...)
 }
 
 // OkxAccountBalanceReply OKX account balance response
@@ -209,16 +239,16 @@ type OkxAccountBalanceReply struct {
 
 // OkxBalanceData OKX account balance data
 type OkxBalanceData struct {
-	TotalEq     string           `json:"totalEq"`
-	AvailEq     string           `json:"availEq"`
-	AdjEq       string           `json:"adjEq"`
-	Imr         string           `json:"imr"`
-	Mmr         string           `json:"mmr"`
-	MgnRatio    string           `json:"mgnRatio"`
-	NotionalUsd string           `json:"notionalUsd"`
-	OrdFroz     string           `json:"ordFroz"`
-	Upl         string           `json:"upl"`
-	UTime       string           `json:"uTime"`
+	TotalEq     string             `json:"totalEq"`
+	AvailEq     string             `json:"availEq"`
+	AdjEq       string             `json:"adjEq"`
+	Imr         string             `json:"imr"`
+	Mmr         string             `json:"mmr"`
+	MgnRatio    string             `json:"mgnRatio"`
+	NotionalUsd string             `json:"notionalUsd"`
+	OrdFroz     string             `json:"ordFroz"`
+	Upl         string             `json:"upl"`
+	UTime       string             `json:"uTime"`
 	Details     []OkxBalanceDetail `json:"details"`
 }
 
@@ -240,6 +270,11 @@ type OkxBalanceDetail struct {
 // 持仓类
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetFapiPositionSideDialService creates a service for `GET /user/exchange-apis/fapi-position-side-dial`.
+func (c *Client) NewGetFapiPositionSideDialService() *GetFapiPositionSideDialService {
+	return &GetFapiPositionSideDialService{c: c}
+}
+
 // GetFapiPositionSideDialService get Binance FAPI position side dual status
 type GetFapiPositionSideDialService struct {
 	c         *Client
@@ -252,28 +287,42 @@ func (s *GetFapiPositionSideDialService) BindingId(v string) *GetFapiPositionSid
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetFapiPositionSideDialService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetFapiPositionSideDialService) Do(ctx context.Context, opts ...RequestOption) (res *PositionSideDualReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/fapi-position-side-dial",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(PositionSideDualReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[PositionSideDualReply](ctx, s.c, r, opts...)
+}
+
+// PositionSideDualReply position side dual status response (shared by FAPI and PAPI UM)
+type PositionSideDualReply struct {
+	DualSidePosition bool   `json:"dualSidePosition"`
+	Exchange         string `json:"exchange"`
+	AccountType      string `json:"accountType"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetPapiUmPositionSideDualService creates a service for `GET /user/exchange-apis/papi-um-position-side-dual`.
+func (c *Client) NewGetPapiUmPositionSideDualService() *GetPapiUmPositionSideDualService {
+	return &GetPapiUmPositionSideDualService{c: c}
+}
+
 // GetPapiUmPositionSideDualService get Binance PAPI UM position side dual status
 type GetPapiUmPositionSideDualService struct {
 	c         *Client
@@ -286,35 +335,35 @@ func (s *GetPapiUmPositionSideDualService) BindingId(v string) *GetPapiUmPositio
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetPapiUmPositionSideDualService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetPapiUmPositionSideDualService) Do(ctx context.Context, opts ...RequestOption) (res *PositionSideDualReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/papi-um-position-side-dual",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(PositionSideDualReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-// PositionSideDualReply position side dual status response (shared by FAPI and PAPI UM)
-type PositionSideDualReply struct {
-	DualSidePosition bool   `json:"dualSidePosition"`
-	Exchange         string `json:"exchange"`
-	AccountType      string `json:"accountType"`
+	return callAPIAs[PositionSideDualReply](ctx, s.c, r, opts...)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetOkxAccountPositionsService creates a service for `GET /user/exchange-apis/okx-account-positions`.
+func (c *Client) NewGetOkxAccountPositionsService() *GetOkxAccountPositionsService {
+	return &GetOkxAccountPositionsService{c: c}
+}
+
 // GetOkxAccountPositionsService get OKX account positions
 type GetOkxAccountPositionsService struct {
 	c         *Client
@@ -327,24 +376,26 @@ func (s *GetOkxAccountPositionsService) BindingId(v string) *GetOkxAccountPositi
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetOkxAccountPositionsService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetOkxAccountPositionsService) Do(ctx context.Context, opts ...RequestOption) (res *OkxAccountPositionsReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/okx-account-positions",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(OkxAccountPositionsReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[OkxAccountPositionsReply](ctx, s.c, r, opts...)
 }
 
 // OkxAccountPositionsReply OKX account positions response
@@ -382,6 +433,11 @@ type OkxPositionItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetOkxAccountMaxSizeService creates a service for `GET /user/exchange-apis/okx-account-max-size`.
+func (c *Client) NewGetOkxAccountMaxSizeService() *GetOkxAccountMaxSizeService {
+	return &GetOkxAccountMaxSizeService{c: c}
+}
+
 // GetOkxAccountMaxSizeService get OKX account max order size
 type GetOkxAccountMaxSizeService struct {
 	c         *Client
@@ -408,8 +464,25 @@ func (s *GetOkxAccountMaxSizeService) TdMode(v string) *GetOkxAccountMaxSizeServ
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetOkxAccountMaxSizeService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	if s.instId == "" {
+		return errors.New("instId is required")
+	}
+	if s.tdMode == "" {
+		return errors.New("tdMode is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetOkxAccountMaxSizeService) Do(ctx context.Context, opts ...RequestOption) (res *OkxAccountMaxSizeReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/okx-account-max-size",
@@ -418,16 +491,7 @@ func (s *GetOkxAccountMaxSizeService) Do(ctx context.Context, opts ...RequestOpt
 	r.setParam("bindingId", s.bindingId)
 	r.setParam("instId", s.instId)
 	r.setParam("tdMode", s.tdMode)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(OkxAccountMaxSizeReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[OkxAccountMaxSizeReply](ctx, s.c, r, opts...)
 }
 
 // OkxAccountMaxSizeReply OKX account max order size response
@@ -446,6 +510,11 @@ type OkxMaxSizeItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetLtpPositionService creates a service for `GET /user/exchange-apis/ltp-position`.
+func (c *Client) NewGetLtpPositionService() *GetLtpPositionService {
+	return &GetLtpPositionService{c: c}
+}
+
 // GetLtpPositionService get LTP account positions
 type GetLtpPositionService struct {
 	c         *Client
@@ -465,8 +534,19 @@ func (s *GetLtpPositionService) Sym(v string) *GetLtpPositionService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetLtpPositionService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetLtpPositionService) Do(ctx context.Context, opts ...RequestOption) (res *LtpPositionReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/ltp-position",
@@ -476,16 +556,7 @@ func (s *GetLtpPositionService) Do(ctx context.Context, opts ...RequestOption) (
 	if s.sym != nil {
 		r.setParam("sym", *s.sym)
 	}
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(LtpPositionReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[LtpPositionReply](ctx, s.c, r, opts...)
 }
 
 // LtpPositionReply LTP account positions response
@@ -521,6 +592,11 @@ type LtpPositionItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetDeribitPositionService creates a service for `GET /user/exchange-apis/deribit-position`.
+func (c *Client) NewGetDeribitPositionService() *GetDeribitPositionService {
+	return &GetDeribitPositionService{c: c}
+}
+
 // GetDeribitPositionService get Deribit account positions
 type GetDeribitPositionService struct {
 	c         *Client
@@ -533,24 +609,26 @@ func (s *GetDeribitPositionService) BindingId(v string) *GetDeribitPositionServi
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetDeribitPositionService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetDeribitPositionService) Do(ctx context.Context, opts ...RequestOption) (res *DeribitPositionReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/deribit-position",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(DeribitPositionReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[DeribitPositionReply](ctx, s.c, r, opts...)
 }
 
 // DeribitPositionReply Deribit account positions response
@@ -561,30 +639,35 @@ type DeribitPositionReply struct {
 
 // DeribitPositionItem single position in Deribit account
 type DeribitPositionItem struct {
-	InstrumentName             string  `json:"instrumentName"`
-	Direction                  string  `json:"direction"`
-	Size                       float64 `json:"size"`
-	AveragePrice               float64 `json:"averagePrice"`
-	MarkPrice                  float64 `json:"markPrice"`
-	IndexPrice                 float64 `json:"indexPrice"`
-	FloatingProfitLoss         float64 `json:"floatingProfitLoss"`
-	TotalProfitLoss            float64 `json:"totalProfitLoss"`
-	InitialMargin              float64 `json:"initialMargin"`
-	MaintenanceMargin          float64 `json:"maintenanceMargin"`
-	EstimatedLiquidationPrice  float64 `json:"estimatedLiquidationPrice"`
-	Leverage                   int32   `json:"leverage"`
-	Kind                       string  `json:"kind"`
-	SizeCurrency               float64 `json:"sizeCurrency"`
-	Delta                      float64 `json:"delta"`
-	RealizedFunding            float64 `json:"realizedFunding"`
-	RealizedProfitLoss         float64 `json:"realizedProfitLoss"`
-	SettlementPrice            float64 `json:"settlementPrice"`
+	InstrumentName            string  `json:"instrumentName"`
+	Direction                 string  `json:"direction"`
+	Size                      float64 `json:"size"`
+	AveragePrice              float64 `json:"averagePrice"`
+	MarkPrice                 float64 `json:"markPrice"`
+	IndexPrice                float64 `json:"indexPrice"`
+	FloatingProfitLoss        float64 `json:"floatingProfitLoss"`
+	TotalProfitLoss           float64 `json:"totalProfitLoss"`
+	InitialMargin             float64 `json:"initialMargin"`
+	MaintenanceMargin         float64 `json:"maintenanceMargin"`
+	EstimatedLiquidationPrice float64 `json:"estimatedLiquidationPrice"`
+	Leverage                  int32   `json:"leverage"`
+	Kind                      string  `json:"kind"`
+	SizeCurrency              float64 `json:"sizeCurrency"`
+	Delta                     float64 `json:"delta"`
+	RealizedFunding           float64 `json:"realizedFunding"`
+	RealizedProfitLoss        float64 `json:"realizedProfitLoss"`
+	SettlementPrice           float64 `json:"settlementPrice"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // 账户类
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetUmAccountService creates a service for `GET /user/exchange-apis/um-account`.
+func (c *Client) NewGetUmAccountService() *GetUmAccountService {
+	return &GetUmAccountService{c: c}
+}
+
 // GetUmAccountService get Binance PAPI UM account
 type GetUmAccountService struct {
 	c         *Client
@@ -597,63 +680,70 @@ func (s *GetUmAccountService) BindingId(v string) *GetUmAccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetUmAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetUmAccountService) Do(ctx context.Context, opts ...RequestOption) (res *UmAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/um-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(UmAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[UmAccountReply](ctx, s.c, r, opts...)
 }
 
 // UmAccountReply Binance PAPI UM account response
 type UmAccountReply struct {
-	TradeGroupId int32                `json:"tradeGroupId"`
-	Assets       []PapiAccountAsset   `json:"assets"`
+	TradeGroupId int32                 `json:"tradeGroupId"`
+	Assets       []PapiAccountAsset    `json:"assets"`
 	Positions    []PapiAccountPosition `json:"positions"`
-	Exchange     string               `json:"exchange"`
-	AccountType  string               `json:"accountType"`
-	UpdateTime   string               `json:"updateTime"`
+	Exchange     string                `json:"exchange"`
+	AccountType  string                `json:"accountType"`
+	UpdateTime   string                `json:"updateTime"`
 }
 
 // PapiAccountAsset asset info in PAPI UM/CM account
 type PapiAccountAsset struct {
-	Asset                    string `json:"asset"`
-	CrossWalletBalance       string `json:"crossWalletBalance"`
-	CrossUnPnl               string `json:"crossUnPnl"`
-	MaintMargin              string `json:"maintMargin"`
-	InitialMargin            string `json:"initialMargin"`
-	PositionInitialMargin    string `json:"positionInitialMargin"`
-	OpenOrderInitialMargin   string `json:"openOrderInitialMargin"`
-	UpdateTime               int64  `json:"updateTime"`
+	Asset                  string `json:"asset"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	UpdateTime             int64  `json:"updateTime"`
 }
 
 // PapiAccountPosition position info in PAPI UM/CM account
 type PapiAccountPosition struct {
-	Symbol          string `json:"symbol"`
-	PositionAmt     string `json:"positionAmt"`
-	PositionSide    string `json:"positionSide"`
-	EntryPrice      string `json:"entryPrice"`
-	BreakEvenPrice  string `json:"breakEvenPrice"`
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	PositionSide     string `json:"positionSide"`
+	EntryPrice       string `json:"entryPrice"`
+	BreakEvenPrice   string `json:"breakEvenPrice"`
 	UnrealizedProfit string `json:"unrealizedProfit"`
-	Leverage        string `json:"leverage"`
-	InitialMargin   string `json:"initialMargin"`
-	MaintMargin     string `json:"maintMargin"`
+	Leverage         string `json:"leverage"`
+	InitialMargin    string `json:"initialMargin"`
+	MaintMargin      string `json:"maintMargin"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetCmAccountService creates a service for `GET /user/exchange-apis/cm-account`.
+func (c *Client) NewGetCmAccountService() *GetCmAccountService {
+	return &GetCmAccountService{c: c}
+}
+
 // GetCmAccountService get Binance PAPI CM account
 type GetCmAccountService struct {
 	c         *Client
@@ -666,24 +756,26 @@ func (s *GetCmAccountService) BindingId(v string) *GetCmAccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetCmAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetCmAccountService) Do(ctx context.Context, opts ...RequestOption) (res *CmAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/cm-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(CmAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[CmAccountReply](ctx, s.c, r, opts...)
 }
 
 // CmAccountReply Binance PAPI CM account response
@@ -697,6 +789,11 @@ type CmAccountReply struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetPv1AccountService creates a service for `GET /user/exchange-apis/pv1-account`.
+func (c *Client) NewGetPv1AccountService() *GetPv1AccountService {
+	return &GetPv1AccountService{c: c}
+}
+
 // GetPv1AccountService get Binance PAPI PV1 account
 type GetPv1AccountService struct {
 	c         *Client
@@ -709,44 +806,51 @@ func (s *GetPv1AccountService) BindingId(v string) *GetPv1AccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetPv1AccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetPv1AccountService) Do(ctx context.Context, opts ...RequestOption) (res *Pv1AccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/pv1-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(Pv1AccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[Pv1AccountReply](ctx, s.c, r, opts...)
 }
 
 // Pv1AccountReply Binance PAPI PV1 account response
 type Pv1AccountReply struct {
-	Exchange                    string `json:"exchange"`
-	AccountType                 string `json:"accountType"`
-	UniMmr                      string `json:"uniMmr"`
-	AccountEquity               string `json:"accountEquity"`
-	ActualEquity                string `json:"actualEquity"`
-	AccountInitialMargin        string `json:"accountInitialMargin"`
-	AccountMaintMargin          string `json:"accountMaintMargin"`
-	AccountStatus               string `json:"accountStatus"`
-	VirtualMaxWithdrawAmount    string `json:"virtualMaxWithdrawAmount"`
-	TotalAvailableBalance       string `json:"totalAvailableBalance"`
-	TotalMarginOpenLoss         string `json:"totalMarginOpenLoss"`
-	UpdateTime                  string `json:"updateTime"`
+	Exchange                 string `json:"exchange"`
+	AccountType              string `json:"accountType"`
+	UniMmr                   string `json:"uniMmr"`
+	AccountEquity            string `json:"accountEquity"`
+	ActualEquity             string `json:"actualEquity"`
+	AccountInitialMargin     string `json:"accountInitialMargin"`
+	AccountMaintMargin       string `json:"accountMaintMargin"`
+	AccountStatus            string `json:"accountStatus"`
+	VirtualMaxWithdrawAmount string `json:"virtualMaxWithdrawAmount"`
+	TotalAvailableBalance    string `json:"totalAvailableBalance"`
+	TotalMarginOpenLoss      string `json:"totalMarginOpenLoss"`
+	UpdateTime               string `json:"updateTime"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetDapiAccountService creates a service for `GET /user/exchange-apis/dapi-account`.
+func (c *Client) NewGetDapiAccountService() *GetDapiAccountService {
+	return &GetDapiAccountService{c: c}
+}
+
 // GetDapiAccountService get Binance DAPI account
 type GetDapiAccountService struct {
 	c         *Client
@@ -759,24 +863,26 @@ func (s *GetDapiAccountService) BindingId(v string) *GetDapiAccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetDapiAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetDapiAccountService) Do(ctx context.Context, opts ...RequestOption) (res *DapiAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/dapi-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(DapiAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[DapiAccountReply](ctx, s.c, r, opts...)
 }
 
 // DapiAccountReply Binance DAPI account response
@@ -806,18 +912,23 @@ type DapiAsset struct {
 
 // DapiPosition position info in DAPI account
 type DapiPosition struct {
-	Symbol          string `json:"symbol"`
-	PositionAmt     string `json:"positionAmt"`
-	PositionSide    string `json:"positionSide"`
-	EntryPrice      string `json:"entryPrice"`
-	BreakEvenPrice  string `json:"breakEvenPrice"`
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	PositionSide     string `json:"positionSide"`
+	EntryPrice       string `json:"entryPrice"`
+	BreakEvenPrice   string `json:"breakEvenPrice"`
 	UnrealizedProfit string `json:"unrealizedProfit"`
-	Leverage        string `json:"leverage"`
-	Isolated        bool   `json:"isolated"`
+	Leverage         string `json:"leverage"`
+	Isolated         bool   `json:"isolated"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetFapiAccountService creates a service for `GET /user/exchange-apis/fapi-account`.
+func (c *Client) NewGetFapiAccountService() *GetFapiAccountService {
+	return &GetFapiAccountService{c: c}
+}
+
 // GetFapiAccountService get Binance FAPI account
 type GetFapiAccountService struct {
 	c         *Client
@@ -830,41 +941,43 @@ func (s *GetFapiAccountService) BindingId(v string) *GetFapiAccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetFapiAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetFapiAccountService) Do(ctx context.Context, opts ...RequestOption) (res *FapiAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/fapi-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(FapiAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[FapiAccountReply](ctx, s.c, r, opts...)
 }
 
 // FapiAccountReply Binance FAPI account response
 type FapiAccountReply struct {
-	TotalWalletBalance       string         `json:"totalWalletBalance"`
-	TotalUnrealizedProfit    string         `json:"totalUnrealizedProfit"`
-	TotalMarginBalance       string         `json:"totalMarginBalance"`
-	AvailableBalance         string         `json:"availableBalance"`
-	MaxWithdrawAmount        string         `json:"maxWithdrawAmount"`
-	TotalInitialMargin       string         `json:"totalInitialMargin"`
-	TotalMaintMargin         string         `json:"totalMaintMargin"`
-	TotalCrossWalletBalance  string         `json:"totalCrossWalletBalance"`
-	TotalCrossUnPnl          string         `json:"totalCrossUnPnl"`
-	Assets                   []FapiAsset    `json:"assets"`
-	Positions                []FapiPosition `json:"positions"`
-	Exchange                 string         `json:"exchange"`
-	AccountType              string         `json:"accountType"`
+	TotalWalletBalance      string         `json:"totalWalletBalance"`
+	TotalUnrealizedProfit   string         `json:"totalUnrealizedProfit"`
+	TotalMarginBalance      string         `json:"totalMarginBalance"`
+	AvailableBalance        string         `json:"availableBalance"`
+	MaxWithdrawAmount       string         `json:"maxWithdrawAmount"`
+	TotalInitialMargin      string         `json:"totalInitialMargin"`
+	TotalMaintMargin        string         `json:"totalMaintMargin"`
+	TotalCrossWalletBalance string         `json:"totalCrossWalletBalance"`
+	TotalCrossUnPnl         string         `json:"totalCrossUnPnl"`
+	Assets                  []FapiAsset    `json:"assets"`
+	Positions               []FapiPosition `json:"positions"`
+	Exchange                string         `json:"exchange"`
+	AccountType             string         `json:"accountType"`
 }
 
 // FapiAsset asset info in FAPI account
@@ -894,6 +1007,11 @@ type FapiPosition struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetCrossMarginAccountDetailService creates a service for `GET /user/exchange-apis/cross-margin-account-detail`.
+func (c *Client) NewGetCrossMarginAccountDetailService() *GetCrossMarginAccountDetailService {
+	return &GetCrossMarginAccountDetailService{c: c}
+}
+
 // GetCrossMarginAccountDetailService get Binance cross margin account detail
 type GetCrossMarginAccountDetailService struct {
 	c         *Client
@@ -906,24 +1024,26 @@ func (s *GetCrossMarginAccountDetailService) BindingId(v string) *GetCrossMargin
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetCrossMarginAccountDetailService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetCrossMarginAccountDetailService) Do(ctx context.Context, opts ...RequestOption) (res *CrossMarginAccountDetailReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/cross-margin-account-detail",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(CrossMarginAccountDetailReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[CrossMarginAccountDetailReply](ctx, s.c, r, opts...)
 }
 
 // CrossMarginAccountDetailReply Binance cross margin account detail response
@@ -952,6 +1072,11 @@ type CrossMarginUserAsset struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetLtpAccountService creates a service for `GET /user/exchange-apis/ltp-account`.
+func (c *Client) NewGetLtpAccountService() *GetLtpAccountService {
+	return &GetLtpAccountService{c: c}
+}
+
 // GetLtpAccountService get LTP account info
 type GetLtpAccountService struct {
 	c         *Client
@@ -964,24 +1089,26 @@ func (s *GetLtpAccountService) BindingId(v string) *GetLtpAccountService {
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetLtpAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetLtpAccountService) Do(ctx context.Context, opts ...RequestOption) (res *LtpAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/ltp-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(LtpAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[LtpAccountReply](ctx, s.c, r, opts...)
 }
 
 // LtpAccountReply LTP account info response
@@ -1009,6 +1136,11 @@ type LtpAccountItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetLtpPortfolioAssetService creates a service for `GET /user/exchange-apis/ltp-portfolio-asset`.
+func (c *Client) NewGetLtpPortfolioAssetService() *GetLtpPortfolioAssetService {
+	return &GetLtpPortfolioAssetService{c: c}
+}
+
 // GetLtpPortfolioAssetService get LTP portfolio assets
 type GetLtpPortfolioAssetService struct {
 	c         *Client
@@ -1021,24 +1153,26 @@ func (s *GetLtpPortfolioAssetService) BindingId(v string) *GetLtpPortfolioAssetS
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetLtpPortfolioAssetService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetLtpPortfolioAssetService) Do(ctx context.Context, opts ...RequestOption) (res *LtpPortfolioAssetReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/ltp-portfolio-asset",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(LtpPortfolioAssetReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[LtpPortfolioAssetReply](ctx, s.c, r, opts...)
 }
 
 // LtpPortfolioAssetReply LTP portfolio assets response
@@ -1069,6 +1203,11 @@ type LtpPortfolioAssetItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetDeribitAccountService creates a service for `GET /user/exchange-apis/deribit-account`.
+func (c *Client) NewGetDeribitAccountService() *GetDeribitAccountService {
+	return &GetDeribitAccountService{c: c}
+}
+
 // GetDeribitAccountService get Deribit account info
 type GetDeribitAccountService struct {
 	c         *Client
@@ -1081,24 +1220,26 @@ func (s *GetDeribitAccountService) BindingId(v string) *GetDeribitAccountService
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetDeribitAccountService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetDeribitAccountService) Do(ctx context.Context, opts ...RequestOption) (res *DeribitAccountReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/deribit-account",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(DeribitAccountReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[DeribitAccountReply](ctx, s.c, r, opts...)
 }
 
 // DeribitAccountReply Deribit account info response
@@ -1109,34 +1250,39 @@ type DeribitAccountReply struct {
 
 // DeribitAccountItem account info per currency in Deribit
 type DeribitAccountItem struct {
-	Currency                    string  `json:"currency"`
-	Equity                      float64 `json:"equity"`
-	Balance                     float64 `json:"balance"`
-	AvailableFunds              float64 `json:"availableFunds"`
-	AvailableWithdrawalFunds    float64 `json:"availableWithdrawalFunds"`
-	MarginBalance               float64 `json:"marginBalance"`
-	InitialMargin               float64 `json:"initialMargin"`
-	MaintenanceMargin           float64 `json:"maintenanceMargin"`
-	LockedBalance               float64 `json:"lockedBalance"`
-	TotalPl                     float64 `json:"totalPl"`
-	SessionUpl                  float64 `json:"sessionUpl"`
-	SessionRpl                  float64 `json:"sessionRpl"`
-	FuturesPl                   float64 `json:"futuresPl"`
-	OptionsValue                float64 `json:"optionsValue"`
-	OptionsDelta                float64 `json:"optionsDelta"`
-	OptionsGamma                float64 `json:"optionsGamma"`
-	OptionsVega                 float64 `json:"optionsVega"`
-	OptionsTheta                float64 `json:"optionsTheta"`
-	DeltaTotal                  float64 `json:"deltaTotal"`
-	MarginModel                 string  `json:"marginModel"`
-	PortfolioMarginingEnabled   bool    `json:"portfolioMarginingEnabled"`
-	CrossCollateralEnabled      bool    `json:"crossCollateralEnabled"`
+	Currency                  string  `json:"currency"`
+	Equity                    float64 `json:"equity"`
+	Balance                   float64 `json:"balance"`
+	AvailableFunds            float64 `json:"availableFunds"`
+	AvailableWithdrawalFunds  float64 `json:"availableWithdrawalFunds"`
+	MarginBalance             float64 `json:"marginBalance"`
+	InitialMargin             float64 `json:"initialMargin"`
+	MaintenanceMargin         float64 `json:"maintenanceMargin"`
+	LockedBalance             float64 `json:"lockedBalance"`
+	TotalPl                   float64 `json:"totalPl"`
+	SessionUpl                float64 `json:"sessionUpl"`
+	SessionRpl                float64 `json:"sessionRpl"`
+	FuturesPl                 float64 `json:"futuresPl"`
+	OptionsValue              float64 `json:"optionsValue"`
+	OptionsDelta              float64 `json:"optionsDelta"`
+	OptionsGamma              float64 `json:"optionsGamma"`
+	OptionsVega               float64 `json:"optionsVega"`
+	OptionsTheta              float64 `json:"optionsTheta"`
+	DeltaTotal                float64 `json:"deltaTotal"`
+	MarginModel               string  `json:"marginModel"`
+	PortfolioMarginingEnabled bool    `json:"portfolioMarginingEnabled"`
+	CrossCollateralEnabled    bool    `json:"crossCollateralEnabled"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Hyperliquid
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetHyperliquidSpotBalanceService creates a service for `GET /user/exchange-apis/hyperliquid-spot-balance`.
+func (c *Client) NewGetHyperliquidSpotBalanceService() *GetHyperliquidSpotBalanceService {
+	return &GetHyperliquidSpotBalanceService{c: c}
+}
+
 // GetHyperliquidSpotBalanceService get Hyperliquid spot account balance
 type GetHyperliquidSpotBalanceService struct {
 	c         *Client
@@ -1149,24 +1295,26 @@ func (s *GetHyperliquidSpotBalanceService) BindingId(v string) *GetHyperliquidSp
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetHyperliquidSpotBalanceService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetHyperliquidSpotBalanceService) Do(ctx context.Context, opts ...RequestOption) (res *HyperliquidSpotBalanceReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/hyperliquid-spot-balance",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(HyperliquidSpotBalanceReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[HyperliquidSpotBalanceReply](ctx, s.c, r, opts...)
 }
 
 // HyperliquidSpotBalanceReply Hyperliquid spot balance response
@@ -1188,6 +1336,11 @@ type HyperliquidSpotBalanceItem struct {
 
 // ─────────────────────────────────────────────────────────────────────────────
 
+// NewGetHyperliquidPositionsService creates a service for `GET /user/exchange-apis/hyperliquid-positions`.
+func (c *Client) NewGetHyperliquidPositionsService() *GetHyperliquidPositionsService {
+	return &GetHyperliquidPositionsService{c: c}
+}
+
 // GetHyperliquidPositionsService get Hyperliquid perpetual positions
 type GetHyperliquidPositionsService struct {
 	c         *Client
@@ -1200,24 +1353,26 @@ func (s *GetHyperliquidPositionsService) BindingId(v string) *GetHyperliquidPosi
 	return s
 }
 
+// validate reports the first parameter Do would reject.
+func (s *GetHyperliquidPositionsService) validate() error {
+	if s.bindingId == "" {
+		return errors.New("bindingId is required")
+	}
+	return nil
+}
+
 // Do send request
 func (s *GetHyperliquidPositionsService) Do(ctx context.Context, opts ...RequestOption) (res *HyperliquidPositionsReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/user/exchange-apis/hyperliquid-positions",
 		secType:  secTypeSigned,
 	}
 	r.setParam("bindingId", s.bindingId)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	res = new(HyperliquidPositionsReply)
-	err = json.Unmarshal(data, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return callAPIAs[HyperliquidPositionsReply](ctx, s.c, r, opts...)
 }
 
 // HyperliquidPositionsReply Hyperliquid perpetual positions response
@@ -0,0 +1,336 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FailoverConfig configures SetFailover.
+type FailoverConfig struct {
+	// BaseURLs are the REST gateways in order of preference; the first is
+	// the primary.
+	BaseURLs []string
+	// WSHosts are the WebSocket hosts in order of preference, used by
+	// WebSocketServices created without an explicit host.
+	WSHosts []string
+	// EjectAfter consecutive transport failures take an endpoint out of
+	// rotation. The default is 3.
+	EjectAfter int
+	// EjectFor is how long an ejected endpoint stays out of rotation unless
+	// a health check (see RunHealthChecks) finds it up again. The default is
+	// 30 seconds; after it one more failure ejects the endpoint again.
+	EjectFor time.Duration
+}
+
+// SetFailover spreads the client over several gateways. Requests go to the
+// first endpoint in cfg.BaseURLs that is not ejected, so traffic fails back
+// to the primary as soon as it recovers. When a request fails on one
+// endpoint it is sent to the next if that is safe: GETs after a transport
+// failure or a 502, 503 or 504, other methods only when the connection
+// could not be established at all, so a call that may have reached the
+// server is never sent twice.
+//
+// BaseURL is set to the primary. Pass a zero FailoverConfig to go back to
+// BaseURL alone.
+func (c *Client) SetFailover(cfg FailoverConfig) *Client {
+	if c.frozen("SetFailover") {
+		return c
+	}
+	if cfg.EjectAfter <= 0 {
+		cfg.EjectAfter = 3
+	}
+	if cfg.EjectFor <= 0 {
+		cfg.EjectFor = 30 * time.Second
+	}
+	cfg.BaseURLs, cfg.WSHosts = trimSlashes(cfg.BaseURLs), trimSlashes(cfg.WSHosts)
+	c.rest, c.wsHosts = nil, nil
+	if len(cfg.BaseURLs) > 0 {
+		c.BaseURL = cfg.BaseURLs[0]
+		c.rest = newEndpointPool(cfg.BaseURLs, cfg.EjectAfter, cfg.EjectFor)
+	}
+	if len(cfg.WSHosts) > 0 {
+		c.wsHosts = newEndpointPool(cfg.WSHosts, cfg.EjectAfter, cfg.EjectFor)
+	}
+	return c
+}
+
+// trimSlashes returns a copy of urls without trailing slashes, the form
+// BaseURL and WebSocket hosts are joined with paths in.
+func trimSlashes(urls []string) []string {
+	if urls == nil {
+		return nil
+	}
+	out := make([]string, len(urls))
+	for i, u := range urls {
+		out[i] = strings.TrimSuffix(u, "/")
+	}
+	return out
+}
+
+// ActiveBaseURL returns the base URL new requests are sent to.
+func (c *Client) ActiveBaseURL() string {
+	if c.rest == nil {
+		return c.settings().baseURL
+	}
+	return c.rest.candidates()[0]
+}
+
+// RunHealthChecks pings every failover base URL with PingService each
+// interval until ctx is done. A failed ping ejects the endpoint and a
+// successful one brings it back at once, so without health checks recovery
+// waits for FailoverConfig.EjectFor. It returns immediately when SetFailover
+// was not called with BaseURLs.
+//
+//	go client.RunHealthChecks(ctx, 10*time.Second)
+func (c *Client) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	pool := c.rest
+	if pool == nil {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		for _, u := range pool.urls {
+			err := c.NewPingServer().Do(ctx, withBaseURL(u), WithoutReadCache())
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				pool.eject(u)
+				c.logFailover(ctx, "qe health check failed", slog.String("baseURL", u), slog.Any("error", err))
+			} else {
+				pool.recover(u)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// withBaseURL sends the call to base, without failover.
+func withBaseURL(base string) RequestOption {
+	return func(r *request) {
+		r.baseURL = base
+		r.pinned = true
+	}
+}
+
+// baseURL returns the base URL for a new request.
+func (c *Client) baseURL() string {
+	return c.ActiveBaseURL()
+}
+
+// failoverTargets returns the base URLs to try for a request built against
+// base, in order.
+func (c *Client) failoverTargets(rl *requestLog) []string {
+	if c.rest == nil || rl.pinned {
+		return []string{rl.base}
+	}
+	targets := []string{rl.base}
+	for _, u := range c.rest.candidates() {
+		if u != rl.base {
+			targets = append(targets, u)
+		}
+	}
+	return targets
+}
+
+// retarget returns a copy of req sent to base instead of rl.base.
+func retarget(rl *requestLog, req *http.Request, base string) (*http.Request, error) {
+	to, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	from, err := url.Parse(rl.base)
+	if err != nil {
+		return nil, err
+	}
+	out := req.Clone(req.Context())
+	out.URL = &url.URL{
+		Scheme:   to.Scheme,
+		Host:     to.Host,
+		Path:     to.Path + strings.TrimPrefix(req.URL.Path, from.Path),
+		RawQuery: req.URL.RawQuery,
+	}
+	out.Host = ""
+	if req.GetBody != nil {
+		if out.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// canFailover reports whether a request that failed with err (a transport
+// error, or nil for a gateway status) may be sent again elsewhere.
+func canFailover(method string, err error) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	return err != nil && notSent(err)
+}
+
+// notSent reports whether err means the request never left the client.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// gatewayFailure reports whether status means the gateway, not the API,
+// failed.
+func gatewayFailure(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// sendWithFailover performs req on the first target and, when allowed, on
+// the following ones, recording each attempt in x.
+func (c *Client) sendWithFailover(ctx context.Context, rl *requestLog, req *http.Request, x *exchange) *http.Response {
+	targets := c.failoverTargets(rl)
+	for i, base := range targets {
+		attempt := req
+		if i > 0 {
+			var err error
+			if attempt, err = retarget(rl, req, base); err != nil {
+				x.err = err
+				return nil
+			}
+			c.logFailover(ctx, "qe failover", slog.String("endpoint", rl.endpoint), slog.String("baseURL", base))
+		}
+		x.attempts++
+		res, err := c.doHTTP(attempt)
+		last := i == len(targets)-1 || ctx.Err() != nil
+		if err != nil {
+			x.err = err
+			if !rl.pinned && c.rest != nil && ctx.Err() == nil {
+				c.rest.failure(base)
+			}
+			if last || !canFailover(req.Method, err) {
+				return nil
+			}
+			continue
+		}
+		if !rl.pinned && c.rest != nil {
+			c.rest.success(base)
+		}
+		if gatewayFailure(res.StatusCode) && !last && canFailover(req.Method, nil) {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+			continue
+		}
+		x.err = nil
+		return res
+	}
+	return nil
+}
+
+func (c *Client) logFailover(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if l := c.logger(); l != nil {
+		l.LogAttrs(ctx, slog.LevelWarn, msg, attrs...)
+	}
+}
+
+// endpointPool tracks the health of an ordered list of endpoints.
+type endpointPool struct {
+	urls       []string
+	ejectAfter int
+	ejectFor   time.Duration
+
+	mu       sync.Mutex
+	failures []int
+	ejected  []time.Time // ejected until
+}
+
+func newEndpointPool(urls []string, ejectAfter int, ejectFor time.Duration) *endpointPool {
+	return &endpointPool{
+		urls:       append([]string(nil), urls...),
+		ejectAfter: ejectAfter,
+		ejectFor:   ejectFor,
+		failures:   make([]int, len(urls)),
+		ejected:    make([]time.Time, len(urls)),
+	}
+}
+
+// candidates returns the endpoints in rotation by preference, followed by
+// the ejected ones as a last resort.
+func (p *endpointPool) candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	up := make([]string, 0, len(p.urls))
+	var down []string
+	for i, u := range p.urls {
+		if now.Before(p.ejected[i]) {
+			down = append(down, u)
+		} else {
+			up = append(up, u)
+		}
+	}
+	return append(up, down...)
+}
+
+func (p *endpointPool) index(u string) int {
+	for i, v := range p.urls {
+		if v == u {
+			return i
+		}
+	}
+	return -1
+}
+
+// failure records a transport failure on u, ejecting it after EjectAfter in
+// a row.
+func (p *endpointPool) failure(u string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.index(u); i >= 0 {
+		p.failures[i]++
+		if p.failures[i] >= p.ejectAfter {
+			p.ejected[i] = time.Now().Add(p.ejectFor)
+		}
+	}
+}
+
+// success records a response from u.
+func (p *endpointPool) success(u string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.index(u); i >= 0 {
+		p.failures[i] = 0
+	}
+}
+
+// eject takes u out of rotation, e.g. after a failed health check.
+func (p *endpointPool) eject(u string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.index(u); i >= 0 {
+		p.failures[i] = p.ejectAfter
+		p.ejected[i] = time.Now().Add(p.ejectFor)
+	}
+}
+
+// recover puts u back into rotation.
+func (p *endpointPool) recover(u string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.index(u); i >= 0 {
+		p.failures[i] = 0
+		p.ejected[i] = time.Time{}
+	}
+}
@@ -0,0 +1,190 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+// deadURL returns the URL of a server that is no longer listening.
+func deadURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+// droppingServer reads each request and closes the connection without a
+// response, so the client cannot tell whether the call was processed.
+func droppingServer(t *testing.T, hits *atomic.Int32) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestFailoverGetToNextBaseURL(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	dead := deadURL(t)
+	c := qe.NewClient(testAPIKey, testSecretKey).
+		SetFailover(qe.FailoverConfig{BaseURLs: []string{dead, srv.URL}, EjectAfter: 2})
+	if c.BaseURL != dead || c.ActiveBaseURL() != dead {
+		t.Fatalf("BaseURL = %q, active = %q", c.BaseURL, c.ActiveBaseURL())
+	}
+
+	for i := 0; i < 2; i++ {
+		var meta qe.ResponseMeta
+		if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+			t.Fatal(err)
+		}
+		if meta.Attempts != 2 {
+			t.Fatalf("call %d made %d attempts", i, meta.Attempts)
+		}
+	}
+	// Two failures in a row eject the primary.
+	if c.ActiveBaseURL() != srv.URL {
+		t.Fatalf("active = %q after ejection", c.ActiveBaseURL())
+	}
+	var meta qe.ResponseMeta
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil || meta.Attempts != 1 {
+		t.Fatalf("after ejection: err=%v attempts=%d", err, meta.Attempts)
+	}
+}
+
+func TestFailoverTrimsTrailingSlashes(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	cfg := qe.FailoverConfig{BaseURLs: []string{srv.URL + "/"}}
+
+	set := qe.NewClient(testAPIKey, testSecretKey).SetFailover(cfg)
+	built, err := qe.New(qe.WithCredentials(testAPIKey, testSecretKey), qe.WithFailover(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, c := range map[string]*qe.Client{"SetFailover": set, "WithFailover": built} {
+		if c.ActiveBaseURL() != srv.URL {
+			t.Fatalf("%s: active = %q", name, c.ActiveBaseURL())
+		}
+		if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	if cfg.BaseURLs[0] != srv.URL+"/" {
+		t.Fatalf("caller's config modified: %q", cfg.BaseURLs[0])
+	}
+}
+
+func TestFailoverGetOnGatewayError(t *testing.T) {
+	primary := qetest.NewServer(testAPIKey, testSecretKey)
+	defer primary.Close()
+	secondary := qetest.NewServer(testAPIKey, testSecretKey)
+	defer secondary.Close()
+	primary.InjectFault(qetest.Fault{HTTPStatus: http.StatusBadGateway})
+	c := qe.NewClient(testAPIKey, testSecretKey).
+		SetFailover(qe.FailoverConfig{BaseURLs: []string{primary.URL, secondary.URL}})
+
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background()); err == nil {
+		t.Fatal("POST answered with 502 must not be resent")
+	}
+	if n := len(secondary.Requests()); n != 1 {
+		t.Fatalf("secondary saw %d requests, want 1", n)
+	}
+}
+
+func TestFailoverNeverResendsAmbiguousMutation(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	var hits atomic.Int32
+	c := qe.NewClient(testAPIKey, testSecretKey).
+		SetFailover(qe.FailoverConfig{BaseURLs: []string{droppingServer(t, &hits), srv.URL}})
+
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background()); err == nil {
+		t.Fatal("expected the dropped POST to fail")
+	}
+	if hits.Load() != 1 || len(srv.Requests()) != 0 {
+		t.Fatalf("primary hits = %d, secondary requests = %d", hits.Load(), len(srv.Requests()))
+	}
+
+	// A GET is safe to resend.
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// A POST whose connection was refused never left the client.
+	c.SetFailover(qe.FailoverConfig{BaseURLs: []string{deadURL(t), srv.URL}})
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHealthChecksFailBack(t *testing.T) {
+	primary := qetest.NewServer(testAPIKey, testSecretKey)
+	defer primary.Close()
+	secondary := qetest.NewServer(testAPIKey, testSecretKey)
+	defer secondary.Close()
+	primary.InjectFault(qetest.Fault{Path: "/ping", Times: 1})
+	c := qe.NewClient(testAPIKey, testSecretKey).
+		SetFailover(qe.FailoverConfig{BaseURLs: []string{primary.URL, secondary.URL}, EjectFor: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var seenSecondary atomic.Bool
+	go func() {
+		for ctx.Err() == nil {
+			if c.ActiveBaseURL() == secondary.URL {
+				seenSecondary.Store(true)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		c.RunHealthChecks(ctx, 20*time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !seenSecondary.Load() || c.ActiveBaseURL() != primary.URL {
+		if time.Now().After(deadline) {
+			t.Fatalf("active = %q, saw secondary = %v", c.ActiveBaseURL(), seenSecondary.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestWebSocketFailsOverToNextHost(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetFailover(qe.FailoverConfig{WSHosts: []string{"ws" + deadURL(t)[len("http"):], srv.WSHost()}})
+	lk, err := c.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := c.NewWebSocketService()
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := srv.WaitForWSClients(1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
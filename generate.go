@@ -0,0 +1,4 @@
+package qe_connector
+
+//go:generate go run ./internal/svcgen -spec api/strategy-api.yaml -tags 余额类,持仓类,账户类,Hyperliquid -out exchange_balance_gen.go -test exchange_balance_gen_test.go
+//go:generate go run ./internal/svcgen -spec api/strategy-api.yaml -tags 交易类,交易类V2 -out trading_gen.go -test trading_gen_test.go
@@ -0,0 +1,268 @@
+package qe_connector
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeConfig configures SetHedging.
+type HedgeConfig struct {
+	// Endpoints are the endpoint prefixes whose GETs are hedged. The default
+	// is V2 master orders (detail polling) and the exchange_balance reads.
+	Endpoints []string
+	// Percentile of recent response times after which the hedge is sent,
+	// between 0 and 1. The default is 0.95.
+	Percentile float64
+	// MinDelay and MaxDelay bound the hedge delay; MaxDelay is also used
+	// until enough responses have been timed. The defaults are 10ms and 1s.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Budget caps hedges at this fraction of eligible calls, so a slow
+	// server does not get twice the load. The default is 0.1.
+	Budget float64
+}
+
+// HedgeStats counts hedging activity since SetHedging.
+type HedgeStats struct {
+	// Calls is the number of eligible calls.
+	Calls int64
+	// Hedged is the number of calls a hedge was sent for, and Wins how many
+	// of those the hedge answered first.
+	Hedged int64
+	Wins   int64
+	// Denied counts hedges the budget did not allow.
+	Denied int64
+}
+
+// HedgeObserver is implemented by an Instrumentation that wants to know
+// about hedges. ObserveHedge is called once per hedge sent, with won set
+// when the hedge answered first.
+type HedgeObserver interface {
+	ObserveHedge(ctx context.Context, endpoint string, won bool)
+}
+
+// SetHedging hedges slow reads: when a GET to one of cfg.Endpoints has not
+// been answered after the configured percentile of recent response times,
+// a second copy, signed anew, is sent and the first answer wins; the other
+// request is cancelled. Only GETs are ever hedged. Pass nil to turn it off.
+func (c *Client) SetHedging(cfg *HedgeConfig) *Client {
+	if c.frozen("SetHedging") {
+		return c
+	}
+	if cfg == nil {
+		c.hedge = nil
+		return c
+	}
+	h := &hedger{cfg: *cfg, samples: map[string]*latencyWindow{}}
+	if len(h.cfg.Endpoints) == 0 {
+		h.cfg.Endpoints = []string{MasterOrdersV2Endpoint, ExchangeBalanceEndpoint}
+	}
+	if h.cfg.Percentile <= 0 || h.cfg.Percentile > 1 {
+		h.cfg.Percentile = 0.95
+	}
+	if h.cfg.MinDelay <= 0 {
+		h.cfg.MinDelay = 10 * time.Millisecond
+	}
+	if h.cfg.MaxDelay <= 0 {
+		h.cfg.MaxDelay = time.Second
+	}
+	if h.cfg.Budget <= 0 {
+		h.cfg.Budget = 0.1
+	}
+	c.hedge = h
+	return c
+}
+
+// HedgeStats returns the hedging counters, or zero when hedging is off.
+func (c *Client) HedgeStats() HedgeStats {
+	h := c.hedge
+	if h == nil {
+		return HedgeStats{}
+	}
+	return HedgeStats{
+		Calls:  h.calls.Load(),
+		Hedged: h.hedged.Load(),
+		Wins:   h.wins.Load(),
+		Denied: h.denied.Load(),
+	}
+}
+
+// hedgeMinSamples is how many response times a prefix needs before the
+// percentile is used instead of MaxDelay.
+const hedgeMinSamples = 20
+
+// hedgeMaxTokens caps the budget saved up while nothing is slow.
+const hedgeMaxTokens = 10
+
+type hedger struct {
+	cfg HedgeConfig
+
+	mu      sync.Mutex
+	samples map[string]*latencyWindow
+	tokens  float64
+
+	calls, hedged, wins, denied atomic.Int64
+}
+
+// prefix returns the configured prefix endpoint falls under.
+func (h *hedger) prefix(endpoint string) (string, bool) {
+	for _, p := range h.cfg.Endpoints {
+		if strings.HasPrefix(endpoint, p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// delay returns the hedge delay for prefix and earns budget for the call.
+func (h *hedger) delay(prefix string) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens = min(h.tokens+h.cfg.Budget, hedgeMaxTokens)
+	w := h.samples[prefix]
+	if w == nil || w.n < hedgeMinSamples {
+		return h.cfg.MaxDelay
+	}
+	return min(max(w.percentile(h.cfg.Percentile), h.cfg.MinDelay), h.cfg.MaxDelay)
+}
+
+// spend takes budget for one hedge.
+func (h *hedger) spend() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+func (h *hedger) observe(prefix string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := h.samples[prefix]
+	if w == nil {
+		w = new(latencyWindow)
+		h.samples[prefix] = w
+	}
+	w.add(d)
+}
+
+// answered reports whether x is an answer worth returning, rather than a
+// failure the other copy may still beat.
+func (x *exchange) answered() bool {
+	return x.err == nil && x.status < http.StatusInternalServerError
+}
+
+type hedgeResult struct {
+	x     *exchange
+	hedge bool
+}
+
+// hedged sends req and, if it is slow, a re-signed copy, returning the
+// first answer.
+func (c *Client) hedged(h *hedger, prefix string, rl *requestLog, req *http.Request) *exchange {
+	h.calls.Add(1)
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	results := make(chan hedgeResult, 2)
+	start := time.Now()
+	go func() { results <- hedgeResult{x: c.attempt(rl, req.WithContext(ctx))} }()
+
+	timer := time.NewTimer(h.delay(prefix))
+	defer timer.Stop()
+	pending, sent := 1, false
+	var failed *exchange
+	for {
+		select {
+		case <-timer.C:
+			if !h.spend() {
+				h.denied.Add(1)
+				continue
+			}
+			u, err := rl.resign()
+			if err != nil {
+				continue
+			}
+			copyReq := req.Clone(ctx)
+			copyReq.URL, copyReq.Host = u, ""
+			pending, sent = pending+1, true
+			h.hedged.Add(1)
+			go func() { results <- hedgeResult{x: c.attempt(rl, copyReq), hedge: true} }()
+		case r := <-results:
+			pending--
+			if !r.x.answered() && pending > 0 {
+				failed = r.x
+				continue
+			}
+			if r.x.answered() {
+				h.observe(prefix, time.Since(start))
+			}
+			if sent {
+				if r.hedge && r.x.answered() {
+					h.wins.Add(1)
+				}
+				if o, ok := c.instr.(HedgeObserver); ok {
+					o.ObserveHedge(req.Context(), rl.endpoint, r.hedge && r.x.answered())
+				}
+				// Count the HTTP requests of both copies.
+				if failed != nil {
+					r.x.attempts += failed.attempts
+				} else {
+					r.x.attempts++
+				}
+				r.x.hedged = true
+			}
+			return r.x
+		}
+	}
+}
+
+// latencyWindowSize is how many recent response times a latencyWindow
+// keeps.
+const latencyWindowSize = 128
+
+type latencyWindow struct {
+	buf [latencyWindowSize]time.Duration
+	n   int
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.buf[w.n%latencyWindowSize] = d
+	w.n++
+}
+
+func (w *latencyWindow) percentile(p float64) time.Duration {
+	s := append([]time.Duration(nil), w.buf[:min(w.n, latencyWindowSize)]...)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	return s[int(p*float64(len(s)-1))]
+}
+
+// resignURL returns a function that signs a copy of r again with a fresh
+// timestamp and returns its URL. r itself is left alone, so the function
+// may run while the call is being logged.
+func (c *Client) resignURL(ctx context.Context, r *request) func() (*url.URL, error) {
+	return func() (*url.URL, error) {
+		cp := *r
+		cp.query = cloneValues(r.query)
+		cp.form = cloneValues(r.form)
+		if err := c.parseRequest(ctx, &cp); err != nil {
+			return nil, err
+		}
+		return url.Parse(cp.fullURL)
+	}
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vs := range v {
+		out[k] = append([]string(nil), vs...)
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+// slowFirst makes the first request matching method and path prefix take d.
+func slowFirst(srv *qetest.Server, method, prefix string, d time.Duration) {
+	var seen atomic.Int32
+	srv.SetLatencyFunc(func(r *http.Request) time.Duration {
+		if r.Method == method && strings.HasPrefix(r.URL.Path, prefix) && seen.Add(1) == 1 {
+			return d
+		}
+		return 0
+	})
+}
+
+func TestHedgedReadWins(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetHedging(&qe.HedgeConfig{MaxDelay: 50 * time.Millisecond, Budget: 1})
+	id := createTWAP(t, c, "BTCUSDT")
+	slowFirst(srv, http.MethodGet, qe.MasterOrdersV2Endpoint+"/", 5*time.Second)
+
+	var meta qe.ResponseMeta
+	start := time.Now()
+	detail, err := c.NewGetMasterOrderDetailV2Service().MasterOrderId(id).Do(context.Background(), qe.WithResponseMeta(&meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("hedged read took %v", elapsed)
+	}
+	if detail.MasterOrder.MasterOrderId != id || !meta.Hedged || meta.Attempts != 2 {
+		t.Fatalf("detail = %+v, meta = %+v", detail, meta)
+	}
+	if s := c.HedgeStats(); s.Calls != 1 || s.Hedged != 1 || s.Wins != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	// A fast read is not hedged.
+	if _, err := c.NewGetMasterOrderDetailV2Service().MasterOrderId(id).Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Hedged || meta.Attempts != 1 {
+		t.Fatalf("fast read meta = %+v", meta)
+	}
+}
+
+func TestHedgingSkipsWritesAndRespectsBudget(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetHedging(&qe.HedgeConfig{
+			Endpoints: []string{qe.MasterOrdersV2Endpoint, "/user/trading/v2/listen-key"},
+			MaxDelay:  10 * time.Millisecond,
+		})
+
+	slowFirst(srv, http.MethodPost, "/user/trading/v2/listen-key", 100*time.Millisecond)
+	var meta qe.ResponseMeta
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Hedged || meta.Attempts != 1 || len(srv.Requests()) != 1 {
+		t.Fatalf("POST meta = %+v, requests = %d", meta, len(srv.Requests()))
+	}
+
+	// The default budget of 10% has nothing saved up for the first call.
+	slowFirst(srv, http.MethodGet, qe.MasterOrdersV2Endpoint, 100*time.Millisecond)
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if s := c.HedgeStats(); meta.Hedged || s.Calls != 1 || s.Hedged != 0 || s.Denied != 1 {
+		t.Fatalf("meta = %+v, stats = %+v", meta, s)
+	}
+}
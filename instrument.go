@@ -0,0 +1,76 @@
+package qe_connector
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Instrumentation receives callbacks around a Client's REST calls and
+// WebSocket activity, for tracing and metrics. Package otelqe implements it
+// with OpenTelemetry.
+type Instrumentation interface {
+	// StartRequest is called before a REST request is sent. It may add
+	// headers to req (e.g. W3C trace context) and returns the context of the
+	// call and a function called once with its outcome.
+	StartRequest(ctx context.Context, req *http.Request, endpoint string) (context.Context, func(RequestResult))
+
+	// StartWebSocket is called when a WebSocketService starts op, one of
+	// WebSocketOpConnect, WebSocketOpReconnect or WebSocketOpMessage. The
+	// returned function is called once with the outcome.
+	StartWebSocket(ctx context.Context, op string) (context.Context, func(error))
+
+	// MessageLag reports how long after its UpdatedAt a master_data or
+	// order_data push (msgType) was handled.
+	MessageLag(ctx context.Context, msgType ClientMessageType, lag time.Duration)
+}
+
+// WebSocket operations reported to Instrumentation.StartWebSocket.
+const (
+	WebSocketOpConnect   = "connect"
+	WebSocketOpReconnect = "reconnect"
+	WebSocketOpMessage   = "message"
+)
+
+// RequestResult is the outcome of a REST call.
+type RequestResult struct {
+	Method   string
+	Endpoint string
+	// Status is the HTTP status, or 0 when no response was received.
+	Status  int
+	Latency time.Duration
+	// Code and Reason are set for API errors.
+	Code   int
+	Reason string
+	// TraceId is the server's trace id from the response envelope.
+	TraceId string
+	Err     error
+}
+
+// SetInstrumentation reports the client's REST calls and the activity of
+// its WebSocketServices to i. Pass nil to turn it off.
+func (c *Client) SetInstrumentation(i Instrumentation) *Client {
+	if c.frozen("SetInstrumentation") {
+		return c
+	}
+	c.instr = i
+	return c
+}
+
+// startWebSocket wraps Instrumentation.StartWebSocket for when none is set.
+func (c *Client) startWebSocket(ctx context.Context, op string) (context.Context, func(error)) {
+	if c.instr == nil {
+		return ctx, func(error) {}
+	}
+	return c.instr.StartWebSocket(ctx, op)
+}
+
+// observeLag reports the lag of a push carrying updatedAt (RFC 3339).
+func (c *Client) observeLag(ctx context.Context, msgType ClientMessageType, updatedAt string) {
+	if c.instr == nil || updatedAt == "" {
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, updatedAt); err == nil {
+		c.instr.MessageLag(ctx, msgType, time.Since(t))
+	}
+}
@@ -0,0 +1,1013 @@
+// Command svcgen writes the builder services of the strategy-api endpoints
+// described in api/strategy-api.yaml, and tests for them. Run it through go
+// generate in the module root; -tags selects the operations of one output
+// file.
+//
+// Each operation becomes a <OperationId>Service with a Client constructor, one
+// setter per parameter and a Do method that validates the parameters before
+// sending the signed request; its response schema and the schemas it refers
+// to become the DTOs. Operations are written in spec order, grouped
+// under a banner per tag.
+//
+// Path and query parameters are sent V1-style, in the query string. The
+// properties of a JSON request body are sent as the body of a V2 request
+// (callAPIV2As) instead. A parameter whose schema enumerates a single value
+// is a constant sent with every request, and one with a default is sent
+// with it when unset. Parameters and properties may be strings, integers
+// (int32/int64), doubles and booleans. Body parameters may also be arrays of
+// strings; properties may also be other schemas, arrays of them, string maps
+// and nullable values, which become pointers. A message may be an array,
+// which Do returns as a slice that is never nil.
+//
+// What OpenAPI has no words for is carried by extensions:
+//
+//   - x-go-imports (root): the packages x-go-type may refer to.
+//   - x-go-type: the Go type of a parameter, property or array item, e.g. an
+//     enum; the schema type still decides how it is sent and sampled.
+//   - x-go-name: the Go name of a parameter or property.
+//   - x-go-doc: the setter comment, replacing the generated one.
+//   - x-go-default: a Go expression sent when an optional parameter is
+//     unset, for defaults that depend on other parameters.
+//   - x-go-check (operation): the service's validate method, which Do calls
+//     first, ends with its handwritten check method, for the rules the spec
+//     cannot express.
+//   - x-go-test-unset (operation): parameters the generated test leaves
+//     unset, because check rejects them together with others.
+//
+// Deprecated properties are tagged json:"-": the handwritten UnmarshalJSON
+// of their type fills them from the fields that replace them.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const generator = "svcgen"
+
+func main() {
+	specPath := flag.String("spec", "api/strategy-api.yaml", "OpenAPI spec to read")
+	tags := flag.String("tags", "", "comma-separated tags of the operations to write; empty for all")
+	out := flag.String("out", "exchange_balance_gen.go", "services file to write")
+	testOut := flag.String("test", "exchange_balance_gen_test.go", "test file to write")
+	flag.Parse()
+
+	src, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var only []string
+	if *tags != "" {
+		only = strings.Split(*tags, ",")
+	}
+	code, test, err := generate(filepath.Base(*specPath), src, only, caseName(*testOut))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*testOut, test, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type operation struct {
+	id      string
+	method  string
+	path    string
+	summary string
+	desc    string
+	tag     string
+	params  []param
+	body    bool     // params other than path ones go in a JSON body
+	check   bool     // validate calls s.check()
+	unset   []string // parameters the test leaves unset
+	reply   string   // Go type of the message, or of its elements
+	schema  string   // schema to write for reply, if any
+	list    bool     // the message is an array
+}
+
+type param struct {
+	name     string
+	goName   string
+	desc     string
+	doc      string
+	in       string // path, query or body
+	goType   string
+	base     string // string, bool, int32, int64, float64 or []string
+	required bool
+	constant string // the only value of an enum
+	def      string // default
+	defExpr  string // Go expression sent when unset
+	example  string
+	same     bool // a query parameter repeating the path parameter of that name
+}
+
+// field reports whether p has a field and a setter.
+func (p param) field() bool { return p.constant == "" && !p.same }
+
+// pointer reports whether p is unset while its field is nil.
+func (p param) pointer() bool { return !p.required && p.base != "[]string" }
+
+// missing returns the error of a Do call without the required p.
+func (p param) missing() string {
+	if p.base == "[]string" {
+		return p.name + " must not be empty"
+	}
+	return p.name + " is required"
+}
+
+type schema struct {
+	name  string
+	desc  string
+	props []prop
+}
+
+type prop struct {
+	json       string
+	goName     string
+	goType     string
+	ref        string // element schema of an array
+	nullable   bool
+	deprecated bool
+	comment    string
+	sample     any
+}
+
+type spec struct {
+	ops     []operation
+	schemas map[string]*schema
+	imports []string
+}
+
+// generate returns the services file and its test file for the operations
+// of the spec in src tagged with one of tags, or for all if tags is empty.
+// The test's identifiers start with name, so that several test files can
+// share a package.
+func generate(filename string, src []byte, tags []string, name string) (code, test []byte, err error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, fmt.Errorf("%s: empty spec", filename)
+	}
+	sp, err := parse(doc.Content[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if len(tags) > 0 {
+		sp.ops = slices.DeleteFunc(sp.ops, func(op operation) bool { return !slices.Contains(tags, op.tag) })
+	}
+	if code, err = format.Source(writeServices(filename, sp)); err != nil {
+		return nil, nil, err
+	}
+	if test, err = format.Source(writeTests(filename, sp, name)); err != nil {
+		return nil, nil, err
+	}
+	return code, test, nil
+}
+
+// get returns the value of key in the mapping n, or nil.
+func get(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// value returns the scalar at path below n, or "".
+func value(n *yaml.Node, path ...string) string {
+	for _, k := range path {
+		n = get(n, k)
+	}
+	if n == nil {
+		return ""
+	}
+	return n.Value
+}
+
+// pairs calls f for each key and value of the mapping n, in order.
+func pairs(n *yaml.Node, f func(k string, v *yaml.Node) error) error {
+	if n == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if err := f(n.Content[i].Value, n.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// strs returns the scalars of the sequence n.
+func strs(n *yaml.Node) []string {
+	var s []string
+	if n != nil {
+		for _, c := range n.Content {
+			s = append(s, c.Value)
+		}
+	}
+	return s
+}
+
+func refName(n *yaml.Node) string {
+	return strings.TrimPrefix(value(n, "$ref"), "#/components/schemas/")
+}
+
+// caseName returns the name the test identifiers of the test file start
+// with: exchange_balance_gen_test.go gives exchangeBalance.
+func caseName(testFile string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(testFile), "_test.go"), "_gen")
+	words := strings.Split(base, "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = exported(words[i])
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func goName(n *yaml.Node, name string) string {
+	if gn := value(n, "x-go-name"); gn != "" {
+		return gn
+	}
+	return exported(name)
+}
+
+// scalarType returns the Go type of a scalar schema, or "".
+func scalarType(n *yaml.Node) string {
+	switch t, f := value(n, "type"), value(n, "format"); {
+	case t == "string" && f == "":
+		return "string"
+	case t == "boolean":
+		return "bool"
+	case t == "integer" && f == "int32":
+		return "int32"
+	case t == "integer" && f == "int64":
+		return "int64"
+	case t == "number" && f == "double":
+		return "float64"
+	}
+	return ""
+}
+
+// sampleOf returns the value the tests use for a scalar of Go type base;
+// strings are s.
+func sampleOf(base, s string) any {
+	switch base {
+	case "bool":
+		return true
+	case "int32":
+		return 32
+	case "int64":
+		return 1700000000000
+	case "float64":
+		return 1.5
+	}
+	return s
+}
+
+// literal returns the scalar v as a Go constant of type base.
+func literal(base, v string) (string, error) {
+	switch base {
+	case "string":
+		return strconv.Quote(v), nil
+	case "bool":
+		_, err := strconv.ParseBool(v)
+		return v, err
+	case "int32", "int64":
+		_, err := strconv.ParseInt(v, 10, 64)
+		return v, err
+	case "float64":
+		_, err := strconv.ParseFloat(v, 64)
+		return v, err
+	}
+	return "", fmt.Errorf("no %s literals", base)
+}
+
+func parseProp(js string, pn *yaml.Node) (prop, error) {
+	p := prop{
+		json:       js,
+		goName:     goName(pn, js),
+		nullable:   value(pn, "nullable") == "true",
+		deprecated: value(pn, "deprecated") == "true",
+	}
+	base := scalarType(pn)
+	switch items := get(pn, "items"); {
+	case base != "":
+		p.goType, p.sample = base, sampleOf(base, js+"-value")
+	case refName(pn) != "":
+		p.ref = refName(pn)
+		p.goType = p.ref
+	case value(pn, "type") == "array" && refName(items) != "":
+		p.ref = refName(items)
+		p.goType = "[]" + p.ref
+	case value(pn, "type") == "object" && scalarType(get(pn, "additionalProperties")) == "string":
+		p.goType, p.sample = "map[string]string", map[string]any{js + "-key": js + "-value"}
+	case value(pn, "type") == "" && len(pn.Content) == 0:
+		// Untyped, e.g. the Envelope's message.
+		p.goType = "any"
+	default:
+		return p, fmt.Errorf("property %s: unsupported type %q format %q", js, value(pn, "type"), value(pn, "format"))
+	}
+	if t := value(pn, "x-go-type"); t != "" {
+		p.goType = t
+	}
+	if p.nullable {
+		p.goType = "*" + p.goType
+	}
+	if p.deprecated {
+		p.comment = value(pn, "description")
+	}
+	return p, nil
+}
+
+func parseParam(p param, meta, sn *yaml.Node) (param, error) {
+	p.goName = goName(meta, p.name)
+	p.desc = value(meta, "description")
+	p.doc = strings.TrimSpace(value(meta, "x-go-doc"))
+	p.base = scalarType(sn)
+	if p.base == "" && value(sn, "type") == "array" && scalarType(get(sn, "items")) == "string" && p.in == "body" {
+		p.base = "[]string"
+	}
+	if p.base == "" {
+		return p, fmt.Errorf("parameter %s: unsupported type %q format %q", p.name, value(sn, "type"), value(sn, "format"))
+	}
+	p.goType = p.base
+	if t := value(sn, "x-go-type"); t != "" {
+		p.goType = t
+	}
+	if enum := strs(get(sn, "enum")); len(enum) > 0 {
+		if len(enum) != 1 || !p.required || p.base != "string" {
+			return p, fmt.Errorf("parameter %s: only required single-value string enums are supported", p.name)
+		}
+		p.constant = enum[0]
+	}
+	if p.required && p.base != "string" && p.base != "[]string" {
+		return p, fmt.Errorf("parameter %s: required %s parameters are not supported", p.name, p.base)
+	}
+	if d := get(sn, "default"); d != nil {
+		if _, err := literal(p.base, d.Value); err != nil {
+			return p, fmt.Errorf("parameter %s: default: %w", p.name, err)
+		}
+		p.def = d.Value
+	}
+	if e := value(sn, "x-go-default"); e != "" {
+		if p.def != "" || !p.pointer() {
+			return p, fmt.Errorf("parameter %s: x-go-default needs an optional parameter without a default", p.name)
+		}
+		p.defExpr = e
+	}
+	if e := get(sn, "example"); e != nil {
+		if _, err := literal(p.base, e.Value); err != nil {
+			return p, fmt.Errorf("parameter %s: example: %w", p.name, err)
+		}
+		p.example = e.Value
+	}
+	return p, nil
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func parseOperation(path, method string, n *yaml.Node, sp *spec) (operation, error) {
+	op := operation{
+		id:      value(n, "operationId"),
+		method:  method,
+		path:    path,
+		summary: value(n, "summary"),
+		desc:    strings.TrimSpace(value(n, "description")),
+		check:   value(n, "x-go-check") == "true",
+		unset:   strs(get(n, "x-go-test-unset")),
+	}
+	if op.id == "" || op.summary == "" {
+		return op, fmt.Errorf("%s: need an operationId and a summary", path)
+	}
+	switch method {
+	case "get", "post", "put", "delete":
+	default:
+		return op, fmt.Errorf("%s: unsupported method %s", path, method)
+	}
+	if tags := get(n, "tags"); tags != nil && len(tags.Content) > 0 {
+		op.tag = tags.Content[0].Value
+	}
+
+	inPath := map[string]bool{}
+	if ps := get(n, "parameters"); ps != nil {
+		for _, pn := range ps.Content {
+			p := param{
+				name:     value(pn, "name"),
+				in:       value(pn, "in"),
+				required: value(pn, "required") == "true",
+			}
+			if p.in != "path" && p.in != "query" {
+				return op, fmt.Errorf("%s: parameter %s: unsupported location %q", op.id, p.name, p.in)
+			}
+			p, err := parseParam(p, pn, get(pn, "schema"))
+			if err != nil {
+				return op, fmt.Errorf("%s: %w", op.id, err)
+			}
+			if p.in == "path" {
+				if !p.required || p.base != "string" {
+					return op, fmt.Errorf("%s: path parameter %s must be a required string", op.id, p.name)
+				}
+				inPath[p.name] = true
+			} else if inPath[p.name] {
+				p.same = true
+			}
+			op.params = append(op.params, p)
+		}
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		if !inPath[m[1]] {
+			return op, fmt.Errorf("%s: path parameter %s is not declared", op.id, m[1])
+		}
+		delete(inPath, m[1])
+	}
+	if len(inPath) > 0 {
+		return op, fmt.Errorf("%s: path parameters missing from %s", op.id, path)
+	}
+
+	if rb := get(n, "requestBody"); rb != nil {
+		bs := get(get(get(rb, "content"), "application/json"), "schema")
+		if value(bs, "type") != "object" {
+			return op, fmt.Errorf("%s: the request body must be a JSON object", op.id)
+		}
+		for _, p := range op.params {
+			if p.in == "query" {
+				return op, fmt.Errorf("%s: query parameter %s next to a request body", op.id, p.name)
+			}
+		}
+		required := strs(get(bs, "required"))
+		err := pairs(get(bs, "properties"), func(name string, pn *yaml.Node) error {
+			p, err := parseParam(param{name: name, in: "body", required: slices.Contains(required, name)}, pn, pn)
+			op.params = append(op.params, p)
+			return err
+		})
+		if err != nil {
+			return op, fmt.Errorf("%s: %w", op.id, err)
+		}
+		op.body = true
+	}
+	for _, name := range op.unset {
+		if !slices.ContainsFunc(op.params, func(p param) bool { return p.name == name && p.field() && !p.required && p.defExpr == "" }) {
+			return op, fmt.Errorf("%s: x-go-test-unset: no optional parameter %s", op.id, name)
+		}
+	}
+
+	if allOf := get(get(get(get(get(get(n, "responses"), "200"), "content"), "application/json"), "schema"), "allOf"); allOf != nil {
+		for _, part := range allOf.Content {
+			m := get(get(part, "properties"), "message")
+			if m == nil {
+				continue
+			}
+			if value(m, "type") == "array" {
+				op.list = true
+				m = get(m, "items")
+			}
+			op.schema = refName(m)
+			op.reply = op.schema
+			if t := value(m, "x-go-type"); t != "" && op.list {
+				op.schema, op.reply = "", t
+			}
+		}
+	}
+	if op.reply == "" || op.schema != "" && sp.schemas[op.schema] == nil {
+		return op, fmt.Errorf("%s: response message schema %q not found", op.id, op.schema)
+	}
+	return op, nil
+}
+
+func parse(root *yaml.Node) (*spec, error) {
+	sp := &spec{schemas: map[string]*schema{}, imports: strs(get(root, "x-go-imports"))}
+	err := pairs(get(get(root, "components"), "schemas"), func(name string, n *yaml.Node) error {
+		s := &schema{name: name, desc: strings.TrimSpace(value(n, "description"))}
+		sp.schemas[name] = s
+		return pairs(get(n, "properties"), func(js string, pn *yaml.Node) error {
+			p, err := parseProp(js, pn)
+			if err != nil {
+				return fmt.Errorf("schema %s: %w", name, err)
+			}
+			s.props = append(s.props, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = pairs(get(root, "paths"), func(path string, item *yaml.Node) error {
+		return pairs(item, func(method string, n *yaml.Node) error {
+			op, err := parseOperation(path, method, n, sp)
+			if err != nil {
+				return err
+			}
+			sp.ops = append(sp.ops, op)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sp.schemas {
+		for _, p := range s.props {
+			if p.ref != "" && sp.schemas[p.ref] == nil {
+				return nil, fmt.Errorf("schema %s: property %s: schema %q not found", s.name, p.json, p.ref)
+			}
+		}
+	}
+	return sp, nil
+}
+
+const rule = "// ─────────────────────────────────────────────────────────────────────────────\n"
+
+func header(b *bytes.Buffer, filename, pkg string) {
+	fmt.Fprintf(b, "// Code generated by %s from %s; DO NOT EDIT.\n\n", generator, filename)
+	fmt.Fprintf(b, "package %s\n\n", pkg)
+}
+
+// comment writes text as a comment, one line per line of text.
+func comment(b *bytes.Buffer, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			b.WriteString("//\n")
+		} else {
+			fmt.Fprintf(b, "// %s\n", line)
+		}
+	}
+}
+
+func writeServices(filename string, sp *spec) []byte {
+	var body bytes.Buffer
+	written := map[string]bool{}
+	tag := "\x00"
+	for i, op := range sp.ops {
+		if op.tag != tag {
+			tag = op.tag
+			fmt.Fprintf(&body, "%s// %s\n%s\n", rule, tag, rule)
+		} else if i > 0 {
+			fmt.Fprintf(&body, "%s\n", rule)
+		}
+		writeService(&body, op)
+		if op.schema != "" {
+			writeSchema(&body, sp, op.schema, written)
+		}
+	}
+
+	var b bytes.Buffer
+	header(&b, filename, "qe_connector")
+	b.WriteString("import (\n\t\"context\"\n")
+	for _, pkg := range []string{"errors", "fmt"} {
+		if bytes.Contains(body.Bytes(), []byte(pkg+".")) {
+			fmt.Fprintf(&b, "\t%q\n", pkg)
+		}
+	}
+	b.WriteString("\t\"net/http\"\n")
+	var imports []string
+	for _, imp := range sp.imports {
+		if bytes.Contains(body.Bytes(), []byte(path.Base(imp)+".")) {
+			imports = append(imports, imp)
+		}
+	}
+	if len(imports) > 0 {
+		b.WriteString("\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.Write(body.Bytes())
+	return b.Bytes()
+}
+
+func writeService(b *bytes.Buffer, op operation) {
+	svc := op.id + "Service"
+	fmt.Fprintf(b, "// New%s creates a service for `%s %s`.\n", svc, strings.ToUpper(op.method), op.path)
+	fmt.Fprintf(b, "func (c *Client) New%s() *%s {\n\treturn &%s{c: c}\n}\n\n", svc, svc, svc)
+
+	comment(b, svc+" "+op.summary)
+	if op.desc != "" {
+		b.WriteString("//\n")
+		comment(b, op.desc)
+	}
+	fmt.Fprintf(b, "type %s struct {\n\tc *Client\n", svc)
+	for _, p := range op.params {
+		if !p.field() {
+			continue
+		}
+		if p.pointer() {
+			fmt.Fprintf(b, "\t%s *%s\n", p.name, p.goType)
+		} else {
+			fmt.Fprintf(b, "\t%s %s\n", p.name, p.goType)
+		}
+	}
+	b.WriteString("}\n\n")
+
+	for _, p := range op.params {
+		if !p.field() {
+			continue
+		}
+		if p.doc != "" {
+			comment(b, p.goName+" "+p.doc)
+		} else {
+			note := p.desc
+			if !p.required {
+				note = strings.TrimSuffix("optional, "+note, ", ")
+			}
+			if note != "" {
+				note = " (" + note + ")"
+			}
+			fmt.Fprintf(b, "// %s set %s%s\n", p.goName, p.name, note)
+		}
+		fmt.Fprintf(b, "func (s *%s) %s(v %s) *%s {\n", svc, p.goName, p.goType, svc)
+		if p.pointer() {
+			fmt.Fprintf(b, "\ts.%s = &v\n", p.name)
+		} else {
+			fmt.Fprintf(b, "\ts.%s = v\n", p.name)
+		}
+		b.WriteString("\treturn s\n}\n\n")
+	}
+
+	reply := "*" + op.reply
+	if op.list {
+		reply = "[]*" + op.reply
+	}
+	var checks bytes.Buffer
+	for _, p := range op.params {
+		switch {
+		case !p.field() || !p.required:
+		case p.base == "[]string":
+			fmt.Fprintf(&checks, "\tif len(s.%s) == 0 {\n\t\treturn errors.New(%q)\n\t}\n", p.name, p.missing())
+		default:
+			fmt.Fprintf(&checks, "\tif s.%s == \"\" {\n\t\treturn errors.New(%q)\n\t}\n", p.name, p.missing())
+		}
+	}
+	validate := checks.Len() > 0 || op.check
+	if validate {
+		b.WriteString("// validate reports the first parameter Do would reject.\n")
+		fmt.Fprintf(b, "func (s *%s) validate() error {\n", svc)
+		b.Write(checks.Bytes())
+		if op.check {
+			b.WriteString("\treturn s.check()\n}\n\n")
+		} else {
+			b.WriteString("\treturn nil\n}\n\n")
+		}
+	}
+
+	b.WriteString("// Do send request\n")
+	fmt.Fprintf(b, "func (s *%s) Do(ctx context.Context, opts ...RequestOption) (res %s, err error) {\n", svc, reply)
+	if validate {
+		b.WriteString("\tif err := s.validate(); err != nil {\n\t\treturn nil, err\n\t}\n")
+	}
+
+	endpoint := strconv.Quote(op.path)
+	if ms := pathParam.FindAllStringSubmatch(op.path, -1); len(ms) > 0 {
+		args := ""
+		for _, m := range ms {
+			args += ", s." + m[1]
+		}
+		endpoint = fmt.Sprintf("fmt.Sprintf(%q%s)", pathParam.ReplaceAllString(op.path, "%s"), args)
+	}
+	method := "http.Method" + exported(op.method)
+
+	// set writes the statements sending p with assign, which formats a key
+	// and a value.
+	set := func(p param, assign string) {
+		switch {
+		case p.constant != "":
+			fmt.Fprintf(b, "\t"+assign+"\n", p.name, strconv.Quote(p.constant))
+		case !p.pointer():
+			fmt.Fprintf(b, "\t"+assign+"\n", p.name, "s."+p.name)
+		default:
+			fmt.Fprintf(b, "\tif s.%s != nil {\n\t\t"+assign+"\n\t}", p.name, p.name, "*s."+p.name)
+			def := p.defExpr
+			if p.def != "" {
+				def, _ = literal(p.base, p.def)
+			}
+			if def != "" {
+				fmt.Fprintf(b, " else {\n\t\t"+assign+"\n\t}", p.name, def)
+			}
+			b.WriteString("\n")
+		}
+	}
+	var call func(t string) string
+	if op.body {
+		b.WriteString("\tbody := params{}\n")
+		for _, p := range op.params {
+			if p.in == "body" {
+				set(p, "body[%q] = %s")
+			}
+		}
+		call = func(t string) string {
+			return fmt.Sprintf("callAPIV2As[%s](ctx, s.c, %s, %s, body, opts...)", t, method, endpoint)
+		}
+	} else {
+		fmt.Fprintf(b, "\tr := &request{\n\t\tmethod: %s,\n\t\tendpoint: %s,\n\t\tsecType: secTypeSigned,\n\t}\n", method, endpoint)
+		for _, p := range op.params {
+			if p.in == "query" {
+				if p.same {
+					fmt.Fprintf(b, "\tr.setParam(%q, s.%s)\n", p.name, p.name)
+				} else {
+					set(p, "r.setParam(%q, %s)")
+				}
+			}
+		}
+		call = func(t string) string {
+			return fmt.Sprintf("callAPIAs[%s](ctx, s.c, r, opts...)", t)
+		}
+	}
+	if op.list {
+		fmt.Fprintf(b, "\trows, err := %s\n", call("[]*"+op.reply))
+		fmt.Fprintf(b, "\tif err != nil {\n\t\treturn nil, err\n\t}\n\tif *rows == nil {\n\t\treturn make([]*%s, 0), nil\n\t}\n\treturn *rows, nil\n}\n\n", op.reply)
+	} else {
+		fmt.Fprintf(b, "\treturn %s\n}\n\n", call(op.reply))
+	}
+}
+
+// writeSchema writes the schema name and then the schemas it refers to,
+// skipping those already written.
+func writeSchema(b *bytes.Buffer, sp *spec, name string, written map[string]bool) {
+	if written[name] {
+		return
+	}
+	written[name] = true
+	s := sp.schemas[name]
+	comment(b, s.name+" "+s.desc)
+	fmt.Fprintf(b, "type %s struct {\n", s.name)
+	for _, p := range s.props {
+		switch {
+		case p.deprecated:
+			fmt.Fprintf(b, "\t%s %s `json:\"-\"`", p.goName, p.goType)
+		case p.nullable:
+			fmt.Fprintf(b, "\t%s %s `json:%q`", p.goName, p.goType, p.json+",omitempty")
+		default:
+			fmt.Fprintf(b, "\t%s %s `json:%q`", p.goName, p.goType, p.json)
+		}
+		if p.comment != "" {
+			fmt.Fprintf(b, " // %s", p.comment)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n\n")
+	for _, p := range s.props {
+		if p.ref != "" {
+			writeSchema(b, sp, p.ref, written)
+		}
+	}
+}
+
+// sample returns a message of schema name with every field set, arrays
+// holding one element. Deprecated fields are not sent.
+func sample(sp *spec, name string) map[string]any {
+	m := map[string]any{}
+	for _, p := range sp.schemas[name].props {
+		switch {
+		case p.deprecated:
+		case p.ref == p.goType:
+			m[p.json] = sample(sp, p.ref)
+		case p.ref != "":
+			m[p.json] = []any{sample(sp, p.ref)}
+		default:
+			m[p.json] = p.sample
+		}
+	}
+	return m
+}
+
+// jsonValue returns the scalar s of Go type base as JSON decodes it.
+func jsonValue(base, s string) any {
+	switch base {
+	case "bool":
+		v, _ := strconv.ParseBool(s)
+		return v
+	case "int32", "int64", "float64":
+		v, _ := strconv.ParseFloat(s, 64)
+		return v
+	}
+	return s
+}
+
+// testValue returns the Go literal the test sets p to, and its JSON value.
+func testValue(p param) (lit string, v any) {
+	if p.base == "[]string" {
+		return fmt.Sprintf("[]string{%q}", p.name+"-1"), []any{p.name + "-1"}
+	}
+	s := p.example
+	if s == "" {
+		s = fmt.Sprint(sampleOf(p.base, p.name+"-1"))
+	}
+	lit, _ = literal(p.base, s)
+	return lit, jsonValue(p.base, s)
+}
+
+// sent returns the values op sends when the test sets every parameter it
+// does not leave unset, by name, and the path it sends them to.
+func sent(op operation) (path string, values map[string]any) {
+	values = map[string]any{}
+	path = op.path
+	for _, p := range op.params {
+		switch _, v := testValue(p); {
+		case p.in == "path":
+			path = strings.Replace(path, "{"+p.name+"}", v.(string), 1)
+			continue
+		case p.constant != "":
+			values[p.name] = p.constant
+		case p.same || !slices.Contains(op.unset, p.name):
+			values[p.name] = v
+		case p.def != "":
+			values[p.name] = jsonValue(p.base, p.def)
+		}
+	}
+	return path, values
+}
+
+func writeTests(filename string, sp *spec, name string) []byte {
+	var b bytes.Buffer
+	header(&b, filename, "qe_connector_test")
+	strings.NewReplacer("generatedServiceCase", name+"Case", "TestGeneratedServices", "Test"+exported(name)+"Services").WriteString(&b, testPrelude)
+	fmt.Fprintf(&b, "var %sCases = []%sCase{\n", name, name)
+	for _, op := range sp.ops {
+		var msg any
+		switch {
+		case !op.list:
+			msg = sample(sp, op.reply)
+		case op.schema != "":
+			msg = []any{sample(sp, op.schema)}
+		default:
+			msg = []any{}
+		}
+		message, err := json.Marshal(msg)
+		if err != nil {
+			panic(err) // samples hold only JSON values
+		}
+		path, values := sent(op)
+		fmt.Fprintf(&b, "\t{\n\t\tname: %q,\n\t\tmethod: %q,\n\t\tpath: %q,\n", op.id, strings.ToUpper(op.method), path)
+		if op.body {
+			data, err := json.Marshal(values)
+			if err != nil {
+				panic(err)
+			}
+			fmt.Fprintf(&b, "\t\tbody: `%s`,\n", data)
+		} else {
+			b.WriteString("\t\tquery: map[string]string{")
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			for _, name := range names {
+				v := fmt.Sprint(values[name])
+				if f, ok := values[name].(float64); ok {
+					v = strconv.FormatFloat(f, 'f', -1, 64)
+				}
+				fmt.Fprintf(&b, "%q: %q, ", name, v)
+			}
+			b.WriteString("},\n")
+		}
+		fmt.Fprintf(&b, "\t\tmessage: `%s`,\n", message)
+		fmt.Fprintf(&b, "\t\tdo: func(ctx context.Context, c *qe.Client) (any, error) {\n\t\t\treturn c.New%sService()%s.Do(ctx)\n\t\t},\n",
+			op.id, setters(op, ""))
+		var required []param
+		for _, p := range op.params {
+			if p.field() && p.required {
+				required = append(required, p)
+			}
+		}
+		if len(required) > 0 {
+			b.WriteString("\t\tmissing: map[string]func(ctx context.Context, c *qe.Client) error{\n")
+			for _, p := range required {
+				fmt.Fprintf(&b, "\t\t\t%q: func(ctx context.Context, c *qe.Client) error {\n\t\t\t\t_, err := c.New%sService()%s.Do(ctx)\n\t\t\t\treturn err\n\t\t\t},\n",
+					p.missing(), op.id, setters(op, p.name))
+			}
+			b.WriteString("\t\t},\n")
+		}
+		b.WriteString("\t},\n")
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// setters returns the setter calls of every parameter of op the test sets,
+// but skip.
+func setters(op operation, skip string) string {
+	var s strings.Builder
+	for _, p := range op.params {
+		if p.field() && p.name != skip && !slices.Contains(op.unset, p.name) {
+			lit, _ := testValue(p)
+			fmt.Fprintf(&s, ".%s(%s)", p.goName, lit)
+		}
+	}
+	return s.String()
+}
+
+const testPrelude = `import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+type generatedServiceCase struct {
+	name   string
+	method string
+	path   string
+	query  map[string]string
+	// body is the JSON body of a V2 request, empty for a V1 one.
+	body    string
+	message string
+	do      func(ctx context.Context, c *qe.Client) (any, error)
+	// missing calls the service without a required parameter, by the error
+	// that must be returned.
+	missing map[string]func(ctx context.Context, c *qe.Client) error
+}
+
+// TestGeneratedServices checks that each generated service sends a signed
+// request with its parameters and decodes every field of its response.
+func TestGeneratedServices(t *testing.T) {
+	// jsonEqual reports whether a and b hold the same JSON value.
+	jsonEqual := func(a, b []byte) bool {
+		var x, y any
+		return json.Unmarshal(a, &x) == nil && json.Unmarshal(b, &y) == nil && reflect.DeepEqual(x, y)
+	}
+	for _, tc := range generatedServiceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if r.Method != tc.method || r.URL.Path != tc.path {
+					t.Errorf("request = %s %s, want %s %s", r.Method, r.URL.Path, tc.method, tc.path)
+				}
+				q := r.URL.Query()
+				for k, v := range tc.query {
+					if got := q.Get(k); got != v {
+						t.Errorf("%s = %q, want %q", k, got, v)
+					}
+				}
+				body, _ := io.ReadAll(r.Body)
+				if tc.body == "" && len(body) > 0 || tc.body != "" && !jsonEqual(body, []byte(tc.body)) {
+					t.Errorf("body = %s, want %s", body, tc.body)
+				}
+				if q.Get("signature") == "" || q.Get("timestamp") == "" || r.Header.Get("X-MBX-APIKEY") != "key" {
+					t.Errorf("request is not signed: %s", r.URL.RawQuery)
+				}
+				w.Write([]byte(` + "`" + `{"code":200,"message":` + "`" + ` + tc.message + "}"))
+			}))
+			defer srv.Close()
+			c := qe.NewClient("key", "secret", srv.URL)
+			ctx := context.Background()
+
+			res, err := tc.do(ctx, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(got, []byte(tc.message)) {
+				t.Errorf("decoded %s\nwant    %s", got, tc.message)
+			}
+
+			for want, call := range tc.missing {
+				if err := call(ctx, c); err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("err = %v, want %q", err, want)
+				}
+			}
+			if n := calls.Load(); n != 1 {
+				t.Errorf("%d requests sent, want 1", n)
+			}
+		})
+	}
+}
+
+`
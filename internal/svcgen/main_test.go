@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedServicesUpToDate regenerates the files of every svcgen
+// directive in generate.go and compares them with the checked-in ones.
+func TestGeneratedServicesUpToDate(t *testing.T) {
+	directives, err := os.ReadFile("../../generate.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := os.ReadFile("../../api/strategy-api.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := 0
+	for _, line := range strings.Split(string(directives), "\n") {
+		args, ok := strings.CutPrefix(strings.TrimSpace(line), "//go:generate go run ./internal/svcgen ")
+		if !ok {
+			continue
+		}
+		runs++
+		fs := flag.NewFlagSet("svcgen", flag.ContinueOnError)
+		tags := fs.String("tags", "", "")
+		out := fs.String("out", "", "")
+		testOut := fs.String("test", "", "")
+		fs.String("spec", "", "")
+		if err := fs.Parse(strings.Fields(args)); err != nil {
+			t.Fatal(err)
+		}
+		var only []string
+		if *tags != "" {
+			only = strings.Split(*tags, ",")
+		}
+		code, test, err := generate("strategy-api.yaml", src, only, caseName(*testOut))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for file, want := range map[string][]byte{*out: code, *testOut: test} {
+			got, err := os.ReadFile("../../" + file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// .gitattributes checks text files out with CRLF line endings.
+			if !bytes.Equal(bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n")), want) {
+				t.Errorf("%s is stale; run go generate in the module root", file)
+			}
+		}
+	}
+	if runs == 0 {
+		t.Fatal("no svcgen directive in generate.go")
+	}
+}
+
+func TestGenerateRejectsUnsupportedSpec(t *testing.T) {
+	cases := map[string]string{
+		"method": `
+paths:
+  /x:
+    patch:
+      operationId: X
+      summary: patch x`,
+		"parameter": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x
+      parameters:
+        - {name: id, in: header, schema: {type: string}}`,
+		"undeclared path parameter": `
+paths:
+  /x/{id}:
+    get:
+      operationId: X
+      summary: get x`,
+		"required integer": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x
+      parameters:
+        - {name: n, in: query, required: true, schema: {type: integer, format: int32}}`,
+		"query next to body": `
+paths:
+  /x:
+    post:
+      operationId: X
+      summary: create x
+      parameters:
+        - {name: n, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {m: {type: string}}}`,
+		"bad default": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x
+      parameters:
+        - {name: on, in: query, schema: {type: boolean, default: maybe}}`,
+		"two defaults": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x
+      parameters:
+        - {name: on, in: query, schema: {type: boolean, default: true, x-go-default: s.def()}}`,
+		"no response": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x`,
+		"missing schema": `
+paths:
+  /x:
+    get:
+      operationId: X
+      summary: get x
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - properties:
+                      message: {$ref: '#/components/schemas/Nope'}`,
+		"property type": `
+components:
+  schemas:
+    Reply:
+      properties:
+        at: {type: string, format: date-time}
+        n: {type: integer}`,
+	}
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := generate("x.yaml", []byte(strings.TrimPrefix(spec, "\n")), nil, "x"); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+// SetSlogLogger sends the client's logs, including those of its
+// WebSocketServices, to l as structured records. Every REST call logs one
+// record with method, endpoint, status, latency, traceId and masterOrderId:
+// at Debug when it succeeds, Warn for API errors and Error for transport
+// failures; Debug records also carry the URL and bodies. The handler's level
+// decides what is emitted.
+//
+// API keys, secrets, signatures and listen keys are redacted from every
+// record. Pass nil to go back to the legacy Debug / Logger output, which now
+// goes through the same redaction.
+func (c *Client) SetSlogLogger(l *slog.Logger) *Client {
+	if c.frozen("SetSlogLogger") {
+		return c
+	}
+	c.slogger = l
+	return c
+}
+
+// logKey is what the client's logger is built from; the cached logger is
+// rebuilt when any of it changes.
+type logKey struct {
+	slogger   *slog.Logger
+	debug     bool
+	legacy    *log.Logger
+	creds     *credentialSet
+	apiKey    string
+	secretKey string
+}
+
+type cachedLogger struct {
+	key    logKey
+	logger *slog.Logger
+}
+
+// logger returns the redacting logger of the client, or nil when logging is
+// off. It is called for every request and WebSocket frame, so the logger is
+// cached until the logging settings or credentials change.
+func (c *Client) logger() *slog.Logger {
+	s := c.settings()
+	key := logKey{
+		slogger: c.slogger,
+		debug:   s.debug,
+		legacy:  s.logger,
+		creds:   c.creds.Load(),
+	}
+	if key.creds == nil {
+		key.apiKey, key.secretKey = c.APIKey, c.SecretKey
+	}
+	if cached := c.logCache.Load(); cached != nil && cached.key == key {
+		return cached.logger
+	}
+	var h slog.Handler
+	switch {
+	case key.slogger != nil:
+		h = key.slogger.Handler()
+	case key.debug && key.legacy != nil:
+		h = slog.NewTextHandler(legacyLogWriter{key.legacy}, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			// *log.Logger adds its own timestamp.
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})
+	}
+	var l *slog.Logger
+	if h != nil {
+		cs := key.creds
+		if cs == nil {
+			cs = &credentialSet{Credentials: Credentials{APIKey: key.apiKey, SecretKey: key.secretKey}}
+		}
+		l = slog.New(&redactingHandler{next: h, secrets: cs.secrets()})
+	}
+	c.logCache.Store(&cachedLogger{key: key, logger: l})
+	return l
+}
+
+func (c *Client) debug(format string, v ...interface{}) {
+	if l := c.logger(); l != nil {
+		l.Debug(fmt.Sprintf(format, v...))
+	}
+}
+
+// legacyLogWriter adapts a *log.Logger to the io.Writer slog handlers use;
+// each Write is one record.
+type legacyLogWriter struct{ l *log.Logger }
+
+func (w legacyLogWriter) Write(p []byte) (int, error) {
+	w.l.Print(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// logRedactKeys are attribute keys whose values are always redacted, on top
+// of the recorder's credential keys.
+var logRedactKeys = map[string]bool{"apikey": true, "x-mbx-apikey": true, "listen_key": true}
+
+// logRedactPattern finds credentials embedded in text such as signed URLs,
+// JSON bodies and WebSocket frames.
+var logRedactPattern = regexp.MustCompile(`(?i)((?:signature|listen_?key|secret_?key|api_?secret|x-mbx-apikey)"?\s*[=:]\s*"?)([^&\s",}]+)`)
+
+// redactingHandler scrubs credentials from every record before passing it on.
+type redactingHandler struct {
+	next    slog.Handler
+	secrets []string
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, h.text(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.attr(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	scrubbed := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		scrubbed[i] = h.attr(a)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(scrubbed), secrets: h.secrets}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name), secrets: h.secrets}
+}
+
+func (h *redactingHandler) attr(a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, k := range defaultRedactKeys {
+		if key == strings.ToLower(k) {
+			return slog.String(a.Key, Redacted)
+		}
+	}
+	if logRedactKeys[key] {
+		return slog.String(a.Key, Redacted)
+	}
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, h.text(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		scrubbed := make([]any, len(group))
+		for i, g := range group {
+			scrubbed[i] = h.attr(g)
+		}
+		return slog.Group(a.Key, scrubbed...)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return slog.String(a.Key, h.text(err.Error()))
+		}
+		return slog.String(a.Key, h.text(fmt.Sprint(v.Any())))
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+func (h *redactingHandler) text(s string) string {
+	for _, secret := range h.secrets {
+		// Short values would match unrelated text; real keys are far longer.
+		if len(secret) >= 8 {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return logRedactPattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// requestLog collects what a REST call logs and reports once it completes.
+type requestLog struct {
+	start    time.Time
+	method   string
+	endpoint string
+	url      string
+	params   url.Values
+	body     []byte
+	// masterOrderID is the order the request names, if any.
+	masterOrderID string
+	meta          *ResponseMeta
+	base          string
+	pinned        bool
+	// resign signs the request again for a hedge copy; nil when it may
+	// not be hedged.
+	resign func() (*url.URL, error)
+	done   func(RequestResult)
+}
+
+func newRequestLog(method, endpoint string) *requestLog {
+	return &requestLog{start: time.Now(), method: method, endpoint: endpoint}
+}
+
+// startRequest hands req to the client's Instrumentation, if any, and
+// returns the request to send.
+func (c *Client) startRequest(ctx context.Context, rl *requestLog, req *http.Request) *http.Request {
+	if c.instr == nil {
+		return req
+	}
+	ctx, rl.done = c.instr.StartRequest(ctx, req, rl.endpoint)
+	return req.WithContext(ctx)
+}
+
+// finishRequest reports a completed REST call to the Instrumentation and
+// the logger. status is 0 when no response was received; data is the raw
+// response body, and reply and traceID the decoded envelope and its traceId,
+// if the call succeeded.
+func (c *Client) finishRequest(ctx context.Context, rl *requestLog, status int, data []byte, reply envelope, traceID string, err error) {
+	l := c.logger()
+	level := slog.LevelDebug
+	var apiErr *handlers.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		level = slog.LevelWarn
+	case err != nil:
+		level = slog.LevelError
+	}
+	logging := l != nil && l.Enabled(ctx, level)
+	if rl.done == nil && !logging {
+		return
+	}
+
+	res := RequestResult{
+		Method:   rl.method,
+		Endpoint: rl.endpoint,
+		Status:   status,
+		Latency:  time.Since(rl.start),
+		TraceId:  traceID,
+		Err:      err,
+	}
+	if apiErr != nil {
+		res.Code, res.Reason = apiErr.Code, apiErr.Reason
+		if apiErr.TraceId != "" {
+			res.TraceId = apiErr.TraceId
+		}
+	}
+	if rl.done != nil {
+		rl.done(res)
+	}
+	if !logging {
+		return
+	}
+
+	masterOrderID := rl.masterOrderID
+	if masterOrderID == "" && reply != nil {
+		masterOrderID = reply.masterOrderID()
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", res.Method),
+		slog.String("endpoint", res.Endpoint),
+		slog.Int("status", res.Status),
+		slog.Duration("latency", res.Latency),
+	}
+	if res.TraceId != "" {
+		attrs = append(attrs, slog.String("traceId", res.TraceId))
+	}
+	if masterOrderID != "" {
+		attrs = append(attrs, slog.String("masterOrderId", masterOrderID))
+	}
+	if apiErr != nil {
+		attrs = append(attrs, slog.Int("code", res.Code), slog.String("reason", res.Reason))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	if level == slog.LevelDebug || err != nil {
+		attrs = append(attrs, slog.String("url", rl.url))
+		if len(rl.body) > 0 {
+			attrs = append(attrs, slog.String("requestBody", string(rl.body)))
+		}
+		if len(data) > 0 {
+			attrs = append(attrs, slog.String("responseBody", string(data)))
+		}
+	}
+	l.LogAttrs(ctx, level, "qe request", attrs...)
+}
@@ -0,0 +1,146 @@
+package qe_connector_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	sc := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var rec map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("log line %q: %v", sc.Text(), err)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func assertNoCredentials(t *testing.T, logs string, extra ...string) {
+	t.Helper()
+	for _, secret := range append([]string{testAPIKey, testSecretKey}, extra...) {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs leak %q:\n%s", secret, logs)
+		}
+	}
+	if strings.Contains(logs, "signature=") && !strings.Contains(logs, "signature="+qe.Redacted) {
+		t.Errorf("logs leak a signature:\n%s", logs)
+	}
+}
+
+func TestSlogRequestRecords(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	id := createTWAP(t, c, "BTCUSDT")
+	bad := qe.NewClient(testAPIKey, "wrong-secret-value", srv.URL).
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if _, err := bad.NewGetMasterOrdersV2Service().MasterOrderId(id).Do(context.Background()); err == nil {
+		t.Fatal("expected a signature error")
+	}
+
+	recs := logRecords(t, &buf)
+	if len(recs) != 2 {
+		t.Fatalf("got %d records:\n%s", len(recs), buf.String())
+	}
+	create, failed := recs[0], recs[1]
+	if create["level"] != "DEBUG" || create["msg"] != "qe request" || create["method"] != "POST" ||
+		create["status"] != float64(200) || create["masterOrderId"] != id ||
+		!strings.HasPrefix(create["traceId"].(string), "qetest-") {
+		t.Fatalf("create record = %v", create)
+	}
+	if _, ok := create["latency"]; !ok {
+		t.Fatalf("create record has no latency: %v", create)
+	}
+	if failed["level"] != "WARN" || failed["method"] != "GET" || failed["masterOrderId"] != id ||
+		failed["code"] == nil || failed["traceId"] == nil {
+		t.Fatalf("failure record = %v", failed)
+	}
+	assertNoCredentials(t, buf.String(), "wrong-secret-value")
+}
+
+func TestSlogLevelFiltersSuccessfulRequests(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	createTWAP(t, c, "BTCUSDT")
+	if buf.Len() != 0 {
+		t.Fatalf("successful request logged at Warn level:\n%s", buf.String())
+	}
+}
+
+func TestLegacyDebugLoggerIsRedacted(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := qe.NewTestClient(testAPIKey, testSecretKey, srv.URL)
+	c.Logger = log.New(&buf, "qe ", 0)
+	createTWAP(t, c, "BTCUSDT")
+	if !strings.Contains(buf.String(), `msg="qe request"`) || !strings.Contains(buf.String(), "signature="+qe.Redacted) {
+		t.Fatalf("legacy debug output:\n%s", buf.String())
+	}
+	assertNoCredentials(t, buf.String())
+}
+
+func TestWebSocketLogsRedactListenKey(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	var buf syncBuffer
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	lk, err := c.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := c.NewWebSocketService(srv.WSHost())
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	ws.Close()
+
+	logs := buf.String()
+	if !strings.Contains(logs, `msg="qe ws connecting"`) || !strings.Contains(logs, "listen_key="+qe.Redacted) {
+		t.Fatalf("ws logs:\n%s", logs)
+	}
+	assertNoCredentials(t, logs, lk.ListenKey)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,364 @@
+package qe_connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"gopkg.in/yaml.v3"
+)
+
+// OrderTemplate is a reusable, serialisable description of a V2 create
+// master order request. It covers every CreateMasterOrderV2Service field
+// and round-trips through JSON and YAML with the same lowerCamelCase keys as
+// the request body, e.g.
+//
+//	name: binance-perp-twap-30m
+//	apiKeyId: ${apiKeyId}
+//	exchange: Binance
+//	marketType: PERP
+//	marginType: U
+//	symbol: ${symbol}
+//	side: buy
+//	algorithm: TWAP
+//	executionDurationSeconds: 1800
+//	totalQuantity: ${quantity}
+//	tailOrderProtection: true
+//	makerRateLimit: "0.3"
+//
+// String fields may contain `${name}` placeholders that are resolved by
+// Substitute. Templates are layered with With: later layers override fields
+// they set. The fully resolved template is checked with the same rules as
+// CreateMasterOrderV2Service.Do (see Validate).
+type OrderTemplate struct {
+	Name                     string                    `json:"name,omitempty" yaml:"name,omitempty"`
+	ApiKeyId                 string                    `json:"apiKeyId,omitempty" yaml:"apiKeyId,omitempty"`
+	Exchange                 trading_enums.Exchange    `json:"exchange,omitempty" yaml:"exchange,omitempty"`
+	MarketType               trading_enums.MarketType  `json:"marketType,omitempty" yaml:"marketType,omitempty"`
+	Symbol                   string                    `json:"symbol,omitempty" yaml:"symbol,omitempty"`
+	Side                     trading_enums.OrderSide   `json:"side,omitempty" yaml:"side,omitempty"`
+	Algorithm                trading_enums.Algorithm   `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	ExecutionDurationSeconds *int64                    `json:"executionDurationSeconds,omitempty" yaml:"executionDurationSeconds,omitempty"`
+	StartTimeMs              *int64                    `json:"startTimeMs,omitempty" yaml:"startTimeMs,omitempty"`
+	TotalQuantity            *string                   `json:"totalQuantity,omitempty" yaml:"totalQuantity,omitempty"`
+	OrderNotional            *string                   `json:"orderNotional,omitempty" yaml:"orderNotional,omitempty"`
+	MarginType               *trading_enums.MarginType `json:"marginType,omitempty" yaml:"marginType,omitempty"`
+	ReduceOnly               *bool                     `json:"reduceOnly,omitempty" yaml:"reduceOnly,omitempty"`
+	IsMargin                 *bool                     `json:"isMargin,omitempty" yaml:"isMargin,omitempty"`
+	WorstPrice               *string                   `json:"worstPrice,omitempty" yaml:"worstPrice,omitempty"`
+	MustComplete             *bool                     `json:"mustComplete,omitempty" yaml:"mustComplete,omitempty"`
+	MakerRateLimit           *string                   `json:"makerRateLimit,omitempty" yaml:"makerRateLimit,omitempty"`
+	PovLimit                 *string                   `json:"povLimit,omitempty" yaml:"povLimit,omitempty"`
+	PovMinLimit              *string                   `json:"povMinLimit,omitempty" yaml:"povMinLimit,omitempty"`
+	UpTolerance              *string                   `json:"upTolerance,omitempty" yaml:"upTolerance,omitempty"`
+	LowTolerance             *string                   `json:"lowTolerance,omitempty" yaml:"lowTolerance,omitempty"`
+	StrictUpBound            *bool                     `json:"strictUpBound,omitempty" yaml:"strictUpBound,omitempty"`
+	TailOrderProtection      *bool                     `json:"tailOrderProtection,omitempty" yaml:"tailOrderProtection,omitempty"`
+	EnableMake               *bool                     `json:"enableMake,omitempty" yaml:"enableMake,omitempty"`
+	IsTargetPosition         *bool                     `json:"isTargetPosition,omitempty" yaml:"isTargetPosition,omitempty"`
+	ClientOrderId            *string                   `json:"clientOrderId,omitempty" yaml:"clientOrderId,omitempty"`
+	Notes                    *string                   `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+var templateVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ParseOrderTemplateJSON decodes a JSON template. Unknown keys are rejected
+// so typos such as `makerRateLimt` fail loudly instead of being ignored.
+func ParseOrderTemplateJSON(data []byte) (*OrderTemplate, error) {
+	t := new(OrderTemplate)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("order template: %w", err)
+	}
+	return t, nil
+}
+
+// ParseOrderTemplateYAML decodes a YAML template. Unknown keys are rejected.
+func ParseOrderTemplateYAML(data []byte) (*OrderTemplate, error) {
+	t := new(OrderTemplate)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("order template: %w", err)
+	}
+	return t, nil
+}
+
+// LoadOrderTemplate reads a template file, choosing the decoder by
+// extension (`.json`, `.yaml`, `.yml`).
+func LoadOrderTemplate(path string) (*OrderTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseOrderTemplateJSON(data)
+	case ".yaml", ".yml":
+		return ParseOrderTemplateYAML(data)
+	default:
+		return nil, fmt.Errorf("order template %s: unsupported extension", path)
+	}
+}
+
+// With returns a copy of t with each layer applied in order. A layer
+// overrides every field it sets (non-empty strings, non-nil pointers).
+func (t *OrderTemplate) With(layers ...*OrderTemplate) *OrderTemplate {
+	out := t.clone()
+	for _, l := range layers {
+		if l == nil {
+			continue
+		}
+		if l.Name != "" {
+			out.Name = l.Name
+		}
+		if l.ApiKeyId != "" {
+			out.ApiKeyId = l.ApiKeyId
+		}
+		if l.Exchange != "" {
+			out.Exchange = l.Exchange
+		}
+		if l.MarketType != "" {
+			out.MarketType = l.MarketType
+		}
+		if l.Symbol != "" {
+			out.Symbol = l.Symbol
+		}
+		if l.Side != "" {
+			out.Side = l.Side
+		}
+		if l.Algorithm != "" {
+			out.Algorithm = l.Algorithm
+		}
+		override(&out.ExecutionDurationSeconds, l.ExecutionDurationSeconds)
+		override(&out.StartTimeMs, l.StartTimeMs)
+		// Quantity and notional are mutually exclusive, so a layer that
+		// sizes the order replaces the base sizing entirely.
+		if l.TotalQuantity != nil || l.OrderNotional != nil {
+			out.TotalQuantity, out.OrderNotional = nil, nil
+		}
+		override(&out.TotalQuantity, l.TotalQuantity)
+		override(&out.OrderNotional, l.OrderNotional)
+		override(&out.MarginType, l.MarginType)
+		override(&out.ReduceOnly, l.ReduceOnly)
+		override(&out.IsMargin, l.IsMargin)
+		override(&out.WorstPrice, l.WorstPrice)
+		override(&out.MustComplete, l.MustComplete)
+		override(&out.MakerRateLimit, l.MakerRateLimit)
+		override(&out.PovLimit, l.PovLimit)
+		override(&out.PovMinLimit, l.PovMinLimit)
+		override(&out.UpTolerance, l.UpTolerance)
+		override(&out.LowTolerance, l.LowTolerance)
+		override(&out.StrictUpBound, l.StrictUpBound)
+		override(&out.TailOrderProtection, l.TailOrderProtection)
+		override(&out.EnableMake, l.EnableMake)
+		override(&out.IsTargetPosition, l.IsTargetPosition)
+		override(&out.ClientOrderId, l.ClientOrderId)
+		override(&out.Notes, l.Notes)
+	}
+	return out
+}
+
+// Variables lists the `${name}` placeholders still present in t, sorted.
+func (t *OrderTemplate) Variables() []string {
+	seen := map[string]bool{}
+	t.eachString(func(s *string) {
+		for _, m := range templateVarPattern.FindAllStringSubmatch(*s, -1) {
+			seen[m[1]] = true
+		}
+	})
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Substitute returns a copy of t with `${name}` placeholders replaced from
+// vars (typically symbol, quantity, apiKeyId). It fails if a placeholder has
+// no value.
+func (t *OrderTemplate) Substitute(vars map[string]string) (*OrderTemplate, error) {
+	out := t.clone()
+	var missing []string
+	out.eachString(func(s *string) {
+		*s = templateVarPattern.ReplaceAllStringFunc(*s, func(m string) string {
+			name := m[2 : len(m)-1]
+			v, ok := vars[name]
+			if !ok {
+				missing = append(missing, name)
+				return m
+			}
+			return v
+		})
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("order template %s: no value for variables %s", t.Name, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// Validate checks a fully resolved template with the same rules that
+// CreateMasterOrderV2Service.Do applies before sending.
+func (t *OrderTemplate) Validate() error {
+	if vars := t.Variables(); len(vars) > 0 {
+		return fmt.Errorf("order template %s: unresolved variables %s", t.Name, strings.Join(vars, ", "))
+	}
+	if err := t.apply(&CreateMasterOrderV2Service{}).validate(); err != nil {
+		if t.Name != "" {
+			return fmt.Errorf("order template %s: %w", t.Name, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// NewCreateMasterOrderV2ServiceFromTemplate resolves t with vars, validates
+// it and returns a ready-to-send service.
+func (c *Client) NewCreateMasterOrderV2ServiceFromTemplate(t *OrderTemplate, vars map[string]string) (*CreateMasterOrderV2Service, error) {
+	if t == nil {
+		return nil, errors.New("order template is nil")
+	}
+	resolved, err := t.Substitute(vars)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolved.Validate(); err != nil {
+		return nil, err
+	}
+	return resolved.apply(c.NewCreateMasterOrderV2Service()), nil
+}
+
+// Template captures the service's current fields as an OrderTemplate, e.g.
+// to save a hand-built request for reuse.
+func (s *CreateMasterOrderV2Service) Template() *OrderTemplate {
+	t := &OrderTemplate{
+		ApiKeyId:                 s.apiKeyId,
+		Exchange:                 s.exchange,
+		MarketType:               s.marketType,
+		Symbol:                   s.symbol,
+		Side:                     s.side,
+		Algorithm:                s.algorithm,
+		ExecutionDurationSeconds: s.executionDurationSeconds,
+		StartTimeMs:              s.startTimeMs,
+		TotalQuantity:            s.totalQuantity,
+		OrderNotional:            s.orderNotional,
+		MarginType:               s.marginType,
+		ReduceOnly:               s.reduceOnly,
+		IsMargin:                 s.isMargin,
+		WorstPrice:               s.worstPrice,
+		MustComplete:             s.mustComplete,
+		MakerRateLimit:           s.makerRateLimit,
+		PovLimit:                 s.povLimit,
+		PovMinLimit:              s.povMinLimit,
+		UpTolerance:              s.upTolerance,
+		LowTolerance:             s.lowTolerance,
+		StrictUpBound:            s.strictUpBound,
+		TailOrderProtection:      s.tailOrderProtection,
+		EnableMake:               s.enableMake,
+		IsTargetPosition:         s.isTargetPosition,
+		ClientOrderId:            s.clientOrderId,
+		Notes:                    s.notes,
+	}
+	return t.clone()
+}
+
+// apply copies every template field into s.
+func (t *OrderTemplate) apply(s *CreateMasterOrderV2Service) *CreateMasterOrderV2Service {
+	c := t.clone()
+	s.apiKeyId = c.ApiKeyId
+	s.exchange = c.Exchange
+	s.marketType = c.MarketType
+	s.symbol = c.Symbol
+	s.side = c.Side
+	s.algorithm = c.Algorithm
+	s.executionDurationSeconds = c.ExecutionDurationSeconds
+	s.startTimeMs = c.StartTimeMs
+	s.totalQuantity = c.TotalQuantity
+	s.orderNotional = c.OrderNotional
+	s.marginType = c.MarginType
+	s.reduceOnly = c.ReduceOnly
+	s.isMargin = c.IsMargin
+	s.worstPrice = c.WorstPrice
+	s.mustComplete = c.MustComplete
+	s.makerRateLimit = c.MakerRateLimit
+	s.povLimit = c.PovLimit
+	s.povMinLimit = c.PovMinLimit
+	s.upTolerance = c.UpTolerance
+	s.lowTolerance = c.LowTolerance
+	s.strictUpBound = c.StrictUpBound
+	s.tailOrderProtection = c.TailOrderProtection
+	s.enableMake = c.EnableMake
+	s.isTargetPosition = c.IsTargetPosition
+	s.clientOrderId = c.ClientOrderId
+	s.notes = c.Notes
+	return s
+}
+
+// clone deep-copies t so that layers and services never share pointers.
+func (t *OrderTemplate) clone() *OrderTemplate {
+	out := *t
+	out.ExecutionDurationSeconds = copyPtr(t.ExecutionDurationSeconds)
+	out.StartTimeMs = copyPtr(t.StartTimeMs)
+	out.TotalQuantity = copyPtr(t.TotalQuantity)
+	out.OrderNotional = copyPtr(t.OrderNotional)
+	out.MarginType = copyPtr(t.MarginType)
+	out.ReduceOnly = copyPtr(t.ReduceOnly)
+	out.IsMargin = copyPtr(t.IsMargin)
+	out.WorstPrice = copyPtr(t.WorstPrice)
+	out.MustComplete = copyPtr(t.MustComplete)
+	out.MakerRateLimit = copyPtr(t.MakerRateLimit)
+	out.PovLimit = copyPtr(t.PovLimit)
+	out.PovMinLimit = copyPtr(t.PovMinLimit)
+	out.UpTolerance = copyPtr(t.UpTolerance)
+	out.LowTolerance = copyPtr(t.LowTolerance)
+	out.StrictUpBound = copyPtr(t.StrictUpBound)
+	out.TailOrderProtection = copyPtr(t.TailOrderProtection)
+	out.EnableMake = copyPtr(t.EnableMake)
+	out.IsTargetPosition = copyPtr(t.IsTargetPosition)
+	out.ClientOrderId = copyPtr(t.ClientOrderId)
+	out.Notes = copyPtr(t.Notes)
+	return &out
+}
+
+// eachString visits every string-valued field, including enum-typed ones.
+func (t *OrderTemplate) eachString(f func(s *string)) {
+	f(&t.Name)
+	f(&t.ApiKeyId)
+	f((*string)(&t.Exchange))
+	f((*string)(&t.MarketType))
+	f(&t.Symbol)
+	f((*string)(&t.Side))
+	f((*string)(&t.Algorithm))
+	for _, p := range []*string{
+		t.TotalQuantity, t.OrderNotional, t.WorstPrice, t.MakerRateLimit,
+		t.PovLimit, t.PovMinLimit, t.UpTolerance, t.LowTolerance,
+		t.ClientOrderId, t.Notes, (*string)(t.MarginType),
+	} {
+		if p != nil {
+			f(p)
+		}
+	}
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func override[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = copyPtr(src)
+	}
+}
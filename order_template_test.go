@@ -0,0 +1,153 @@
+package qe_connector
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const binancePerpTwapTemplateYAML = `
+name: binance-perp-twap-30m
+apiKeyId: ${apiKeyId}
+exchange: Binance
+marketType: PERP
+marginType: U
+symbol: ${symbol}
+side: buy
+algorithm: TWAP
+executionDurationSeconds: 1800
+totalQuantity: ${quantity}
+tailOrderProtection: true
+makerRateLimit: "0.3"
+`
+
+func TestOrderTemplateMaterializesFromYAMLWithOverrides(t *testing.T) {
+	base, err := ParseOrderTemplateYAML([]byte(binancePerpTwapTemplateYAML))
+	if err != nil {
+		t.Fatalf("ParseOrderTemplateYAML() error = %v", err)
+	}
+	if got := strings.Join(base.Variables(), ","); got != "apiKeyId,quantity,symbol" {
+		t.Fatalf("Variables() = %s", got)
+	}
+	if err := base.Validate(); err == nil || !strings.Contains(err.Error(), "unresolved variables") {
+		t.Fatalf("Validate() on unresolved template = %v", err)
+	}
+
+	sell, err := ParseOrderTemplateJSON([]byte(`{"side":"sell","orderNotional":"5000","notes":"desk ${desk}"}`))
+	if err != nil {
+		t.Fatalf("ParseOrderTemplateJSON() error = %v", err)
+	}
+
+	var body map[string]interface{}
+	client := NewClient("k", "s", "https://example.test")
+	client.do = func(r *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"code":200,"message":{"masterOrderId":"mo_tpl","status":"NEW"}}`)),
+			Header:     make(http.Header),
+		}, nil
+	}
+
+	svc, err := client.NewCreateMasterOrderV2ServiceFromTemplate(base.With(sell), map[string]string{
+		"apiKeyId": "binding-id",
+		"symbol":   "ETHUSDT",
+		"desk":     "a",
+	})
+	if err != nil {
+		t.Fatalf("NewCreateMasterOrderV2ServiceFromTemplate() error = %v", err)
+	}
+	if _, err := svc.Do(context.Background()); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	for key, want := range map[string]interface{}{
+		"apiKeyId":                 "binding-id",
+		"symbol":                   "ETHUSDT",
+		"side":                     "sell",
+		"marginType":               "U",
+		"orderNotional":            "5000",
+		"makerRateLimit":           "0.3",
+		"tailOrderProtection":      true,
+		"executionDurationSeconds": float64(1800),
+		"notes":                    "desk a",
+	} {
+		if body[key] != want {
+			t.Fatalf("body[%s] = %#v, want %#v; body=%#v", key, body[key], want, body)
+		}
+	}
+	if _, ok := body["totalQuantity"]; ok {
+		t.Fatalf("sizing override should drop totalQuantity; body=%#v", body)
+	}
+	if base.Side != "buy" || base.OrderNotional != nil {
+		t.Fatalf("With() must not mutate the base template: %#v", base)
+	}
+}
+
+func TestOrderTemplateRejectsUnknownFieldsAndMissingVariables(t *testing.T) {
+	if _, err := ParseOrderTemplateYAML([]byte("symbol: BTCUSDT\nmakerRateLimt: \"0.3\"\n")); err == nil {
+		t.Fatal("expected unknown YAML field error")
+	}
+	if _, err := ParseOrderTemplateJSON([]byte(`{"symbol":"BTCUSDT","povLimt":"0.1"}`)); err == nil {
+		t.Fatal("expected unknown JSON field error")
+	}
+
+	tpl, err := ParseOrderTemplateYAML([]byte(binancePerpTwapTemplateYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewClient("k", "s").NewCreateMasterOrderV2ServiceFromTemplate(tpl, map[string]string{"symbol": "BTCUSDT"})
+	if err == nil || !strings.Contains(err.Error(), "apiKeyId") || !strings.Contains(err.Error(), "quantity") {
+		t.Fatalf("err = %v, want missing apiKeyId/quantity", err)
+	}
+
+	resolved, _ := tpl.Substitute(map[string]string{"apiKeyId": "k", "symbol": "BTCUSDT", "quantity": "1"})
+	short := int64(10)
+	if err := resolved.With(&OrderTemplate{ExecutionDurationSeconds: &short}).Validate(); err == nil ||
+		!strings.Contains(err.Error(), "executionDurationSeconds must be greater than 10") {
+		t.Fatalf("Validate() = %v, want service validation error", err)
+	}
+}
+
+func TestOrderTemplateRoundTripsThroughFiles(t *testing.T) {
+	svc := NewClient("k", "s").NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").Exchange("OKX").MarketType("SPOT").Symbol("BTCUSDT").
+		Side("buy").Algorithm("VWAP").ExecutionDurationSeconds(600).TotalQuantity("0.2").
+		EnableMake(false)
+	tpl := svc.Template()
+	tpl.Name = "okx-vwap"
+
+	dir := t.TempDir()
+	yamlData, err := yaml.Marshal(tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{"t.yaml": yamlData, "t.json": jsonData} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadOrderTemplate(path)
+		if err != nil {
+			t.Fatalf("LoadOrderTemplate(%s) error = %v", name, err)
+		}
+		if err := got.Validate(); err != nil {
+			t.Fatalf("%s: Validate() error = %v", name, err)
+		}
+		if got.Name != "okx-vwap" || got.Algorithm != "VWAP" || got.EnableMake == nil || *got.EnableMake {
+			t.Fatalf("%s: round trip mismatch: %#v", name, got)
+		}
+	}
+}
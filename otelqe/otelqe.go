@@ -0,0 +1,218 @@
+// Package otelqe instruments a qe_connector Client with OpenTelemetry.
+//
+//	inst, err := otelqe.New()
+//	if err != nil {
+//		return err
+//	}
+//	client.SetInstrumentation(inst)
+//
+// REST calls get client spans named "<METHOD> <endpoint>" with the HTTP
+// status, the API error code and reason, and the server's traceId as
+// attributes; W3C trace context (or whatever propagator is configured) is
+// injected into the request headers. WebSocket connects, reconnects and
+// handled messages get their own spans.
+//
+// Metrics:
+//
+//	qe.client.request.duration  histogram (s)  qe.endpoint, http.request.method, http.response.status_code
+//	qe.client.request.errors    counter        qe.endpoint, error.type (API reason, "http_<status>" or "transport")
+//	qe.client.hedges            counter        qe.endpoint, outcome ("win" or "loss"); see qe.Client.SetHedging
+//	qe.ws.reconnects            counter        outcome ("ok" or "error")
+//	qe.ws.message.lag           histogram (s)  qe.ws.message_type; now minus the push's updatedAt
+//
+// Nothing is exported by this package: spans and metrics go to the
+// configured providers, so tests can use in-memory exporters and readers.
+package otelqe
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/Quantum-Execute/qe-connector-go/otelqe"
+
+// Attribute keys set on spans and metrics.
+const (
+	EndpointKey    = attribute.Key("qe.endpoint")
+	ErrorCodeKey   = attribute.Key("qe.error.code")
+	ErrorReasonKey = attribute.Key("qe.error.reason")
+	ServerTraceKey = attribute.Key("qe.trace_id")
+	MessageTypeKey = attribute.Key("qe.ws.message_type")
+	MethodKey      = attribute.Key("http.request.method")
+	StatusKey      = attribute.Key("http.response.status_code")
+	ErrorTypeKey   = attribute.Key("error.type")
+	OutcomeKey     = attribute.Key("outcome")
+)
+
+type config struct {
+	tp          trace.TracerProvider
+	mp          metric.MeterProvider
+	propagators propagation.TextMapPropagator
+}
+
+// Option configures New.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The default is the global
+// one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tp = tp }
+}
+
+// WithMeterProvider sets the meter provider. The default is the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.mp = mp }
+}
+
+// WithPropagators sets the propagator used to inject trace context into REST
+// requests. The default is the global one, which is a no-op unless
+// otel.SetTextMapPropagator was called.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagators = p }
+}
+
+// Instrumentation implements qe.Instrumentation with OpenTelemetry.
+type Instrumentation struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+	hedges      metric.Int64Counter
+	reconnects  metric.Int64Counter
+	lag         metric.Float64Histogram
+}
+
+var (
+	_ qe.Instrumentation = (*Instrumentation)(nil)
+	_ qe.HedgeObserver   = (*Instrumentation)(nil)
+)
+
+// New creates the tracer and instruments.
+func New(opts ...Option) (*Instrumentation, error) {
+	cfg := config{
+		tp:          otel.GetTracerProvider(),
+		mp:          otel.GetMeterProvider(),
+		propagators: otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	meter := cfg.mp.Meter(ScopeName, metric.WithInstrumentationVersion(qe.Version))
+	i := &Instrumentation{
+		tracer:      cfg.tp.Tracer(ScopeName, trace.WithInstrumentationVersion(qe.Version)),
+		propagators: cfg.propagators,
+	}
+	var err error
+	if i.duration, err = meter.Float64Histogram("qe.client.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of QE REST calls.")); err != nil {
+		return nil, err
+	}
+	if i.errors, err = meter.Int64Counter("qe.client.request.errors",
+		metric.WithDescription("Failed QE REST calls by error type.")); err != nil {
+		return nil, err
+	}
+	if i.hedges, err = meter.Int64Counter("qe.client.hedges",
+		metric.WithDescription("Hedge requests sent, by whether they answered first.")); err != nil {
+		return nil, err
+	}
+	if i.reconnects, err = meter.Int64Counter("qe.ws.reconnects",
+		metric.WithDescription("WebSocket reconnect attempts.")); err != nil {
+		return nil, err
+	}
+	if i.lag, err = meter.Float64Histogram("qe.ws.message.lag",
+		metric.WithUnit("s"), metric.WithDescription("Delay between a push's updatedAt and its handling.")); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// StartRequest implements qe.Instrumentation.
+func (i *Instrumentation) StartRequest(ctx context.Context, req *http.Request, endpoint string) (context.Context, func(qe.RequestResult)) {
+	ctx, span := i.tracer.Start(ctx, req.Method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			MethodKey.String(req.Method),
+			EndpointKey.String(endpoint),
+			attribute.String("server.address", req.URL.Hostname()),
+		))
+	i.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return ctx, func(res qe.RequestResult) {
+		attrs := []attribute.KeyValue{MethodKey.String(res.Method), EndpointKey.String(res.Endpoint)}
+		if res.Status != 0 {
+			attrs = append(attrs, StatusKey.Int(res.Status))
+		}
+		i.duration.Record(ctx, res.Latency.Seconds(), metric.WithAttributes(attrs...))
+
+		span.SetAttributes(attrs...)
+		if res.TraceId != "" {
+			span.SetAttributes(ServerTraceKey.String(res.TraceId))
+		}
+		if res.Err != nil {
+			errType := "transport"
+			switch {
+			case res.Reason != "":
+				errType = res.Reason
+			case res.Code != 0:
+				errType = "code_" + strconv.Itoa(res.Code)
+			case res.Status >= http.StatusBadRequest:
+				errType = "http_" + strconv.Itoa(res.Status)
+			}
+			if res.Code != 0 {
+				span.SetAttributes(ErrorCodeKey.Int(res.Code), ErrorReasonKey.String(res.Reason))
+			}
+			i.errors.Add(ctx, 1, metric.WithAttributes(EndpointKey.String(res.Endpoint), ErrorTypeKey.String(errType)))
+			span.RecordError(res.Err)
+			span.SetStatus(codes.Error, errType)
+		}
+		span.End()
+	}
+}
+
+// StartWebSocket implements qe.Instrumentation.
+func (i *Instrumentation) StartWebSocket(ctx context.Context, op string) (context.Context, func(error)) {
+	kind := trace.SpanKindClient
+	if op == qe.WebSocketOpMessage {
+		kind = trace.SpanKindConsumer
+	}
+	ctx, span := i.tracer.Start(ctx, "qe.ws."+op, trace.WithSpanKind(kind))
+	return ctx, func(err error) {
+		if op == qe.WebSocketOpReconnect {
+			outcome := "ok"
+			if err != nil {
+				outcome = "error"
+			}
+			i.reconnects.Add(ctx, 1, metric.WithAttributes(OutcomeKey.String(outcome)))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// ObserveHedge implements qe.HedgeObserver.
+func (i *Instrumentation) ObserveHedge(ctx context.Context, endpoint string, won bool) {
+	outcome := "loss"
+	if won {
+		outcome = "win"
+	}
+	i.hedges.Add(ctx, 1, metric.WithAttributes(EndpointKey.String(endpoint), OutcomeKey.String(outcome)))
+}
+
+// MessageLag implements qe.Instrumentation.
+func (i *Instrumentation) MessageLag(ctx context.Context, msgType qe.ClientMessageType, lag time.Duration) {
+	i.lag.Record(ctx, lag.Seconds(), metric.WithAttributes(MessageTypeKey.String(string(msgType))))
+}
@@ -0,0 +1,238 @@
+package otelqe_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/otelqe"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	apiKey    = "otel-test-api-key"
+	secretKey = "otel-test-secret-key"
+)
+
+type harness struct {
+	srv    *qetest.Server
+	client *qe.Client
+	spans  *tracetest.InMemoryExporter
+	reader *sdkmetric.ManualReader
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	h := &harness{
+		srv:    qetest.NewServer(apiKey, secretKey),
+		spans:  tracetest.NewInMemoryExporter(),
+		reader: sdkmetric.NewManualReader(),
+	}
+	t.Cleanup(h.srv.Close)
+	inst, err := otelqe.New(
+		otelqe.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(h.spans))),
+		otelqe.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(h.reader))),
+		otelqe.WithPropagators(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.client = qe.NewClient(apiKey, secretKey, h.srv.URL).SetInstrumentation(inst)
+	return h
+}
+
+func (h *harness) span(t *testing.T, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, s := range h.spans.GetSpans() {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no span %q in %d spans", name, len(h.spans.GetSpans()))
+	return tracetest.SpanStub{}
+}
+
+func (h *harness) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := h.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	out := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m.Data
+		}
+	}
+	return out
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestRESTSpansMetricsAndPropagation(t *testing.T) {
+	h := newHarness(t)
+	ctx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "caller")
+	_, err := h.client.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity("1").
+		Do(ctx)
+	parent.End()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.client.NewGetMasterOrderDetailV2Service().MasterOrderId("missing").Do(context.Background()); err == nil {
+		t.Fatal("expected not found")
+	}
+
+	var endpoint string
+	for _, s := range h.spans.GetSpans() {
+		if strings.HasPrefix(s.Name, "POST ") {
+			endpoint = strings.TrimPrefix(s.Name, "POST ")
+		}
+	}
+	create := h.span(t, "POST "+endpoint)
+	if create.SpanKind != trace.SpanKindClient || create.Parent.TraceID() != parent.SpanContext().TraceID() {
+		t.Fatalf("create span kind=%v parent=%v", create.SpanKind, create.Parent)
+	}
+	if attr(create.Attributes, otelqe.StatusKey).AsInt64() != 200 ||
+		!strings.HasPrefix(attr(create.Attributes, otelqe.ServerTraceKey).AsString(), "qetest-") {
+		t.Fatalf("create span attributes = %v", create.Attributes)
+	}
+
+	reqs := h.srv.Requests()
+	var traceparent string
+	for _, r := range reqs {
+		if r.Method == "POST" {
+			traceparent = r.Header.Get("traceparent")
+		}
+	}
+	if !strings.Contains(traceparent, create.SpanContext.TraceID().String()) ||
+		!strings.Contains(traceparent, create.SpanContext.SpanID().String()) {
+		t.Fatalf("traceparent %q does not carry span %v", traceparent, create.SpanContext)
+	}
+
+	var failed tracetest.SpanStub
+	for _, s := range h.spans.GetSpans() {
+		if s.Status.Code == codes.Error {
+			failed = s
+		}
+	}
+	if !strings.HasPrefix(failed.Name, "GET ") || attr(failed.Attributes, otelqe.ErrorCodeKey).AsInt64() == 0 {
+		t.Fatalf("error span = %+v", failed)
+	}
+
+	m := h.metrics(t)
+	hist := m["qe.client.request.duration"].(metricdata.Histogram[float64])
+	var calls uint64
+	for _, dp := range hist.DataPoints {
+		calls += dp.Count
+	}
+	if calls != 2 {
+		t.Fatalf("duration histogram has %d calls, want 2", calls)
+	}
+	errs := m["qe.client.request.errors"].(metricdata.Sum[int64])
+	if len(errs.DataPoints) != 1 || errs.DataPoints[0].Value != 1 {
+		t.Fatalf("error counter = %+v", errs.DataPoints)
+	}
+}
+
+func TestWebSocketSpansAndLag(t *testing.T) {
+	h := newHarness(t)
+	lk, err := h.client.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(chan struct{}, 1)
+	ws := h.client.NewWebSocketService(h.srv.WSHost()).SetHandlers(&qe.WebSocketEventHandlers{
+		OnOrderFillDetail: func(*qe.WsOrderFillDetail) error { got <- struct{}{}; return nil },
+	})
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := h.srv.WaitForWSClients(1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	updated := time.Now().Add(-2 * time.Second).UTC().Format(time.RFC3339)
+	if err := h.srv.PushOrderFill(&qe.OrderFillV2Info{MasterOrderId: "mo-1", UpdatedAt: updated}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-got:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no fill push")
+	}
+
+	h.span(t, "qe.ws.connect")
+	// The message span ends after the handler returns.
+	deadline := time.Now().Add(time.Second)
+	for {
+		var found bool
+		for _, s := range h.spans.GetSpans() {
+			found = found || s.Name == "qe.ws.message"
+		}
+		if found {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no qe.ws.message span")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	lag := h.metrics(t)["qe.ws.message.lag"].(metricdata.Histogram[float64])
+	if len(lag.DataPoints) != 1 || lag.DataPoints[0].Count != 1 || lag.DataPoints[0].Sum < 1 {
+		t.Fatalf("lag histogram = %+v", lag.DataPoints)
+	}
+	if v, _ := lag.DataPoints[0].Attributes.Value(otelqe.MessageTypeKey); v.AsString() != string(qe.ClientOrderFillDetailType) {
+		t.Fatalf("lag attributes = %v", lag.DataPoints[0].Attributes)
+	}
+}
+
+func TestHedgeCounter(t *testing.T) {
+	h := newHarness(t)
+	h.client.SetHedging(&qe.HedgeConfig{MaxDelay: 20 * time.Millisecond, Budget: 1})
+	var first atomic.Bool
+	h.srv.SetLatencyFunc(func(r *http.Request) time.Duration {
+		if r.Method == http.MethodGet && first.CompareAndSwap(false, true) {
+			return 5 * time.Second
+		}
+		return 0
+	})
+	if _, err := h.client.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	hedges := h.metrics(t)["qe.client.hedges"].(metricdata.Sum[int64])
+	if len(hedges.DataPoints) != 1 || hedges.DataPoints[0].Value != 1 {
+		t.Fatalf("hedge counter = %+v", hedges.DataPoints)
+	}
+	if v, _ := hedges.DataPoints[0].Attributes.Value(otelqe.OutcomeKey); v.AsString() != "win" {
+		t.Fatalf("hedge attributes = %v", hedges.DataPoints[0].Attributes)
+	}
+}
@@ -0,0 +1,520 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+// PairTradeCoordinator runs two V2 master orders as one spread execution
+// (e.g. spot buy + perp sell, or a Binance vs OKX basis trade) and keeps
+// their fill progress in step.
+//
+// Progress of a leg is `cumFilledQty / totalQuantity` (or
+// `cumFilledNotional / orderNotional` for notional orders), so legs on
+// different instruments are compared on the same 0-1 scale. When one leg gets
+// ahead of the other by more than Tolerance, the leading leg is paused with
+// PauseMasterOrderV2Service; once the gap closes to ResumeTolerance it is
+// resumed with ResumeMasterOrderV2Service.
+//
+// Progress is observed by polling GetMasterOrderDetailV2Service and, when
+// wired into WebSocketEventHandlers.OnMasterOrderDetail, by WS pushes:
+//
+//	handlers.OnMasterOrderDetail = coordinator.HandleMasterOrderDetail
+type PairTradeCoordinator struct {
+	c               *Client
+	legs            [2]*pairTradeLeg
+	tolerance       float64
+	resumeTolerance *float64
+	pollInterval    time.Duration
+	cancelHeld      bool
+
+	mu      sync.Mutex
+	started bool
+	pauses  int
+	resumes int
+	changed chan struct{}
+}
+
+type pairTradeLeg struct {
+	name          string
+	order         *CreateMasterOrderV2Service
+	masterOrderId string
+	target        float64
+	byNotional    bool
+	status        MasterOrderStatusV2
+	cumQty        float64
+	cumNotional   float64
+	paused        bool
+	pollErr       error
+}
+
+// PairTradeLegState is a snapshot of one leg.
+type PairTradeLegState struct {
+	Name              string
+	MasterOrderId     string
+	Status            MasterOrderStatusV2
+	CumFilledQty      float64
+	CumFilledNotional float64
+	// Target is totalQuantity, or orderNotional for notional-sized legs.
+	Target float64
+	// Progress is the filled fraction of Target in [0, 1].
+	Progress float64
+	// Paused is true while the coordinator holds this leg paused.
+	Paused bool
+	// PollError is the error of the last poll of this leg if it failed
+	// after retrying, nil once a poll succeeds again.
+	PollError error
+}
+
+// PairTradeReport summarises the pair once both legs are done, or whenever
+// Snapshot is called.
+type PairTradeReport struct {
+	Legs [2]PairTradeLegState
+	// Imbalance is Legs[0].Progress - Legs[1].Progress; positive means the
+	// first leg is ahead.
+	Imbalance float64
+	Pauses    int
+	Resumes   int
+}
+
+// pairTradeCleanupTimeout bounds the cancel and resume calls that clean up
+// after the pair, which run even when the caller's context is done.
+const pairTradeCleanupTimeout = 10 * time.Second
+
+// pairTradePollBackoff is the pause between attempts of a leg poll that
+// failed transiently; attempts stop when the next poll is due.
+const pairTradePollBackoff = 250 * time.Millisecond
+
+// NewPairTradeCoordinator creates a coordinator for two legs. The services
+// are fully configured create requests; the coordinator sends them in Start.
+func (c *Client) NewPairTradeCoordinator(first, second *CreateMasterOrderV2Service) *PairTradeCoordinator {
+	return &PairTradeCoordinator{
+		c: c,
+		legs: [2]*pairTradeLeg{
+			{name: "leg1", order: first},
+			{name: "leg2", order: second},
+		},
+		tolerance:    0.05,
+		pollInterval: 2 * time.Second,
+		changed:      make(chan struct{}, 1),
+	}
+}
+
+// LegNames sets display names for the two legs (default `leg1` / `leg2`).
+func (p *PairTradeCoordinator) LegNames(first, second string) *PairTradeCoordinator {
+	p.legs[0].name = first
+	p.legs[1].name = second
+	return p
+}
+
+// Tolerance sets the maximum progress gap (0-1) before the leading leg is
+// paused. Default 0.05, i.e. five percentage points.
+func (p *PairTradeCoordinator) Tolerance(tolerance float64) *PairTradeCoordinator {
+	p.tolerance = tolerance
+	return p
+}
+
+// ResumeTolerance sets the progress gap at which a paused leg is resumed.
+// Default is half of Tolerance, which avoids pause/resume flapping.
+func (p *PairTradeCoordinator) ResumeTolerance(tolerance float64) *PairTradeCoordinator {
+	p.resumeTolerance = &tolerance
+	return p
+}
+
+// PollInterval sets how often leg details are polled over REST. Zero
+// disables polling; progress then comes only from HandleMasterOrderDetail.
+// Each leg is polled on its own: transport failures, 429 and 5xx are
+// retried until the next poll is due, and a leg whose poll still fails is
+// logged and reported in PairTradeLegState.PollError while supervision
+// goes on.
+func (p *PairTradeCoordinator) PollInterval(interval time.Duration) *PairTradeCoordinator {
+	p.pollInterval = interval
+	return p
+}
+
+// CancelHeldLegOnExit makes Run cancel, instead of resume, a leg it still
+// holds paused when it returns.
+func (p *PairTradeCoordinator) CancelHeldLegOnExit(cancel bool) *PairTradeCoordinator {
+	p.cancelHeld = cancel
+	return p
+}
+
+func (p *PairTradeCoordinator) validate() error {
+	if p.legs[0].order == nil || p.legs[1].order == nil {
+		return errors.New("both legs are required")
+	}
+	if p.tolerance <= 0 || p.tolerance >= 1 {
+		return errors.New("tolerance must be between 0 and 1")
+	}
+	if p.resumeTolerance != nil && (*p.resumeTolerance < 0 || *p.resumeTolerance > p.tolerance) {
+		return errors.New("resumeTolerance must be between 0 and tolerance")
+	}
+	for _, leg := range p.legs {
+		if err := leg.order.validate(); err != nil {
+			return fmt.Errorf("%s: %w", leg.name, err)
+		}
+	}
+	return nil
+}
+
+// Start creates both master orders. If the second leg is rejected, the
+// first one is cancelled so the pair never runs one-legged; when that cancel
+// fails too, its error is joined to the returned one and the first leg may
+// still be running. A failed Start can be retried.
+func (p *PairTradeCoordinator) Start(ctx context.Context, opts ...RequestOption) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return errors.New("pair trade already started")
+	}
+	p.started = true
+	p.mu.Unlock()
+
+	for i, leg := range p.legs {
+		if leg.order.totalQuantity != nil {
+			leg.target, _ = strconv.ParseFloat(*leg.order.totalQuantity, 64)
+		} else if leg.order.orderNotional != nil {
+			leg.target, _ = strconv.ParseFloat(*leg.order.orderNotional, 64)
+			leg.byNotional = true
+		}
+		reply, err := leg.order.Do(ctx, opts...)
+		if err != nil {
+			err = fmt.Errorf("create %s: %w", leg.name, err)
+			if i == 1 {
+				err = errors.Join(err, p.cancelFirstLeg(ctx, opts...))
+			}
+			p.mu.Lock()
+			p.started = false
+			for _, l := range p.legs {
+				l.masterOrderId, l.status = "", ""
+			}
+			p.mu.Unlock()
+			return err
+		}
+		p.mu.Lock()
+		leg.masterOrderId = reply.MasterOrderId
+		leg.status = reply.Status
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// cancelFirstLeg cancels the first leg after the second was rejected. It
+// runs even if ctx is done, since that is when a lone leg would most likely
+// go unnoticed.
+func (p *PairTradeCoordinator) cancelFirstLeg(ctx context.Context, opts ...RequestOption) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), pairTradeCleanupTimeout)
+	defer cancel()
+	leg := p.legs[0]
+	if _, err := p.c.NewCancelMasterOrderV2Service().
+		MasterOrderId(leg.masterOrderId).
+		Reason("pair trade: second leg rejected").
+		Do(ctx, opts...); err != nil {
+		return fmt.Errorf("cancel %s %s, which may still be running: %w", leg.name, leg.masterOrderId, err)
+	}
+	return nil
+}
+
+// Run starts both legs (if Start has not been called yet) and supervises
+// them until both are terminal, or until one leg is terminal while the other
+// is held paused. ctx cancellation stops supervision but leaves the orders
+// running.
+//
+// A leg still held paused when Run returns is resumed, or cancelled with
+// CancelHeldLegOnExit, so it is not left paused with nobody supervising it.
+// If that fails the error names the leg and its report state stays Paused.
+func (p *PairTradeCoordinator) Run(ctx context.Context, opts ...RequestOption) (*PairTradeReport, error) {
+	p.mu.Lock()
+	started := p.started
+	p.mu.Unlock()
+	if !started {
+		if err := p.Start(ctx, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	var tick <-chan time.Time
+	if p.pollInterval > 0 {
+		ticker := time.NewTicker(p.pollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+		p.poll(ctx, opts...)
+	}
+	for {
+		if err := p.rebalance(ctx, opts...); err != nil {
+			p.c.debug("pair trade rebalance error: %v", err)
+		}
+		if p.done() {
+			err := p.releaseHeld(ctx, opts...)
+			return p.Snapshot(), err
+		}
+		select {
+		case <-ctx.Done():
+			err := errors.Join(ctx.Err(), p.releaseHeld(ctx, opts...))
+			return p.Snapshot(), err
+		case <-p.changed:
+		case <-tick:
+			p.poll(ctx, opts...)
+		}
+	}
+}
+
+// HandleMasterOrderDetail feeds a WS master_data push into the coordinator.
+// Its signature matches WebSocketEventHandlers.OnMasterOrderDetail; pushes
+// for other master orders are ignored.
+func (p *PairTradeCoordinator) HandleMasterOrderDetail(msg *WsMasterOrderDetail) error {
+	if msg == nil {
+		return nil
+	}
+	p.update(msg.MasterOrderID, msg.Status,
+		msg.CumFilledQty.String(), msg.CumFilledNotional.String())
+	return nil
+}
+
+// Snapshot returns the current state of both legs.
+func (p *PairTradeCoordinator) Snapshot() *PairTradeReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	report := &PairTradeReport{Pauses: p.pauses, Resumes: p.resumes}
+	for i, leg := range p.legs {
+		report.Legs[i] = PairTradeLegState{
+			Name:              leg.name,
+			MasterOrderId:     leg.masterOrderId,
+			Status:            leg.status,
+			CumFilledQty:      leg.cumQty,
+			CumFilledNotional: leg.cumNotional,
+			Target:            leg.target,
+			Progress:          leg.progress(),
+			Paused:            leg.paused,
+			PollError:         leg.pollErr,
+		}
+	}
+	report.Imbalance = report.Legs[0].Progress - report.Legs[1].Progress
+	return report
+}
+
+// poll refreshes both legs over REST. The legs are polled independently,
+// so one failing fetch does not leave the other unwatched; failures are
+// logged and kept in the leg's PollError.
+func (p *PairTradeCoordinator) poll(ctx context.Context, opts ...RequestOption) {
+	var wg sync.WaitGroup
+	for _, leg := range p.legs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.pollLeg(ctx, leg, opts...)
+			p.mu.Lock()
+			leg.pollErr = err
+			p.mu.Unlock()
+			if err != nil && ctx.Err() == nil {
+				if l := p.c.logger(); l != nil {
+					l.Warn("qe pair trade poll failed", slog.String("leg", leg.name), slog.Any("error", err))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// pollLeg fetches one leg, retrying transient failures until the next poll
+// is due.
+func (p *PairTradeCoordinator) pollLeg(ctx context.Context, leg *pairTradeLeg, opts ...RequestOption) error {
+	p.mu.Lock()
+	id := leg.masterOrderId
+	p.mu.Unlock()
+	deadline := time.Now().Add(p.pollInterval)
+	var meta ResponseMeta
+	opts = append(opts[:len(opts):len(opts)], WithResponseMeta(&meta))
+	for {
+		reply, err := p.c.NewGetMasterOrderDetailV2Service().MasterOrderId(id).Do(ctx, opts...)
+		if err == nil {
+			mo := reply.MasterOrder
+			var qty, notional string
+			if mo.CumFilledQty != nil {
+				qty = *mo.CumFilledQty
+			}
+			if mo.CumFilledNotional != nil {
+				notional = *mo.CumFilledNotional
+			}
+			p.update(id, mo.Status, qty, notional)
+			return nil
+		}
+		transient := transientPollError(err, meta.StatusCode)
+		err = fmt.Errorf("poll %s %s: %w", leg.name, id, err)
+		if !transient || time.Until(deadline) < pairTradePollBackoff {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(pairTradePollBackoff):
+		}
+	}
+}
+
+// transientPollError reports whether a fetch that failed with err and HTTP
+// status is worth retrying: transport failures, rate limiting and server
+// errors, but not errors the API reported for the request itself.
+func transientPollError(err error, status int) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		return true
+	}
+	return !handlers.IsAPIError(err)
+}
+
+func (p *PairTradeCoordinator) update(masterOrderId string, status MasterOrderStatusV2, cumQty, cumNotional string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, leg := range p.legs {
+		if leg.masterOrderId == "" || leg.masterOrderId != masterOrderId {
+			continue
+		}
+		if status != "" {
+			leg.status = status
+		}
+		if v, err := strconv.ParseFloat(cumQty, 64); err == nil {
+			leg.cumQty = v
+		}
+		if v, err := strconv.ParseFloat(cumNotional, 64); err == nil {
+			leg.cumNotional = v
+		}
+		select {
+		case p.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// rebalance pauses the leading leg when the gap exceeds tolerance and
+// resumes it once the gap is back within resumeTolerance.
+func (p *PairTradeCoordinator) rebalance(ctx context.Context, opts ...RequestOption) error {
+	p.mu.Lock()
+	a, b := p.legs[0], p.legs[1]
+	gap := a.progress() - b.progress()
+	leader, lagger := a, b
+	if gap < 0 {
+		leader, lagger = b, a
+		gap = -gap
+	}
+	resumeAt := p.tolerance / 2
+	if p.resumeTolerance != nil {
+		resumeAt = *p.resumeTolerance
+	}
+	var pause, resume *pairTradeLeg
+	switch {
+	case gap > p.tolerance && !leader.paused && !leader.status.IsTerminal() && !lagger.status.IsTerminal():
+		pause = leader
+	case a.paused && (a.progress()-b.progress() <= resumeAt):
+		resume = a
+	case b.paused && (b.progress()-a.progress() <= resumeAt):
+		resume = b
+	}
+	p.mu.Unlock()
+
+	if pause != nil {
+		p.c.debug("pair trade: pausing %s (gap %.4f > %.4f)", pause.name, gap, p.tolerance)
+		if _, err := p.c.NewPauseMasterOrderV2Service().
+			MasterOrderId(pause.masterOrderId).
+			Reason(fmt.Sprintf("pair trade: %s ahead by %.4f", pause.name, gap)).
+			Do(ctx, opts...); err != nil {
+			return fmt.Errorf("pause %s: %w", pause.name, err)
+		}
+		p.mu.Lock()
+		pause.paused = true
+		p.pauses++
+		p.mu.Unlock()
+	}
+	if resume != nil {
+		p.c.debug("pair trade: resuming %s (gap %.4f)", resume.name, gap)
+		if _, err := p.c.NewResumeMasterOrderV2Service().
+			MasterOrderId(resume.masterOrderId).
+			Reason("pair trade: legs back in line").
+			Do(ctx, opts...); err != nil {
+			return fmt.Errorf("resume %s: %w", resume.name, err)
+		}
+		p.mu.Lock()
+		resume.paused = false
+		p.resumes++
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// releaseHeld resumes, or cancels, the legs still held paused. Like
+// cancelFirstLeg it runs even if ctx is done.
+func (p *PairTradeCoordinator) releaseHeld(ctx context.Context, opts ...RequestOption) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), pairTradeCleanupTimeout)
+	defer cancel()
+	var errs []error
+	for _, leg := range p.legs {
+		p.mu.Lock()
+		held, id := leg.paused && !leg.status.IsTerminal(), leg.masterOrderId
+		p.mu.Unlock()
+		if !held {
+			continue
+		}
+		action := "resume"
+		var err error
+		if p.cancelHeld {
+			action = "cancel"
+			_, err = p.c.NewCancelMasterOrderV2Service().
+				MasterOrderId(id).
+				Reason("pair trade: supervision ended").
+				Do(ctx, opts...)
+		} else {
+			_, err = p.c.NewResumeMasterOrderV2Service().
+				MasterOrderId(id).
+				Reason("pair trade: supervision ended").
+				Do(ctx, opts...)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s is left paused: %s: %w", leg.name, id, action, err))
+			continue
+		}
+		p.mu.Lock()
+		leg.paused = false
+		if !p.cancelHeld {
+			p.resumes++
+		}
+		p.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+func (p *PairTradeCoordinator) done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	a, b := p.legs[0], p.legs[1]
+	if a.status.IsTerminal() && b.status.IsTerminal() {
+		return true
+	}
+	// A finished leg can no longer catch up, so a leg we hold paused
+	// behind it would wait forever; hand control back to the caller.
+	return (a.status.IsTerminal() && b.paused) || (b.status.IsTerminal() && a.paused)
+}
+
+func (l *pairTradeLeg) progress() float64 {
+	if l.target <= 0 {
+		return 0
+	}
+	filled := l.cumQty
+	if l.byNotional {
+		filled = l.cumNotional
+	}
+	return math.Min(filled/l.target, 1)
+}
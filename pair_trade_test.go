@@ -0,0 +1,302 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+func newPairTradeLeg(c *Client, side trading_enums.OrderSide, qty string) *CreateMasterOrderV2Service {
+	return c.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(side).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity(qty)
+}
+
+// pairTradeServer fakes the V2 order endpoints for coordinator tests. Each
+// created order gets the next id mo_1, mo_2, ...; createErr, if set, answers
+// the n-th create (1-based) with an error. Pause, resume and cancel calls are
+// sent on actions.
+type pairTradeServer struct {
+	mu        sync.Mutex
+	created   int
+	createErr map[int]string
+	actionErr string
+	actions   chan string
+}
+
+func newPairTradeServer(c *Client) *pairTradeServer {
+	srv := &pairTradeServer{actions: make(chan string, 16)}
+	c.do = srv.do
+	return srv
+}
+
+func (srv *pairTradeServer) do(r *http.Request) (*http.Response, error) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	body := `{"code":200,"message":{"success":true}}`
+	switch {
+	case r.Method == http.MethodPost:
+		srv.created++
+		body = fmt.Sprintf(`{"code":200,"message":{"masterOrderId":"mo_%d","status":"NEW"}}`, srv.created)
+		if msg, ok := srv.createErr[srv.created]; ok {
+			body = `{"code":9001,"reason":"INVALID_PARAMETER","message":"` + msg + `"}`
+		}
+	case strings.HasSuffix(r.URL.Path, "/pause"), strings.HasSuffix(r.URL.Path, "/resume"),
+		strings.HasSuffix(r.URL.Path, "/cancel"):
+		srv.actions <- r.URL.Path
+		if srv.actionErr != "" {
+			body = `{"code":9002,"reason":"INVALID_STATUS","message":"` + srv.actionErr + `"}`
+		}
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+// expect waits for the next pause, resume or cancel call and checks it.
+func (srv *pairTradeServer) expect(t *testing.T, want string) {
+	t.Helper()
+	select {
+	case got := <-srv.actions:
+		if got != "/user/trading/v2/master-orders/"+want {
+			t.Fatalf("action = %s, want %s", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no action, want %s", want)
+	}
+}
+
+// startPair creates both legs and runs the coordinator in the background.
+func startPair(t *testing.T, p *PairTradeCoordinator, ctx context.Context) <-chan pairTradeResult {
+	t.Helper()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan pairTradeResult, 1)
+	go func() {
+		report, err := p.Run(ctx)
+		done <- pairTradeResult{report, err}
+	}()
+	return done
+}
+
+type pairTradeResult struct {
+	report *PairTradeReport
+	err    error
+}
+
+func wait(t *testing.T, done <-chan pairTradeResult) pairTradeResult {
+	t.Helper()
+	select {
+	case res := <-done:
+		return res
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not return")
+		return pairTradeResult{}
+	}
+}
+
+func push(p *PairTradeCoordinator, id string, status MasterOrderStatusV2, qty string) {
+	_ = p.HandleMasterOrderDetail(&WsMasterOrderDetail{
+		MasterOrderID: id,
+		Status:        status,
+		CumFilledQty:  FlexDecimalString(qty),
+	})
+}
+
+func newPair(client *Client, qty1, qty2 string) *PairTradeCoordinator {
+	return client.NewPairTradeCoordinator(
+		newPairTradeLeg(client, trading_enums.OrderSideBuy, qty1),
+		newPairTradeLeg(client, trading_enums.OrderSideSell, qty2),
+	).Tolerance(0.1).PollInterval(0)
+}
+
+func TestPairTradeCoordinatorPausesLeaderAndResumesWhenBackInLine(t *testing.T) {
+	client := NewClient("k", "s", "https://example.test")
+	srv := newPairTradeServer(client)
+	p := newPair(client, "1", "2")
+	done := startPair(t, p, context.Background())
+
+	push(p, "mo_1", "PROCESSING", "0.5") // leg1 50%, leg2 0% -> pause leg1
+	srv.expect(t, "mo_1/pause")
+	push(p, "mo_2", "PROCESSING", "0.9") // leg2 45% -> gap 5% = resumeTolerance -> resume leg1
+	srv.expect(t, "mo_1/resume")
+	push(p, "mo_1", "COMPLETED", "1")
+	push(p, "mo_2", "COMPLETED", "2")
+
+	res := wait(t, done)
+	if res.err != nil {
+		t.Fatalf("Run() error = %v", res.err)
+	}
+	if res.report.Pauses != 1 || res.report.Resumes != 1 {
+		t.Fatalf("pauses/resumes = %d/%d, want 1/1", res.report.Pauses, res.report.Resumes)
+	}
+	if res.report.Imbalance != 0 {
+		t.Fatalf("Imbalance = %v, want 0", res.report.Imbalance)
+	}
+	if len(srv.actions) != 0 {
+		t.Fatalf("unexpected action %s", <-srv.actions)
+	}
+}
+
+func TestPairTradeCoordinatorReleasesHeldLegOnExit(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		cancelHeld bool
+		want       string
+	}{
+		{"resume", false, "mo_1/resume"},
+		{"cancel", true, "mo_1/cancel"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewClient("k", "s", "https://example.test")
+			srv := newPairTradeServer(client)
+			p := newPair(client, "1", "1").CancelHeldLegOnExit(tc.cancelHeld)
+			done := startPair(t, p, context.Background())
+
+			push(p, "mo_1", "PROCESSING", "0.5")
+			srv.expect(t, "mo_1/pause")
+			push(p, "mo_2", "CANCELLED", "0.1") // leg2 can no longer catch up
+			srv.expect(t, tc.want)
+
+			res := wait(t, done)
+			if res.err != nil || res.report.Legs[0].Paused {
+				t.Fatalf("Run() = %+v, %v; want leg1 released", res.report.Legs[0], res.err)
+			}
+		})
+	}
+}
+
+func TestPairTradeCoordinatorReleasesHeldLegWhenContextEnds(t *testing.T) {
+	client := NewClient("k", "s", "https://example.test")
+	srv := newPairTradeServer(client)
+	p := newPair(client, "1", "1")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := startPair(t, p, ctx)
+
+	push(p, "mo_1", "PROCESSING", "0.5")
+	srv.expect(t, "mo_1/pause")
+	srv.mu.Lock()
+	srv.actionErr = "gateway busy"
+	srv.mu.Unlock()
+	cancel()
+	srv.expect(t, "mo_1/resume")
+
+	res := wait(t, done)
+	if !errors.Is(res.err, context.Canceled) || !strings.Contains(res.err.Error(), "leg1 mo_1 is left paused") {
+		t.Fatalf("Run() error = %v, want context.Canceled and the stranded leg", res.err)
+	}
+	if !res.report.Legs[0].Paused {
+		t.Fatal("report should show leg1 still paused")
+	}
+}
+
+func TestPairTradeCoordinatorCancelsFirstLegWhenSecondIsRejected(t *testing.T) {
+	client := NewClient("k", "s", "https://example.test")
+	srv := newPairTradeServer(client)
+	srv.createErr = map[int]string{2: "insufficient balance"}
+	p := newPair(client, "1", "1")
+
+	err := p.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "create leg2") {
+		t.Fatalf("Start() err = %v, want leg2 create error", err)
+	}
+	srv.expect(t, "mo_1/cancel")
+
+	// A failed Start can be retried.
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if s := p.Snapshot(); s.Legs[0].MasterOrderId != "mo_3" || s.Legs[1].MasterOrderId != "mo_4" {
+		t.Fatalf("legs after retry = %s, %s", s.Legs[0].MasterOrderId, s.Legs[1].MasterOrderId)
+	}
+}
+
+func TestPairTradeCoordinatorReportsFailedCompensatingCancel(t *testing.T) {
+	client := NewClient("k", "s", "https://example.test")
+	srv := newPairTradeServer(client)
+	srv.createErr = map[int]string{2: "insufficient balance"}
+	srv.actionErr = "gateway busy"
+	ctx, cancel := context.WithCancel(context.Background())
+	client.do = func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPost && srv.created == 1 {
+			cancel() // the caller gives up while leg2 is being created
+		}
+		return srv.do(r)
+	}
+
+	err := newPair(client, "1", "1").Start(ctx)
+	srv.expect(t, "mo_1/cancel")
+	if err == nil || !strings.Contains(err.Error(), "create leg2") ||
+		!strings.Contains(err.Error(), "cancel leg1 mo_1, which may still be running") {
+		t.Fatalf("Start() err = %v, want create and cancel errors", err)
+	}
+}
+
+func TestPairTradeCoordinatorRetriesTransientPollErrors(t *testing.T) {
+	client := NewClient("k", "s", "https://example.test")
+	srv := newPairTradeServer(client)
+	var mu sync.Mutex
+	gets := map[string]int{}
+	client.do = func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodGet {
+			return srv.do(r)
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/user/trading/v2/master-orders/")
+		mu.Lock()
+		gets[id]++
+		n := gets[id]
+		mu.Unlock()
+		switch {
+		case id == "mo_1" && n <= 2:
+			return nil, errors.New("connection reset")
+		case id == "mo_2" && n == 1:
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header),
+				Body: io.NopCloser(strings.NewReader(`{"code":9001,"reason":"INVALID_PARAMETER","message":"bad id"}`))}, nil
+		}
+		body := fmt.Sprintf(`{"code":200,"message":{"masterOrder":{"masterOrderId":%q,"status":"COMPLETED","cumFilledQty":"1"}}}`, id)
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	p := newPair(client, "1", "1").PollInterval(time.Second)
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// leg1's connection errors are retried within the first poll; leg2's
+	// API error is not retried but is reported, and leg2 is polled again on
+	// the next tick.
+	p.poll(context.Background())
+	s := p.Snapshot()
+	if s.Legs[0].Status != MasterOrderStatusV2Completed || s.Legs[0].PollError != nil {
+		t.Fatalf("leg1 = %+v, want completed after retries", s.Legs[0])
+	}
+	if s.Legs[1].PollError == nil || !strings.Contains(s.Legs[1].PollError.Error(), "poll leg2 mo_2") {
+		t.Fatalf("leg2 PollError = %v", s.Legs[1].PollError)
+	}
+	mu.Lock()
+	if gets["mo_1"] != 3 || gets["mo_2"] != 1 {
+		t.Fatalf("gets = %v, want 3 for leg1 and 1 for leg2", gets)
+	}
+	mu.Unlock()
+
+	res, err := p.Run(context.Background())
+	if err != nil || res.Legs[1].Status != MasterOrderStatusV2Completed || res.Legs[1].PollError != nil {
+		t.Fatalf("Run() = %+v, %v", res.Legs[1], err)
+	}
+}
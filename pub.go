@@ -2,7 +2,6 @@ package qe_connector
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
 	"net/http"
 )
@@ -71,16 +70,7 @@ func (s *TradingPairsService) Do(ctx context.Context, opts ...RequestOption) (re
 		m["isCoin"] = *s.isCoin
 	}
 	r.setParams(m)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return nil, err
-	}
-	resp := new(TradingPairMessage)
-	err = json.Unmarshal(data, &resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return callAPIAs[TradingPairMessage](ctx, s.c, r, opts...)
 }
 
 type TradingPairMessage struct {
@@ -0,0 +1,722 @@
+package qetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/dto/algorithm_dto"
+)
+
+type order struct {
+	info  qe.MasterOrderV2Info
+	fills []qe.OrderFillV2Info
+}
+
+// updatableFields are the body keys accepted by the update endpoints.
+var updatableFields = map[string]bool{
+	"totalQuantity": true, "orderNotional": true, "upTolerance": true, "lowTolerance": true,
+	"enableMake": true, "makerRateLimit": true, "strictUpBound": true, "povLimit": true,
+	"povMinLimit": true, "worstPrice": true, "tailOrderProtection": true, "mustComplete": true,
+	"executionDurationSeconds": true,
+}
+
+// boolFields are the V1 query parameters that carry booleans.
+var boolFields = map[string]bool{
+	"enableMake": true, "strictUpBound": true, "tailOrderProtection": true, "mustComplete": true,
+	"reduceOnly": true, "isMargin": true, "isTargetPosition": true,
+}
+
+// =============================================================================
+//  Test helpers
+// =============================================================================
+
+// MasterOrder returns the current state of a master order.
+func (s *Server) MasterOrder(id string) (qe.MasterOrderV2Info, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[id]
+	if !ok {
+		return qe.MasterOrderV2Info{}, false
+	}
+	return o.info, true
+}
+
+// SetStatus moves a master order to status (e.g. COMPLETED) and pushes the
+// new state to WebSocket clients.
+func (s *Server) SetStatus(id string, status qe.MasterOrderStatusV2) error {
+	s.mu.Lock()
+	o, ok := s.orders[id]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("master order %s not found", id)
+	}
+	s.setStatusLocked(o, status)
+	info := o.info
+	s.mu.Unlock()
+	return s.PushMasterOrder(&info)
+}
+
+// AddFill records a child order fill (replacing an earlier one with the same
+// Id), updates the master order's cumulative fields and pushes both the
+// order_data and master_data messages.
+func (s *Server) AddFill(fill qe.OrderFillV2Info) error {
+	s.mu.Lock()
+	o, ok := s.orders[fill.MasterOrderId]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("master order %s not found", fill.MasterOrderId)
+	}
+	now := s.now().UTC().Format(time.RFC3339)
+	if fill.Id == "" {
+		fill.Id = fmt.Sprintf("%s-c%d", o.info.MasterOrderId, len(o.fills)+1)
+	}
+	if fill.Symbol == "" {
+		fill.Symbol = o.info.Symbol
+	}
+	if fill.Side == "" {
+		fill.Side = o.info.Side
+	}
+	if fill.Exchange == "" {
+		fill.Exchange = o.info.Exchange
+	}
+	if fill.CreatedAt == "" {
+		fill.CreatedAt = now
+	}
+	fill.UpdatedAt = now
+	replaced := false
+	for i := range o.fills {
+		if o.fills[i].Id == fill.Id {
+			o.fills[i] = fill
+			replaced = true
+		}
+	}
+	if !replaced {
+		o.fills = append(o.fills, fill)
+	}
+	o.recompute()
+	if o.info.Status == qe.MasterOrderStatusV2New {
+		o.info.Status = qe.MasterOrderStatusV2Processing
+	}
+	o.info.UpdatedAt = now
+	info := o.info
+	s.mu.Unlock()
+
+	if err := s.PushOrderFill(&fill); err != nil {
+		return err
+	}
+	return s.PushMasterOrder(&info)
+}
+
+// AddTCA adds rows served by the TCA endpoints.
+func (s *Server) AddTCA(rows ...*qe.TCAAnalysisV2Info) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tca = append(s.tca, rows...)
+}
+
+func (o *order) recompute() {
+	var qty, notional float64
+	for _, f := range o.fills {
+		q, _ := strconv.ParseFloat(f.FilledQuantity.String(), 64)
+		n, _ := strconv.ParseFloat(f.FilledNotional.String(), 64)
+		if n == 0 {
+			p, _ := strconv.ParseFloat(f.AveragePrice.String(), 64)
+			n = q * p
+		}
+		qty += q
+		notional += n
+	}
+	o.info.CumFilledQty = decimal(qty)
+	o.info.CumFilledNotional = decimal(notional)
+	if qty > 0 {
+		o.info.AvgFilledPrice = decimal(notional / qty)
+	}
+}
+
+func decimal(v float64) *string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	return &s
+}
+
+func (s *Server) setStatusLocked(o *order, status qe.MasterOrderStatusV2) {
+	now := s.now()
+	o.info.Status = status
+	o.info.UpdatedAt = now.UTC().Format(time.RFC3339)
+	if status.IsTerminal() && o.info.FinishedMs == nil {
+		ms := qe.FlexInt64(now.UnixMilli())
+		o.info.FinishedMs = &ms
+	}
+}
+
+// =============================================================================
+//  Shared order logic
+// =============================================================================
+
+type apiError struct {
+	status int
+	reason string
+	msg    string
+}
+
+func (s *Server) writeError(w http.ResponseWriter, e *apiError) {
+	s.fail(w, e.status, e.status, e.reason, e.msg)
+}
+
+func notFound(id string) *apiError {
+	return &apiError{http.StatusNotFound, "MASTER_ORDER_NOT_FOUND", fmt.Sprintf("master order %s not found", id)}
+}
+
+func (s *Server) create(info qe.MasterOrderV2Info) (qe.MasterOrderV2Info, *apiError) {
+	switch {
+	case info.ApiKeyId == "", info.Exchange == "", info.Symbol == "", info.Side == "", info.Algorithm == "":
+		return info, &apiError{http.StatusBadRequest, "INVALID_PARAMS", "apiKeyId, exchange, symbol, side and algorithm are required"}
+	case (info.TotalQuantity == nil) == (info.OrderNotional == nil):
+		return info, &apiError{http.StatusBadRequest, "INVALID_PARAMS", "exactly one of totalQuantity and orderNotional is required"}
+	}
+
+	s.mu.Lock()
+	if info.ClientOrderId != "" {
+		for _, o := range s.orders {
+			if o.info.ClientOrderId == info.ClientOrderId {
+				s.mu.Unlock()
+				return info, &apiError{http.StatusConflict, "DUPLICATE_CLIENT_ORDER_ID", "clientOrderId already used by " + o.info.MasterOrderId}
+			}
+		}
+	}
+	s.orderSeq++
+	now := s.now().UTC().Format(time.RFC3339)
+	info.MasterOrderId = fmt.Sprintf("mo_%06d", s.orderSeq)
+	info.ApiKeyUuid = info.ApiKeyId
+	info.Status = qe.MasterOrderStatusV2New
+	info.CreatedAt, info.UpdatedAt = now, now
+	if info.Category == "" {
+		info.Category = trading_enums.Category(strings.ToLower(string(info.MarketType)))
+	}
+	s.orders[info.MasterOrderId] = &order{info: info}
+	s.orderIds = append(s.orderIds, info.MasterOrderId)
+	s.mu.Unlock()
+
+	_ = s.PushMasterOrder(&info)
+	return info, nil
+}
+
+// act applies cancel / pause / resume / update to a master order.
+func (s *Server) act(id, action string, update map[string]interface{}) *apiError {
+	s.mu.Lock()
+	o, ok := s.orders[id]
+	if !ok {
+		s.mu.Unlock()
+		return notFound(id)
+	}
+	status := o.info.Status
+	invalid := &apiError{http.StatusBadRequest, "INVALID_STATUS", fmt.Sprintf("cannot %s master order in status %s", action, status)}
+	switch action {
+	case "cancel":
+		if status.IsTerminal() {
+			s.mu.Unlock()
+			return invalid
+		}
+		s.setStatusLocked(o, qe.MasterOrderStatusV2Cancelled)
+	case "pause":
+		if status.IsTerminal() || status == qe.MasterOrderStatusV2Paused {
+			s.mu.Unlock()
+			return invalid
+		}
+		s.setStatusLocked(o, qe.MasterOrderStatusV2Paused)
+	case "resume":
+		if status != qe.MasterOrderStatusV2Paused {
+			s.mu.Unlock()
+			return invalid
+		}
+		s.setStatusLocked(o, qe.MasterOrderStatusV2Processing)
+	case "update":
+		if status.IsTerminal() {
+			s.mu.Unlock()
+			return invalid
+		}
+		fields := map[string]interface{}{}
+		for k, v := range update {
+			if updatableFields[k] {
+				fields[k] = v
+			}
+		}
+		data, _ := json.Marshal(fields)
+		if err := json.Unmarshal(data, &o.info); err != nil {
+			s.mu.Unlock()
+			return &apiError{http.StatusBadRequest, "INVALID_PARAMS", err.Error()}
+		}
+		o.info.UpdatedAt = s.now().UTC().Format(time.RFC3339)
+	default:
+		s.mu.Unlock()
+		return &apiError{http.StatusNotFound, "NOT_FOUND", "unknown action " + action}
+	}
+	info := o.info
+	s.mu.Unlock()
+	_ = s.PushMasterOrder(&info)
+	return nil
+}
+
+// listOrders filters master orders with the list query parameters. The
+// status filter follows the V2 list semantics: NEW means every running
+// status and COMPLETED every finished one.
+func (s *Server) listOrders(q url.Values) []qe.MasterOrderV2Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []qe.MasterOrderV2Info
+	for i := len(s.orderIds) - 1; i >= 0; i-- {
+		info := s.orders[s.orderIds[i]].info
+		st := info.Status
+		switch q.Get("status") {
+		case "":
+		case string(qe.MasterOrderStatusV2New):
+			if st.IsTerminal() {
+				continue
+			}
+		case string(qe.MasterOrderStatusV2Completed):
+			if !st.IsTerminal() {
+				continue
+			}
+		default:
+			if q.Get("status") != string(info.Status) {
+				continue
+			}
+		}
+		if !matches(q, "exchange", info.Exchange) || !matches(q, "symbol", info.Symbol) ||
+			!matches(q, "algorithm", string(info.Algorithm)) || !matches(q, "apiKeyId", info.ApiKeyId) ||
+			!matches(q, "masterOrderId", info.MasterOrderId) {
+			continue
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+func (s *Server) listFills(q url.Values) []qe.OrderFillV2Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []qe.OrderFillV2Info
+	for _, id := range s.orderIds {
+		for _, f := range s.orders[id].fills {
+			if !matches(q, "masterOrderId", f.MasterOrderId) || !matches(q, "symbol", f.Symbol) ||
+				!matches(q, "status", string(f.Status)) || !matches(q, "orderId", f.OrderId) ||
+				!matches(q, "subOrderId", f.Id) {
+				continue
+			}
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (s *Server) listTCA(q url.Values) []*qe.TCAAnalysisV2Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+	end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+	out := make([]*qe.TCAAnalysisV2Info, 0)
+	for _, row := range s.tca {
+		var symbol, apiKeyId string
+		if o, ok := s.orders[row.MasterOrderId]; ok {
+			symbol, apiKeyId = o.info.Symbol, o.info.ApiKeyId
+		}
+		if !matches(q, "symbol", symbol) || !matches(q, "category", row.Category) ||
+			!matches(q, "strategy", row.Strategy) || !matchesAny(q, "apiKeyId", apiKeyId) ||
+			!matchesAny(q, "apikey", apiKeyId) {
+			continue
+		}
+		if start > 0 || end > 0 {
+			ts, err := time.Parse(time.RFC3339, row.StartTime)
+			if err != nil || (start > 0 && ts.UnixMilli() < start) || (end > 0 && ts.UnixMilli() > end) {
+				continue
+			}
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func matches(q url.Values, key, value string) bool {
+	want := q.Get(key)
+	return want == "" || strings.EqualFold(want, value)
+}
+
+// matchesAny accepts comma-separated filter values.
+func matchesAny(q url.Values, key, value string) bool {
+	want := q.Get(key)
+	if want == "" {
+		return true
+	}
+	for _, w := range strings.Split(want, ",") {
+		if strings.EqualFold(strings.TrimSpace(w), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func paginate[T any](q url.Values, items []T) (page, pageSize int, out []T) {
+	page, _ = strconv.Atoi(q.Get("page"))
+	pageSize, _ = strconv.Atoi(q.Get("pageSize"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	from := (page - 1) * pageSize
+	if from >= len(items) {
+		return page, pageSize, []T{}
+	}
+	to := from + pageSize
+	if to > len(items) {
+		to = len(items)
+	}
+	return page, pageSize, items[from:to]
+}
+
+func (s *Server) handleListenKey(w http.ResponseWriter, r *http.Request) {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	key := hex.EncodeToString(buf)
+	s.mu.Lock()
+	expire := s.now().Add(24 * time.Hour)
+	s.listenKeys[key] = listenKey{expire: expire, apiKey: r.Header.Get("X-MBX-APIKEY")}
+	s.mu.Unlock()
+	s.ok(w, qe.CreateListenKeyReply{ListenKey: key, ExpireAt: expire.UTC().Format(time.RFC3339), Success: true})
+}
+
+// =============================================================================
+//  V2 handlers
+// =============================================================================
+
+func (s *Server) handleCreateV2(w http.ResponseWriter, r *http.Request) {
+	var info qe.MasterOrderV2Info
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		s.writeError(w, &apiError{http.StatusBadRequest, "INVALID_PARAMS", err.Error()})
+		return
+	}
+	info, e := s.create(info)
+	if e != nil {
+		s.writeError(w, e)
+		return
+	}
+	s.ok(w, qe.CreateMasterOrderV2Reply{MasterOrderId: info.MasterOrderId, Status: info.Status, ClientOrderId: info.ClientOrderId})
+}
+
+func (s *Server) handleListV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	items := s.listOrders(q)
+	total := len(items)
+	page, pageSize, items := paginate(q, items)
+	s.ok(w, qe.GetMasterOrdersV2Reply{Items: items, Total: int32(total), Page: int32(page), PageSize: int32(pageSize)})
+}
+
+func (s *Server) handleDetailV2(w http.ResponseWriter, r *http.Request) {
+	info, ok := s.MasterOrder(r.PathValue("id"))
+	if !ok {
+		s.writeError(w, notFound(r.PathValue("id")))
+		return
+	}
+	s.ok(w, qe.GetMasterOrderDetailV2Reply{MasterOrder: info})
+}
+
+func (s *Server) handleDetailByClientIdV2(w http.ResponseWriter, r *http.Request) {
+	info, ok := s.byClientOrderId(r.PathValue("cid"))
+	if !ok {
+		s.writeError(w, notFound(r.PathValue("cid")))
+		return
+	}
+	s.ok(w, qe.GetMasterOrderDetailV2Reply{MasterOrder: info})
+}
+
+func (s *Server) byClientOrderId(cid string) (qe.MasterOrderV2Info, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.orders {
+		if o.info.ClientOrderId == cid {
+			return o.info, true
+		}
+	}
+	return qe.MasterOrderV2Info{}, false
+}
+
+func (s *Server) handleActionV2(w http.ResponseWriter, r *http.Request) {
+	body := map[string]interface{}{}
+	if r.ContentLength != 0 {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	if e := s.act(r.PathValue("id"), r.PathValue("action"), body); e != nil {
+		s.writeError(w, e)
+		return
+	}
+	s.ok(w, qe.MasterOrderActionV2Reply{Success: true, Message: "success"})
+}
+
+func (s *Server) handleBatchCancelV2(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		MasterOrderIds []string `json:"masterOrderIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.MasterOrderIds) == 0 {
+		s.writeError(w, &apiError{http.StatusBadRequest, "INVALID_PARAMS", "masterOrderIds must not be empty"})
+		return
+	}
+	reply := qe.BatchCancelMasterOrdersV2Reply{FailedOrders: []qe.BatchCancelV2FailedOrderInfo{}}
+	for _, id := range body.MasterOrderIds {
+		if e := s.act(id, "cancel", nil); e != nil {
+			reply.FailedOrders = append(reply.FailedOrders, qe.BatchCancelV2FailedOrderInfo{MasterOrderId: id, Reason: e.msg})
+			continue
+		}
+		reply.SuccessCount++
+	}
+	s.ok(w, reply)
+}
+
+func (s *Server) handleFillsV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	items := s.listFills(q)
+	total := len(items)
+	page, pageSize, items := paginate(q, items)
+	s.ok(w, qe.GetOrderFillsV2Reply{Items: items, Total: int32(total), Page: int32(page), PageSize: int32(pageSize)})
+}
+
+func (s *Server) handleTCAV2(w http.ResponseWriter, r *http.Request) {
+	s.ok(w, s.listTCA(r.URL.Query()))
+}
+
+// =============================================================================
+//  V1 handlers
+// =============================================================================
+
+func (s *Server) handleCreateV1(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	fields := map[string]interface{}{}
+	for k := range q {
+		v := q.Get(k)
+		switch {
+		case k == "timestamp" || k == "recvWindow" || k == "signature":
+		case boolFields[k]:
+			fields[k] = v == "true"
+		case k == "executionDurationSeconds":
+			fields[k], _ = strconv.ParseInt(v, 10, 64)
+		case k == "executionDuration":
+			minutes, _ := strconv.ParseInt(v, 10, 64)
+			fields["executionDurationSeconds"] = minutes * 60
+		default:
+			fields[k] = v
+		}
+	}
+	var info qe.MasterOrderV2Info
+	data, _ := json.Marshal(fields)
+	if err := json.Unmarshal(data, &info); err != nil {
+		s.writeError(w, &apiError{http.StatusBadRequest, "INVALID_PARAMS", err.Error()})
+		return
+	}
+	info, e := s.create(info)
+	if e != nil {
+		s.writeError(w, e)
+		return
+	}
+	s.ok(w, qe.CreateMasterOrderReply{MasterOrderId: info.MasterOrderId, Success: true, Message: "success"})
+}
+
+func (s *Server) handleListV1(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	orders := s.listOrders(q)
+	total := len(orders)
+	page, pageSize, orders := paginate(q, orders)
+	items := make([]qe.MasterOrderInfo, len(orders))
+	for i := range orders {
+		items[i] = toV1Order(&orders[i])
+	}
+	s.ok(w, qe.GetMasterOrdersReply{Items: items, Total: strconv.Itoa(total), Page: int32(page), PageSize: int32(pageSize)})
+}
+
+func (s *Server) handleDetailV1(w http.ResponseWriter, r *http.Request) {
+	info, ok := s.MasterOrder(r.PathValue("id"))
+	if !ok {
+		s.writeError(w, notFound(r.PathValue("id")))
+		return
+	}
+	s.ok(w, qe.GetMasterOrderDetailReply{MasterOrder: toV1Order(&info)})
+}
+
+func (s *Server) handleDetailByClientIdV1(w http.ResponseWriter, r *http.Request) {
+	info, ok := s.byClientOrderId(r.PathValue("cid"))
+	if !ok {
+		s.writeError(w, notFound(r.PathValue("cid")))
+		return
+	}
+	s.ok(w, qe.GetMasterOrderDetailReply{MasterOrder: toV1Order(&info)})
+}
+
+func (s *Server) handleActionV1(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	update := map[string]interface{}{}
+	for k := range q {
+		v := q.Get(k)
+		switch {
+		case boolFields[k]:
+			update[k] = v == "true"
+		case k == "executionDurationSeconds":
+			update[k], _ = strconv.ParseInt(v, 10, 64)
+		case k == "executionDuration":
+			minutes, _ := strconv.ParseInt(v, 10, 64)
+			update["executionDurationSeconds"] = minutes * 60
+		default:
+			update[k] = v
+		}
+	}
+	if e := s.act(r.PathValue("id"), r.PathValue("action"), update); e != nil {
+		s.writeError(w, e)
+		return
+	}
+	s.ok(w, qe.CancelMasterOrderReply{Success: true, Message: "success"})
+}
+
+func (s *Server) handleFillsV1(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	fills := s.listFills(q)
+	total := len(fills)
+	page, pageSize, fills := paginate(q, fills)
+	items := make([]qe.OrderFillInfo, len(fills))
+	for i, f := range fills {
+		items[i] = qe.OrderFillInfo{
+			Id:               f.Id,
+			OrderCreatedTime: f.OrderCreatedTime,
+			MasterOrderId:    f.MasterOrderId,
+			Exchange:         f.Exchange,
+			Category:         string(f.Category),
+			Symbol:           f.Symbol,
+			Side:             string(f.Side),
+			FilledValue:      parseFloat(f.FilledNotional.String()),
+			FilledQuantity:   parseFloat(f.FilledQuantity.String()),
+			AvgPrice:         parseFloat(f.AveragePrice.String()),
+			Price:            parseFloat(f.Price.String()),
+			Status:           string(f.Status),
+			RejectReason:     f.RejectReason,
+			Base:             f.BaseCurrency,
+			Quote:            f.QuoteCurrency,
+			Type:             f.OrderType,
+			OrderId:          f.OrderId,
+			Quantity:         parseFloat(f.Quantity.String()),
+			CreatedAt:        f.CreatedAt,
+			UpdatedAt:        f.UpdatedAt,
+		}
+	}
+	s.ok(w, qe.GetOrderFillsReply{Items: items, Total: strconv.Itoa(total), Page: int32(page), PageSize: int32(pageSize)})
+}
+
+func (s *Server) handleTCAV1(w http.ResponseWriter, r *http.Request) {
+	rows := s.listTCA(r.URL.Query())
+	out := make([]*algorithm_dto.TCAAnalysisResponse, len(rows))
+	for i, t := range rows {
+		out[i] = &algorithm_dto.TCAAnalysisResponse{
+			MasterOrderID:           t.MasterOrderId,
+			StartTime:               t.StartTime,
+			EndTime:                 t.EndTime,
+			FinishedTime:            t.FinishedTime,
+			Strategy:                t.Strategy,
+			Category:                t.Category,
+			Date:                    t.Date,
+			MasterOrderQty:          t.OrderQuantity,
+			MasterOrderNotional:     t.OrderNotional,
+			ArrivalPrice:            t.ArrivalPrice,
+			ExcutedRate:             t.ExecutionRate,
+			FillQty:                 t.FilledQuantity,
+			TakeFillNotional:        t.TakerFilledNotional,
+			MakeFillNotional:        t.MakerFilledNotional,
+			FillNotional:            t.FilledNotional,
+			MakerRate:               t.MakerRate,
+			ChildOrderCnt:           int(t.ChildOrderCount),
+			AverageFillPrice:        t.AverageFillPrice,
+			Slippage:                t.Slippage,
+			SlippagePct:             t.SlippagePct,
+			SlippagePctFartouch:     t.SlippagePctFartouch,
+			TwapSlippagePct:         t.TwapSlippagePct,
+			VwapSlippagePct:         t.VwapSlippagePct,
+			Spread:                  t.Spread,
+			TwapSlippagePctFartouch: t.TwapSlippagePctFartouch,
+			VwapSlippagePctFartouch: t.VwapSlippagePctFartouch,
+			IntervalReturn:          t.IntervalReturn,
+			ParticipationRate:       t.ParticipationRate,
+			FeeSavingPct:            t.FeeSavingPct,
+		}
+	}
+	s.ok(w, out)
+}
+
+func toV1Order(i *qe.MasterOrderV2Info) qe.MasterOrderInfo {
+	o := qe.MasterOrderInfo{
+		MasterOrderId:  i.MasterOrderId,
+		Algorithm:      string(i.Algorithm),
+		Exchange:       i.Exchange,
+		Symbol:         i.Symbol,
+		MarketType:     string(i.MarketType),
+		Side:           string(i.Side),
+		Status:         string(i.Status),
+		CreatedAt:      i.CreatedAt,
+		UpdatedAt:      i.UpdatedAt,
+		Notes:          i.Notes,
+		ClientId:       i.ClientOrderId,
+		Category:       string(i.Category),
+		Reason:         i.RejectReason,
+		TradingAccount: i.TradingAccount,
+		TotalQuantity:  parseFloatPtr(i.TotalQuantity),
+		OrderNotional:  parseFloatPtr(i.OrderNotional),
+		FilledQuantity: parseFloatPtr(i.CumFilledQty),
+		FilledAmount:   parseFloatPtr(i.CumFilledNotional),
+		AveragePrice:   parseFloatPtr(i.AvgFilledPrice),
+		MakerRate:      parseFloatPtr(i.MakerRate),
+		UpTolerance:    derefString(i.UpTolerance),
+		LowTolerance:   derefString(i.LowTolerance),
+		MarginType:     derefString(i.MarginType),
+	}
+	if i.ExecutionDurationSeconds != nil {
+		secs := int32(i.ExecutionDurationSeconds.Int64())
+		o.ExecutionDurationSeconds = &secs
+		o.ExecutionDuration = secs / 60
+	}
+	if i.ReduceOnly != nil {
+		o.ReduceOnly = *i.ReduceOnly
+	}
+	if i.MustComplete != nil {
+		o.MustComplete = *i.MustComplete
+	}
+	if i.TailOrderProtection != nil {
+		o.TailOrderProtection = *i.TailOrderProtection
+	}
+	if o.TotalQuantity > 0 {
+		o.CompletionProgress = o.FilledQuantity / o.TotalQuantity
+	} else if o.OrderNotional > 0 {
+		o.CompletionProgress = o.FilledAmount / o.OrderNotional
+	}
+	return o
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+func parseFloatPtr(s *string) float64 {
+	if s == nil {
+		return 0
+	}
+	return parseFloat(*s)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
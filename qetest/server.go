@@ -0,0 +1,414 @@
+// Package qetest provides an in-process fake of the QE strategy-api for
+// integration tests.
+//
+// The fake serves `/ping`, `/timestamp`, `/pub/trading-pairs`, the V1 and V2
+// master-order / order-fill / TCA / listen-key endpoints and the `/api/ws`
+// and `/api/ws/v2` WebSocket streams on an httptest.Server. Signed requests
+// are verified with the same rules as the backend: the API key header must
+// match, the timestamp must be inside recvWindow, and `signature` must be
+// the HMAC-SHA256 of the merged query + JSON body parameters, or their
+// Ed25519 / RSA signature for keys registered with AddPublicKey. Master orders
+// live in memory, and tests can inject faults, latency and WS pushes.
+//
+//	srv := qetest.NewServer("key", "secret")
+//	defer srv.Close()
+//	client := srv.Client()
+//	reply, err := client.NewCreateMasterOrderV2Service()....Do(ctx)
+//	srv.AddFill(qe.OrderFillV2Info{MasterOrderId: reply.MasterOrderId, ...})
+package qetest
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+// DefaultRecvWindow is the timestamp tolerance applied when a signed
+// request carries no recvWindow parameter.
+const DefaultRecvWindow = 5 * time.Second
+
+// Server is a running fake strategy-api.
+type Server struct {
+	*httptest.Server
+
+	apiKey    string
+	secretKey string
+
+	mu         sync.Mutex
+	keys       map[string]verifier // API key -> signature check
+	now        func() time.Time
+	recvWindow time.Duration
+	latency    time.Duration
+	latencyFn  func(*http.Request) time.Duration
+	faults     []*Fault
+	requests   []Request
+	traceSeq   int
+
+	orderSeq   int
+	orders     map[string]*order
+	orderIds   []string
+	listenKeys map[string]listenKey
+	tca        []*qe.TCAAnalysisV2Info
+	pairs      []*qe.TradingPairs
+
+	ws *hub
+}
+
+// Request is one request received by the fake, after signature checks.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Fault makes matching requests fail instead of reaching the fake's
+// handlers.
+type Fault struct {
+	// Method and Path select requests; empty matches any. Path matches by
+	// prefix, e.g. "/user/trading/v2/master-orders".
+	Method string
+	Path   string
+	// HTTPStatus is the response status (default 500). With 200 the error is
+	// returned as a business error inside the success envelope.
+	HTTPStatus int
+	// Code is the envelope `code` (default HTTPStatus, or 500 when
+	// HTTPStatus is 200).
+	Code    int
+	Reason  string
+	Message string
+	// Header is added to the response, e.g. Retry-After.
+	Header http.Header
+	// Delay is applied before responding, on top of Server latency.
+	Delay time.Duration
+	// Times limits how many requests fail; 0 means until ClearFaults.
+	Times int
+}
+
+// NewServer starts a fake that accepts requests signed with apiKey and
+// secretKey. It is seeded with a few Binance trading pairs.
+func NewServer(apiKey, secretKey string) *Server {
+	s := &Server{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		now:        time.Now,
+		recvWindow: DefaultRecvWindow,
+		orders:     map[string]*order{},
+		keys:       map[string]verifier{apiKey: hmacVerifier(secretKey)},
+		listenKeys: map[string]listenKey{},
+		pairs:      defaultTradingPairs(),
+		ws:         newHub(),
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Close disconnects WebSocket clients and shuts the server down.
+func (s *Server) Close() {
+	s.ws.closeAll()
+	s.Server.Close()
+}
+
+// Client returns a client pointed at the fake with matching credentials.
+func (s *Server) Client() *qe.Client {
+	return qe.NewClient(s.apiKey, s.secretKey, s.URL)
+}
+
+// WSHost returns the host to pass to NewWebSocketService.
+func (s *Server) WSHost() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// AddCredentials makes the fake also accept requests signed with apiKey
+// and secretKey, e.g. to rotate credentials.
+func (s *Server) AddCredentials(apiKey, secretKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[apiKey] = hmacVerifier(secretKey)
+}
+
+// AddPublicKey makes the fake accept requests for apiKey signed with the
+// private key of pub, an ed25519.PublicKey or *rsa.PublicKey, as sent by
+// qe.Ed25519Signer and qe.RSASigner.
+func (s *Server) AddPublicKey(apiKey string, pub crypto.PublicKey) error {
+	v, err := publicKeyVerifier(pub)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[apiKey] = v
+	return nil
+}
+
+// RevokeCredentials stops accepting apiKey. Listen keys created with it
+// become invalid and their WebSocket connections are dropped.
+func (s *Server) RevokeCredentials(apiKey string) {
+	s.mu.Lock()
+	delete(s.keys, apiKey)
+	for k, lk := range s.listenKeys {
+		if lk.apiKey == apiKey {
+			delete(s.listenKeys, k)
+		}
+	}
+	s.mu.Unlock()
+	s.ws.closeWhere(func(c *wsConn) bool { return c.apiKey == apiKey })
+}
+
+// SetClock replaces the server clock used for timestamp checks and
+// generated times.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetRecvWindow changes the default timestamp tolerance.
+func (s *Server) SetRecvWindow(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recvWindow = d
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// SetLatencyFunc delays each response by f(r) on top of SetLatency, e.g. to
+// make only some requests slow. Pass nil to remove it.
+func (s *Server) SetLatencyFunc(f func(r *http.Request) time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencyFn = f
+}
+
+// InjectFault registers a fault; the first matching fault wins.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.HTTPStatus == 0 {
+		f.HTTPStatus = http.StatusInternalServerError
+	}
+	if f.Code == 0 {
+		f.Code = f.HTTPStatus
+		if f.HTTPStatus == http.StatusOK {
+			f.Code = http.StatusInternalServerError
+		}
+	}
+	if f.Message == "" {
+		f.Message = "injected fault"
+	}
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests served so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	public := func(pattern string, h http.HandlerFunc) { mux.Handle(pattern, s.wrap(false, h)) }
+	signed := func(pattern string, h http.HandlerFunc) { mux.Handle(pattern, s.wrap(true, h)) }
+
+	public("GET /ping", func(w http.ResponseWriter, r *http.Request) { s.ok(w, nil) })
+	public("GET /timestamp", s.handleTimestamp)
+	public("GET /pub/trading-pairs", s.handleTradingPairs)
+
+	signed("GET /user/trading/master-orders", s.handleListV1)
+	signed("POST /user/trading/master-orders", s.handleCreateV1)
+	signed("GET /user/trading/master-orders/{id}", s.handleDetailV1)
+	signed("GET /user/trading/master-orders/by-client-order-id/{cid}", s.handleDetailByClientIdV1)
+	signed("PUT /user/trading/master-orders/{id}/{action}", s.handleActionV1)
+	signed("GET /user/trading/order-fills", s.handleFillsV1)
+	signed("GET /user/trading/tca-analysis", s.handleTCAV1)
+	signed("POST /user/trading/listen-key", s.handleListenKey)
+
+	signed("GET /user/trading/v2/master-orders", s.handleListV2)
+	signed("POST /user/trading/v2/master-orders", s.handleCreateV2)
+	signed("GET /user/trading/v2/master-orders/{id}", s.handleDetailV2)
+	signed("GET /user/trading/v2/master-orders/by-client-order-id/{cid}", s.handleDetailByClientIdV2)
+	signed("PUT /user/trading/v2/master-orders/{id}/{action}", s.handleActionV2)
+	signed("PUT /user/trading/v2/master-orders/batch-cancel", s.handleBatchCancelV2)
+	signed("GET /user/trading/v2/order-fills", s.handleFillsV2)
+	signed("GET /user/trading/v2/tca-analysis", s.handleTCAV2)
+	signed("POST /user/trading/v2/listen-key", s.handleListenKey)
+
+	mux.HandleFunc("GET /api/ws", s.handleWS)
+	mux.HandleFunc("GET /api/ws/v2", s.handleWS)
+	return mux
+}
+
+// wrap applies latency, faults, authentication and request recording.
+func (s *Server) wrap(signed bool, h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.fail(w, http.StatusBadRequest, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		delay, latencyFn := s.latency, s.latencyFn
+		fault := s.matchFault(r)
+		if fault != nil {
+			delay += fault.Delay
+		}
+		s.mu.Unlock()
+		if latencyFn != nil {
+			delay += latencyFn(r)
+		}
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault != nil {
+			for k, v := range fault.Header {
+				w.Header()[k] = v
+			}
+			s.fail(w, fault.HTTPStatus, fault.Code, fault.Reason, fault.Message)
+			return
+		}
+
+		if signed {
+			if status, reason, msg := s.verify(r, body); status != 0 {
+				s.fail(w, status, status, reason, msg)
+				return
+			}
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header.Clone(), Body: body})
+		s.mu.Unlock()
+		h(w, r)
+	})
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if f.Path != "" && !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) envelope(code int, reason string, message interface{}) handlers.APISuccess {
+	s.mu.Lock()
+	s.traceSeq++
+	trace := fmt.Sprintf("qetest-%d", s.traceSeq)
+	now := s.now()
+	s.mu.Unlock()
+	return handlers.APISuccess{
+		Code:       code,
+		Reason:     reason,
+		Message:    message,
+		TraceId:    trace,
+		ServerTime: now.UnixMilli(),
+	}
+}
+
+func (s *Server) ok(w http.ResponseWriter, message interface{}) {
+	s.writeJSON(w, http.StatusOK, s.envelope(http.StatusOK, "", message))
+}
+
+func (s *Server) fail(w http.ResponseWriter, httpStatus, code int, reason, message string) {
+	s.writeJSON(w, httpStatus, s.envelope(code, reason, message))
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handleTimestamp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	now := s.now()
+	s.mu.Unlock()
+	s.ok(w, qe.TimestampMessage{ServerTimeMilli: now.UnixMilli()})
+}
+
+// SetTradingPairs replaces the pairs served by `/pub/trading-pairs`.
+func (s *Server) SetTradingPairs(pairs ...*qe.TradingPairs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pairs = pairs
+}
+
+func (s *Server) handleTradingPairs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	var items []*qe.TradingPairs
+	for _, p := range s.pairs {
+		if v := q.Get("exchange"); v != "" && !strings.EqualFold(v, p.Exchange) {
+			continue
+		}
+		if v := q.Get("marketType"); v != "" && !strings.EqualFold(v, p.MarketType) {
+			continue
+		}
+		items = append(items, p)
+	}
+	s.mu.Unlock()
+	total := len(items)
+	page, pageSize, items := paginate(q, items)
+	s.ok(w, qe.TradingPairMessage{Items: items, Page: page, PageSize: pageSize, Total: fmt.Sprint(total)})
+}
+
+func defaultTradingPairs() []*qe.TradingPairs {
+	var pairs []*qe.TradingPairs
+	id := 0
+	for _, market := range []string{"SPOT", "PERP"} {
+		for _, base := range []string{"BTC", "ETH", "SOL"} {
+			id++
+			pairs = append(pairs, &qe.TradingPairs{
+				Id:         id,
+				Exchange:   "Binance",
+				MarketType: market,
+				Symbol:     base + "USDT",
+				BaseAsset:  base,
+				QuoteAsset: "USDT",
+				Status:     "TRADING",
+			})
+		}
+	}
+	return pairs
+}
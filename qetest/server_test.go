@@ -0,0 +1,189 @@
+package qetest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/handlers"
+)
+
+func createTWAP(t *testing.T, c *qe.Client, clientOrderId string) *qe.CreateMasterOrderV2Reply {
+	t.Helper()
+	reply, err := c.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity("2").
+		ClientOrderId(clientOrderId).
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	return reply
+}
+
+func TestServerV2OrderLifecycle(t *testing.T) {
+	srv := NewServer("key", "secret")
+	defer srv.Close()
+	c := srv.Client()
+	ctx := context.Background()
+
+	if err := c.NewPingServer().Do(ctx); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+	pairs, err := c.NewTradingPairsService().MarketType(trading_enums.TradingPairMarketType("PERP")).Do(ctx)
+	if err != nil || pairs.Total != "3" {
+		t.Fatalf("trading pairs = %#v, %v", pairs, err)
+	}
+
+	created := createTWAP(t, c, "cid-1")
+	if created.Status != "NEW" || created.ClientOrderId != "cid-1" {
+		t.Fatalf("create reply = %#v", created)
+	}
+	if _, err := c.NewCreateMasterOrderV2Service().ApiKeyId("binding-id").Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).Symbol("BTCUSDT").Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).ExecutionDurationSeconds(600).TotalQuantity("1").
+		ClientOrderId("cid-1").Do(ctx); err == nil || !strings.Contains(err.Error(), "DUPLICATE_CLIENT_ORDER_ID") {
+		t.Fatalf("duplicate clientOrderId err = %v", err)
+	}
+
+	id := created.MasterOrderId
+	if _, err := c.NewPauseMasterOrderV2Service().MasterOrderId(id).Do(ctx); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if _, err := c.NewPauseMasterOrderV2Service().MasterOrderId(id).Do(ctx); err == nil {
+		t.Fatal("pausing a paused order should fail")
+	}
+	if _, err := c.NewResumeMasterOrderV2Service().MasterOrderId(id).Do(ctx); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if _, err := c.NewUpdateMasterOrderParamsV2Service().MasterOrderId(id).PovLimit("0.2").Do(ctx); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := srv.AddFill(qe.OrderFillV2Info{MasterOrderId: id, FilledQuantity: "0.5", FilledNotional: "50000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	detail, err := c.NewGetMasterOrderDetailV2Service().MasterOrderId(id).Do(ctx)
+	if err != nil {
+		t.Fatalf("detail: %v", err)
+	}
+	mo := detail.MasterOrder
+	if mo.Status != "PROCESSING" || *mo.PovLimit != "0.2" || *mo.CumFilledQty != "0.5" || *mo.AvgFilledPrice != "100000" {
+		t.Fatalf("detail = %#v", mo)
+	}
+	fills, err := c.NewGetOrderFillsV2Service().MasterOrderId(id).Do(ctx)
+	if err != nil || fills.Total != 1 || fills.Items[0].Symbol != "BTCUSDT" {
+		t.Fatalf("fills = %#v, %v", fills, err)
+	}
+	v1, err := c.NewGetMasterOrderDetailService().MasterOrderId(id).Do(ctx)
+	if err != nil || v1.MasterOrder.FilledQuantity != 0.5 || v1.MasterOrder.CompletionProgress != 0.25 {
+		t.Fatalf("v1 detail = %#v, %v", v1, err)
+	}
+
+	batch, err := c.NewBatchCancelMasterOrdersV2Service().MasterOrderIds([]string{id, "missing"}).Do(ctx)
+	if err != nil || batch.SuccessCount != 1 || len(batch.FailedOrders) != 1 {
+		t.Fatalf("batch cancel = %#v, %v", batch, err)
+	}
+	running, err := c.NewGetMasterOrdersV2Service().Status(qe.MasterOrderStatusV2New).Do(ctx)
+	if err != nil || running.Total != 0 {
+		t.Fatalf("running orders = %#v, %v", running, err)
+	}
+}
+
+func TestServerRejectsBadSignaturesAndInjectsFaults(t *testing.T) {
+	srv := NewServer("key", "secret")
+	defer srv.Close()
+	ctx := context.Background()
+
+	wrongSecret := qe.NewClient("key", "other", srv.URL)
+	_, err := wrongSecret.NewGetMasterOrdersV2Service().Do(ctx)
+	var apiErr *handlers.APIError
+	if !errors.As(err, &apiErr) || apiErr.Reason != "INVALID_SIGNATURE" {
+		t.Fatalf("wrong secret err = %v", err)
+	}
+
+	stale := srv.Client()
+	stale.TimeOffset = int64(time.Minute / time.Millisecond)
+	if _, err := stale.NewCreateListenKeyV2Service().Do(ctx); err == nil || !strings.Contains(err.Error(), "recvWindow") {
+		t.Fatalf("stale timestamp err = %v", err)
+	}
+
+	c := srv.Client()
+	srv.InjectFault(Fault{Method: "POST", Path: "/user/trading/v2/master-orders", HTTPStatus: 503, Reason: "UNAVAILABLE", Times: 1})
+	if _, err := c.NewCreateMasterOrderV2Service().ApiKeyId("b").Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).Symbol("BTCUSDT").Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).ExecutionDurationSeconds(600).TotalQuantity("1").
+		Do(ctx); err == nil || !strings.Contains(err.Error(), "UNAVAILABLE") {
+		t.Fatalf("fault err = %v", err)
+	}
+	createTWAP(t, c, "after-fault")
+
+	srv.SetLatency(200 * time.Millisecond)
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.NewTimestampService().Do(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("latency err = %v", err)
+	}
+}
+
+func TestServerPushesOverWebSocket(t *testing.T) {
+	srv := NewServer("key", "secret")
+	defer srv.Close()
+	c := srv.Client()
+
+	lk, err := c.NewCreateListenKeyV2Service().Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	details := make(chan *qe.WsMasterOrderDetail, 8)
+	fills := make(chan *qe.WsOrderFillDetail, 8)
+	ws := c.NewWebSocketService(srv.WSHost()).SetHandlers(&qe.WebSocketEventHandlers{
+		OnMasterOrderDetail: func(m *qe.WsMasterOrderDetail) error { details <- m; return nil },
+		OnOrderFillDetail:   func(f *qe.WsOrderFillDetail) error { fills <- f; return nil },
+	})
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := srv.WaitForWSClients(1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	created := createTWAP(t, c, "ws-1")
+	if err := srv.AddFill(qe.OrderFillV2Info{MasterOrderId: created.MasterOrderId, FilledQuantity: "1", FilledNotional: "100"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.SetStatus(created.MasterOrderId, qe.MasterOrderStatusV2Completed); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case f := <-fills:
+		if f.MasterOrderID != created.MasterOrderId || f.FilledQuantity != "1" {
+			t.Fatalf("fill push = %#v", f)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no order_data push")
+	}
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case d := <-details:
+			if d.Status == "COMPLETED" && d.FinishedMs > 0 {
+				return
+			}
+		case <-deadline:
+			t.Fatal("no COMPLETED master_data push")
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package qetest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// verify checks a signed request the way the backend middleware does and
+// returns a non-zero HTTP status on failure.
+func (s *Server) verify(r *http.Request, body []byte) (status int, reason, message string) {
+	s.mu.Lock()
+	check, ok := s.keys[r.Header.Get("X-MBX-APIKEY")]
+	s.mu.Unlock()
+	if !ok {
+		return http.StatusUnauthorized, "INVALID_API_KEY", "invalid api key"
+	}
+	q := r.URL.Query()
+	signature := q.Get("signature")
+	if signature == "" {
+		return http.StatusUnauthorized, "MISSING_SIGNATURE", "signature is required"
+	}
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, "INVALID_TIMESTAMP", "timestamp is required"
+	}
+
+	s.mu.Lock()
+	window := s.recvWindow
+	now := s.now()
+	s.mu.Unlock()
+	if rw := q.Get("recvWindow"); rw != "" {
+		ms, err := strconv.ParseInt(rw, 10, 64)
+		if err != nil {
+			return http.StatusBadRequest, "INVALID_RECV_WINDOW", "recvWindow must be an integer"
+		}
+		window = time.Duration(ms) * time.Millisecond
+	}
+	if d := now.Sub(time.UnixMilli(ts)); d > window || d < -window {
+		return http.StatusBadRequest, "TIMESTAMP_OUTSIDE_RECV_WINDOW", fmt.Sprintf("timestamp %d outside recvWindow", ts)
+	}
+
+	payload, err := signPayload(r, body)
+	if err != nil {
+		return http.StatusBadRequest, "BAD_REQUEST", err.Error()
+	}
+	if !check([]byte(payload), signature) {
+		return http.StatusUnauthorized, "INVALID_SIGNATURE", "signature mismatch"
+	}
+	return 0, "", ""
+}
+
+// verifier reports whether signature is valid for payload under one API
+// key's signing scheme.
+type verifier func(payload []byte, signature string) bool
+
+// hmacVerifier checks hex HMAC-SHA256 signatures made with secret.
+func hmacVerifier(secret string) verifier {
+	return func(payload []byte, signature string) bool {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		return hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(strings.ToLower(signature)))
+	}
+}
+
+// publicKeyVerifier checks the base64 Ed25519 or RSASSA-PKCS1-v1_5
+// (SHA-256) signatures of the asymmetric signers.
+func publicKeyVerifier(pub crypto.PublicKey) (verifier, error) {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return func(payload []byte, signature string) bool {
+			sig, err := base64.StdEncoding.DecodeString(signature)
+			return err == nil && ed25519.Verify(key, payload, sig)
+		}, nil
+	case *rsa.PublicKey:
+		return func(payload []byte, signature string) bool {
+			sig, err := base64.StdEncoding.DecodeString(signature)
+			if err != nil {
+				return false
+			}
+			digest := sha256.Sum256(payload)
+			return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("qetest: unsupported public key type %T", pub)
+	}
+}
+
+// signPayload rebuilds the string the client signed. Query parameters
+// (minus `signature`) and top-level JSON body fields are merged into one
+// url.Values and encoded; JSON numbers keep their literal text, arrays and
+// objects are re-marshalled. Form bodies keep the V1 rule of appending the
+// raw form string to the encoded query.
+func signPayload(r *http.Request, body []byte) (string, error) {
+	merged := url.Values{}
+	for k, vs := range r.URL.Query() {
+		if strings.EqualFold(k, "signature") {
+			continue
+		}
+		for _, v := range vs {
+			merged.Add(k, v)
+		}
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return merged.Encode(), nil
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return merged.Encode() + string(body), nil
+	}
+
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return "", fmt.Errorf("decode body: %w", err)
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, "signature") {
+			continue
+		}
+		if sv, ok := scalarString(v); ok {
+			merged.Add(k, sv)
+			continue
+		}
+		if b, err := json.Marshal(v); err == nil {
+			merged.Add(k, string(b))
+		}
+	}
+	return merged.Encode(), nil
+}
+
+func scalarString(v interface{}) (string, bool) {
+	switch tv := v.(type) {
+	case string:
+		return tv, true
+	case json.Number:
+		return tv.String(), true
+	case float64:
+		if math.IsNaN(tv) || math.IsInf(tv, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(tv, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(tv), true
+	case nil:
+		return fmt.Sprint(tv), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,166 @@
+package qetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/gorilla/websocket"
+)
+
+type hub struct {
+	mu    sync.Mutex
+	conns map[*wsConn]struct{}
+	seq   int
+}
+
+type wsConn struct {
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	apiKey string
+}
+
+// listenKey is an issued listen key and the API key that created it.
+type listenKey struct {
+	expire time.Time
+	apiKey string
+}
+
+func newHub() *hub {
+	return &hub{conns: map[*wsConn]struct{}{}}
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("listen_key")
+	s.mu.Lock()
+	lk, ok := s.listenKeys[key]
+	valid := ok && s.now().Before(lk.expire)
+	s.mu.Unlock()
+	if !valid {
+		s.fail(w, http.StatusUnauthorized, http.StatusUnauthorized, "INVALID_LISTEN_KEY", "invalid or expired listen_key")
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &wsConn{conn: conn, apiKey: lk.apiKey}
+	s.ws.mu.Lock()
+	s.ws.conns[c] = struct{}{}
+	s.ws.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.ws.mu.Lock()
+			delete(s.ws.conns, c)
+			s.ws.mu.Unlock()
+			conn.Close()
+		}()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// WSClients returns the number of connected WebSocket clients.
+func (s *Server) WSClients() int {
+	s.ws.mu.Lock()
+	defer s.ws.mu.Unlock()
+	return len(s.ws.conns)
+}
+
+// WaitForWSClients blocks until at least n WebSocket clients are connected.
+func (s *Server) WaitForWSClients(n int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for s.WSClients() < n {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %d websocket clients, have %d", n, s.WSClients())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil
+}
+
+// DisconnectWS drops every WebSocket connection, e.g. to exercise client
+// reconnects.
+func (s *Server) DisconnectWS() {
+	s.ws.closeAll()
+}
+
+// Push sends a raw push message to every connected client. data is
+// marshalled to JSON and carried as the envelope's `data` string, like the
+// backend does.
+func (s *Server) Push(msgType qe.ClientMessageType, data interface{}) error {
+	var payload string
+	switch v := data.(type) {
+	case string:
+		payload = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		payload = string(b)
+	}
+	s.ws.mu.Lock()
+	s.ws.seq++
+	msg := qe.ClientPushMessage{Type: msgType, MessageId: fmt.Sprintf("qetest-ws-%d", s.ws.seq), Data: payload}
+	conns := make([]*wsConn, 0, len(s.ws.conns))
+	for c := range s.ws.conns {
+		conns = append(conns, c)
+	}
+	s.ws.mu.Unlock()
+
+	frame, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, c := range conns {
+		c.mu.Lock()
+		err := c.conn.WriteMessage(websocket.TextMessage, frame)
+		c.mu.Unlock()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// PushMasterOrder sends a master_data push for info.
+func (s *Server) PushMasterOrder(info *qe.MasterOrderV2Info) error {
+	return s.Push(qe.ClientMasterDetailType, info)
+}
+
+// PushOrderFill sends an order_data push for fill.
+func (s *Server) PushOrderFill(fill *qe.OrderFillV2Info) error {
+	return s.Push(qe.ClientOrderFillDetailType, fill)
+}
+
+func (h *hub) closeAll() {
+	h.closeWhere(func(*wsConn) bool { return true })
+}
+
+// closeWhere drops the connections drop selects.
+func (h *hub) closeWhere(drop func(*wsConn) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		if !drop(c) {
+			continue
+		}
+		c.mu.Lock()
+		_ = c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(time.Second))
+		_ = c.conn.Close()
+		c.mu.Unlock()
+		delete(h.conns, c)
+	}
+}
@@ -0,0 +1,230 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint prefixes for ReadCache.SetTTL and ReadCache.Invalidate.
+const (
+	TradingPairsEndpoint    = "/pub/trading-pairs"
+	ExchangeApisV2Endpoint  = "/user/exchange/v2/exchange-apis"
+	ExchangeBalanceEndpoint = "/user/exchange-apis/"
+	MasterOrdersV2Endpoint  = "/user/trading/v2/master-orders"
+)
+
+// readCacheSweepSize is the entry count above which storing a response
+// first drops expired entries.
+const readCacheSweepSize = 1024
+
+// ReadCache de-duplicates and caches a Client's GET requests. Identical GETs
+// in flight at the same time share one HTTP request; "identical" means same
+// API key, endpoint and parameters, ignoring timestamp, recvWindow and
+// signature. Successful responses of endpoints with a TTL are then served
+// from memory until it expires. Every caller decodes its own copy, so
+// results can be modified freely.
+//
+// A new ReadCache caches trading pairs for 5 minutes, V2 exchange API
+// bindings for 1 minute and exchange_balance reads for 1 second; other GETs
+// are only coalesced. Call Invalidate after your own order mutations if the
+// next read must see them:
+//
+//	cache := qe.NewReadCache().SetTTL(qe.ExchangeBalanceEndpoint, 500*time.Millisecond)
+//	client.SetReadCache(cache)
+//	// ... create or cancel orders ...
+//	cache.Invalidate(qe.ExchangeBalanceEndpoint)
+//
+// A ReadCache may be shared by several clients.
+type ReadCache struct {
+	mu      sync.Mutex
+	gen     uint64
+	ttls    map[string]time.Duration
+	entries map[string]*cacheEntry
+	flights map[string]*flight
+}
+
+type cacheEntry struct {
+	endpoint string
+	x        *exchange
+	expires  time.Time
+}
+
+// flight is a GET in progress; done is closed once x is set.
+type flight struct {
+	done chan struct{}
+	x    *exchange
+}
+
+// NewReadCache returns a ReadCache with the default TTLs.
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		ttls: map[string]time.Duration{
+			TradingPairsEndpoint:    5 * time.Minute,
+			ExchangeApisV2Endpoint:  time.Minute,
+			ExchangeBalanceEndpoint: time.Second,
+		},
+		entries: map[string]*cacheEntry{},
+		flights: map[string]*flight{},
+	}
+}
+
+// SetTTL caches successful GETs of endpoints starting with prefix for ttl.
+// The longest matching prefix wins; a ttl of 0 turns caching off for the
+// prefix, leaving only coalescing.
+func (rc *ReadCache) SetTTL(prefix string, ttl time.Duration) *ReadCache {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.ttls[prefix] = ttl
+	return rc
+}
+
+// Invalidate drops cached responses of endpoints starting with any of
+// prefixes, or all of them when none is given. Responses of requests in
+// flight are passed to their callers but not cached.
+func (rc *ReadCache) Invalidate(prefixes ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.gen++
+	for key, e := range rc.entries {
+		if len(prefixes) == 0 || hasAnyPrefix(e.endpoint, prefixes) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// SetReadCache routes the client's GET requests through rc. Pass nil to
+// turn it off. Use WithoutReadCache to bypass it for a single call.
+func (c *Client) SetReadCache(rc *ReadCache) *Client {
+	if c.frozen("SetReadCache") {
+		return c
+	}
+	c.cache = rc
+	return c
+}
+
+// WithoutReadCache sends the call even when an identical one is in flight
+// or cached.
+func WithoutReadCache() RequestOption {
+	return func(r *request) {
+		r.noCache = true
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCacheKey identifies a GET regardless of its timestamp and signature.
+func readCacheKey(apiKey, endpoint string, query url.Values) string {
+	q := make(url.Values, len(query))
+	for k, v := range query {
+		if k != timestampKey && k != recvWindowKey && k != signatureKey {
+			q[k] = v
+		}
+	}
+	return apiKey + " " + endpoint + "?" + q.Encode()
+}
+
+func (rc *ReadCache) ttl(endpoint string) time.Duration {
+	var best string
+	var ttl time.Duration
+	for prefix, d := range rc.ttls {
+		if strings.HasPrefix(endpoint, prefix) && len(prefix) >= len(best) {
+			best, ttl = prefix, d
+		}
+	}
+	return ttl
+}
+
+// do returns the cached or in-flight exchange for key, or runs fetch. leader
+// is true when fetch ran for this caller, who must then call store if the
+// response succeeded.
+func (rc *ReadCache) do(ctx context.Context, key string, fetch func() *exchange) (x *exchange, leader bool) {
+	for {
+		rc.mu.Lock()
+		if e, ok := rc.entries[key]; ok {
+			if time.Now().Before(e.expires) {
+				rc.mu.Unlock()
+				return e.x.shared(), false
+			}
+			delete(rc.entries, key)
+		}
+		if f, ok := rc.flights[key]; ok {
+			rc.mu.Unlock()
+			select {
+			case <-f.done:
+			case <-ctx.Done():
+				return &exchange{err: ctx.Err()}, false
+			}
+			// The leader's context is not ours: if it gave up, try again.
+			if ctx.Err() == nil && (errors.Is(f.x.err, context.Canceled) || errors.Is(f.x.err, context.DeadlineExceeded)) {
+				continue
+			}
+			return f.x.shared(), false
+		}
+		f := &flight{done: make(chan struct{})}
+		rc.flights[key] = f
+		gen := rc.gen
+		rc.mu.Unlock()
+
+		f.x = fetch()
+		f.x.gen = gen
+		rc.mu.Lock()
+		delete(rc.flights, key)
+		rc.mu.Unlock()
+		close(f.done)
+		return f.x, true
+	}
+}
+
+// store caches a successful exchange for the TTL of endpoint.
+func (rc *ReadCache) store(key, endpoint string, x *exchange) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	ttl := rc.ttl(endpoint)
+	if ttl <= 0 || x.gen != rc.gen {
+		return
+	}
+	now := time.Now()
+	if len(rc.entries) >= readCacheSweepSize {
+		for k, e := range rc.entries {
+			if !now.Before(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+	}
+	rc.entries[key] = &cacheEntry{endpoint: endpoint, x: x, expires: now.Add(ttl)}
+}
+
+// exchange is the outcome of sending one REST request.
+type exchange struct {
+	start  time.Time
+	status int
+	header http.Header
+	raw    []byte
+	rtt    time.Duration
+	// attempts is the number of HTTP requests made; 0 for a shared result.
+	attempts int
+	err      error
+	// gen is the ReadCache generation the request started in.
+	gen uint64
+	// hedged is set when a hedge copy was sent.
+	hedged bool
+}
+
+// shared returns x as seen by a caller that did not send the request.
+func (x *exchange) shared() *exchange {
+	s := *x
+	s.attempts = 0
+	return &s
+}
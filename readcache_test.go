@@ -0,0 +1,130 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func countRequests(srv *qetest.Server, path string) int {
+	var n int
+	for _, r := range srv.Requests() {
+		if r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestReadCacheCoalescesConcurrentGets(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	srv.SetLatency(100 * time.Millisecond)
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetReadCache(qe.NewReadCache().SetTTL(qe.MasterOrdersV2Endpoint, 0))
+
+	const callers = 8
+	var wg sync.WaitGroup
+	replies := make([]*qe.GetMasterOrdersV2Reply, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			replies[i], errs[i] = c.NewGetMasterOrdersV2Service().Page(1).Do(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: %v", i, err)
+		}
+		if i > 0 && replies[i] == replies[0] {
+			t.Fatal("callers share a reply")
+		}
+	}
+	if n := countRequests(srv, "/user/trading/v2/master-orders"); n != 1 {
+		t.Fatalf("server saw %d requests, want 1", n)
+	}
+
+	// Without a TTL nothing is cached once the flight lands.
+	if _, err := c.NewGetMasterOrdersV2Service().Page(1).Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(srv, "/user/trading/v2/master-orders"); n != 2 {
+		t.Fatalf("server saw %d requests, want 2", n)
+	}
+}
+
+func TestReadCacheTTLAndInvalidate(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	cache := qe.NewReadCache()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).SetReadCache(cache)
+	pairs := func(opts ...qe.RequestOption) *qe.ResponseMeta {
+		t.Helper()
+		var meta qe.ResponseMeta
+		_, err := c.NewTradingPairsService().Exchange(trading_enums.ExchangeBinance).
+			Do(context.Background(), append(opts, qe.WithResponseMeta(&meta))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &meta
+	}
+
+	if meta := pairs(); meta.Attempts != 1 {
+		t.Fatalf("first call meta = %+v", meta)
+	}
+	if meta := pairs(); meta.Attempts != 0 || meta.TraceId == "" {
+		t.Fatalf("cached call meta = %+v", meta)
+	}
+	if n := countRequests(srv, qe.TradingPairsEndpoint); n != 1 {
+		t.Fatalf("server saw %d requests, want 1", n)
+	}
+
+	// Different parameters are a different entry.
+	if _, err := c.NewTradingPairsService().Exchange(trading_enums.ExchangeOKX).Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	pairs(qe.WithoutReadCache())
+	if n := countRequests(srv, qe.TradingPairsEndpoint); n != 3 {
+		t.Fatalf("server saw %d requests, want 3", n)
+	}
+
+	cache.Invalidate(qe.ExchangeBalanceEndpoint)
+	pairs()
+	if n := countRequests(srv, qe.TradingPairsEndpoint); n != 3 {
+		t.Fatalf("unrelated invalidation dropped the entry: %d requests", n)
+	}
+	cache.Invalidate(qe.TradingPairsEndpoint)
+	pairs()
+	if n := countRequests(srv, qe.TradingPairsEndpoint); n != 4 {
+		t.Fatalf("server saw %d requests after Invalidate, want 4", n)
+	}
+}
+
+func TestReadCacheDoesNotCacheErrors(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	srv.InjectFault(qetest.Fault{Method: http.MethodGet, Path: "/user/trading/v2/master-orders", Times: 1})
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetReadCache(qe.NewReadCache().SetTTL(qe.MasterOrdersV2Endpoint, time.Minute))
+
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err == nil {
+		t.Fatal("expected the injected fault")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := countRequests(srv, "/user/trading/v2/master-orders"); n != 1 {
+		t.Fatalf("server saw %d requests, want 1", n)
+	}
+}
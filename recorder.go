@@ -0,0 +1,439 @@
+package qe_connector
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cassette entry kinds.
+const (
+	CassetteKindHTTP = "http"
+	CassetteKindWS   = "ws"
+)
+
+// Redacted replaces scrubbed values in recorded traffic.
+const Redacted = "REDACTED"
+
+// ErrCassetteMiss is returned in replay mode when a request has no recorded
+// response left in the cassette.
+var ErrCassetteMiss = errors.New("no matching cassette entry")
+
+// CassetteEntry is one line of a JSONL cassette: either an HTTP exchange or
+// a received WebSocket frame.
+type CassetteEntry struct {
+	Kind           string      `json:"kind"`
+	Time           time.Time   `json:"time"`
+	Method         string      `json:"method,omitempty"`
+	URL            string      `json:"url"`
+	RequestHeader  http.Header `json:"requestHeader,omitempty"`
+	RequestBody    string      `json:"requestBody,omitempty"`
+	Status         int         `json:"status,omitempty"`
+	ResponseHeader http.Header `json:"responseHeader,omitempty"`
+	ResponseBody   string      `json:"responseBody,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	Frame          string      `json:"frame,omitempty"`
+}
+
+var (
+	defaultRedactKeys = []string{
+		"signature", "listen_key", "listenKey", "secret", "secretKey", "apiSecret",
+		"passphrase", "password", "token", "accessToken",
+	}
+	defaultRedactHeaders = []string{"X-MBX-APIKEY", "Authorization", "Cookie", "Set-Cookie"}
+	// replayIgnoredParams vary between runs and are not part of a request's
+	// identity when matching against a cassette.
+	replayIgnoredParams = map[string]bool{timestampKey: true, recvWindowKey: true, signatureKey: true}
+)
+
+// scrubber removes credentials from recorded traffic.
+type scrubber struct {
+	mu      sync.RWMutex
+	keys    map[string]bool
+	headers []string
+	secrets []string
+}
+
+func newScrubber() *scrubber {
+	s := &scrubber{keys: map[string]bool{}, headers: defaultRedactHeaders}
+	s.addKeys(defaultRedactKeys...)
+	return s
+}
+
+func (s *scrubber) addKeys(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		s.keys[strings.ToLower(k)] = true
+	}
+}
+
+func (s *scrubber) addSecrets(secrets ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range secrets {
+		// Short values would match unrelated text; real keys are far longer.
+		if len(v) >= 8 {
+			s.secrets = append(s.secrets, v)
+		}
+	}
+}
+
+func (s *scrubber) sensitive(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[strings.ToLower(key)]
+}
+
+// text replaces literal secrets anywhere in v.
+func (s *scrubber) text(v string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, secret := range s.secrets {
+		v = strings.ReplaceAll(v, secret, Redacted)
+	}
+	return v
+}
+
+func (s *scrubber) values(v url.Values) url.Values {
+	out := url.Values{}
+	for k, vs := range v {
+		for _, x := range vs {
+			if s.sensitive(k) {
+				x = Redacted
+			}
+			out.Add(k, s.text(x))
+		}
+	}
+	return out
+}
+
+func (s *scrubber) url(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return s.text(raw)
+	}
+	u.RawQuery = s.values(u.Query()).Encode()
+	return s.text(u.String())
+}
+
+func (s *scrubber) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for _, k := range s.headers {
+		if out.Get(k) != "" {
+			out.Set(k, Redacted)
+		}
+	}
+	return out
+}
+
+// body redacts sensitive keys in JSON and form bodies; other content only
+// has literal secrets replaced.
+func (s *scrubber) body(b []byte, contentType string) string {
+	if len(b) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err == nil {
+		if out, err := json.Marshal(s.json(v)); err == nil {
+			return s.text(string(out))
+		}
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(b)); err == nil {
+			return s.values(form).Encode()
+		}
+	}
+	return s.text(string(b))
+}
+
+func (s *scrubber) json(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			if s.sensitive(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = s.json(x)
+		}
+	case []interface{}:
+		for i, x := range t {
+			t[i] = s.json(x)
+		}
+	}
+	return v
+}
+
+// Recorder writes a Client's HTTP exchanges and WebSocket frames to a JSONL
+// cassette. API keys, secrets, signatures and listen keys are scrubbed
+// before anything is written.
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	scrub *scrubber
+	now   func() time.Time
+	err   error
+}
+
+// NewRecorder creates a recorder writing one JSON entry per line to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, scrub: newScrubber(), now: time.Now}
+}
+
+// Redact adds query, form and JSON keys (case-insensitive) whose values
+// are scrubbed, on top of the built-in credential keys.
+func (r *Recorder) Redact(keys ...string) *Recorder {
+	r.scrub.addKeys(keys...)
+	return r
+}
+
+// Err returns the first error met while encoding or writing an entry.
+// Recording never fails the request itself, so check Err once the session
+// is over; after an error the recorder writes nothing more, leaving a
+// cassette that is short rather than torn in the middle.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) write(e *CassetteEntry) {
+	e.Time = r.now()
+	line, err := json.Marshal(e)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err != nil {
+		r.err = fmt.Errorf("encode cassette entry: %w", err)
+		return
+	}
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.err = fmt.Errorf("write cassette entry: %w", err)
+	}
+}
+
+func (r *Recorder) roundTrip(req *http.Request, do doFunc) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	e := &CassetteEntry{
+		Kind:          CassetteKindHTTP,
+		Method:        req.Method,
+		URL:           r.scrub.url(req.URL.String()),
+		RequestHeader: r.scrub.header(req.Header),
+		RequestBody:   r.scrub.body(reqBody, req.Header.Get("Content-Type")),
+	}
+	res, err := do(req)
+	if err != nil {
+		e.Error = r.scrub.text(err.Error())
+		r.write(e)
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	e.Status = res.StatusCode
+	e.ResponseHeader = r.scrub.header(res.Header)
+	e.ResponseBody = r.scrub.body(resBody, res.Header.Get("Content-Type"))
+	r.write(e)
+	return res, nil
+}
+
+func (r *Recorder) recordFrame(wsURL string, frame []byte) {
+	r.write(&CassetteEntry{
+		Kind:  CassetteKindWS,
+		URL:   r.scrub.url(wsURL),
+		Frame: r.scrub.body(frame, ""),
+	})
+}
+
+// Cassette is a recorded session loaded for replay.
+type Cassette struct {
+	mu      sync.Mutex
+	entries []*CassetteEntry
+	used    []bool
+	wsNext  int
+	scrub   *scrubber
+}
+
+// LoadCassette reads a JSONL cassette written by a Recorder.
+func LoadCassette(r io.Reader) (*Cassette, error) {
+	cs := &Cassette{scrub: newScrubber()}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		e := new(CassetteEntry)
+		if err := json.Unmarshal(sc.Bytes(), e); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", line, err)
+		}
+		cs.entries = append(cs.entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	cs.used = make([]bool, len(cs.entries))
+	return cs, nil
+}
+
+// Entries returns the loaded entries in recorded order.
+func (cs *Cassette) Entries() []*CassetteEntry {
+	return cs.entries
+}
+
+// requestKey identifies a request independently of per-run values.
+func (cs *Cassette) requestKey(method, rawURL, body string) (route, full string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL, method + " " + rawURL + " " + body
+	}
+	q := u.Query()
+	for k := range replayIgnoredParams {
+		q.Del(k)
+	}
+	route = method + " " + u.Path
+	return route, route + "?" + q.Encode() + " " + body
+}
+
+// roundTrip serves the first unused entry recorded for the same request,
+// falling back to the first unused entry for the same method and path.
+func (cs *Cassette) roundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	route, full := cs.requestKey(req.Method, cs.scrub.url(req.URL.String()),
+		cs.scrub.body(body, req.Header.Get("Content-Type")))
+
+	cs.mu.Lock()
+	match := -1
+	for i, e := range cs.entries {
+		if cs.used[i] || e.Kind != CassetteKindHTTP {
+			continue
+		}
+		r, f := cs.requestKey(e.Method, e.URL, e.RequestBody)
+		if f == full {
+			match = i
+			break
+		}
+		if r == route && match < 0 {
+			match = i
+		}
+	}
+	if match >= 0 {
+		cs.used[match] = true
+	}
+	cs.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, req.Method, req.URL.Path)
+	}
+	e := cs.entries[match]
+	if e.Error != "" {
+		return nil, errors.New(e.Error)
+	}
+	header := e.ResponseHeader.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(e.ResponseBody)),
+		ContentLength: int64(len(e.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// nextFrames returns the recorded WebSocket frames not yet replayed.
+func (cs *Cassette) nextFrames() [][]byte {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	var frames [][]byte
+	for ; cs.wsNext < len(cs.entries); cs.wsNext++ {
+		if e := cs.entries[cs.wsNext]; e.Kind == CassetteKindWS {
+			frames = append(frames, []byte(e.Frame))
+		}
+	}
+	return frames
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// SetRecorder records every HTTP exchange and received WebSocket frame of
+// this client to r. Pass nil to stop recording.
+func (c *Client) SetRecorder(r *Recorder) *Client {
+	if c.frozen("SetRecorder") {
+		return c
+	}
+	if r != nil {
+		cs := c.credentials()
+		r.scrub.addSecrets(cs.APIKey, cs.SecretKey)
+	}
+	c.recorder = r
+	return c
+}
+
+// SetCassette switches the client to replay mode: HTTP requests are served
+// from cs instead of the network, and WebSocket connections deliver the
+// recorded frames in order without dialing. Pass nil to go back online.
+func (c *Client) SetCassette(cs *Cassette) *Client {
+	if c.frozen("SetCassette") {
+		return c
+	}
+	c.cassette = cs
+	return c
+}
+
+// doHTTP sends req through the replay cassette, the recorder or the
+// underlying HTTP client.
+func (c *Client) doHTTP(req *http.Request) (*http.Response, error) {
+	if c.cassette != nil {
+		return c.cassette.roundTrip(req)
+	}
+	f := c.do
+	if f == nil {
+		f = c.settings().httpClient.Do
+	}
+	if c.recorder != nil {
+		return c.recorder.roundTrip(req, f)
+	}
+	return f(req)
+}
@@ -0,0 +1,140 @@
+package qe_connector_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+const (
+	testAPIKey    = "AKIA-test-api-key-0001"
+	testSecretKey = "s3cr3t-test-secret-0001"
+)
+
+// session creates an order and waits for its fill push; it runs the same
+// way against a live server and against a cassette.
+func session(t *testing.T, c *qe.Client, wsHost string, afterCreate func(id string)) (string, *qe.WsOrderFillDetail) {
+	t.Helper()
+	ctx := context.Background()
+	lk, err := c.NewCreateListenKeyV2Service().Do(ctx)
+	if err != nil {
+		t.Fatalf("listen key: %v", err)
+	}
+	fills := make(chan *qe.WsOrderFillDetail, 1)
+	ws := c.NewWebSocketService(wsHost).SetHandlers(&qe.WebSocketEventHandlers{
+		OnOrderFillDetail: func(f *qe.WsOrderFillDetail) error { fills <- f; return nil },
+	})
+	if err := ws.Connect(lk.ListenKey); err != nil {
+		t.Fatalf("ws connect: %v", err)
+	}
+	defer ws.Close()
+
+	created, err := c.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity("1").
+		Do(ctx)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if afterCreate != nil {
+		afterCreate(created.MasterOrderId)
+	}
+	select {
+	case f := <-fills:
+		return created.MasterOrderId, f
+	case <-time.After(2 * time.Second):
+		t.Fatal("no fill push")
+	}
+	return "", nil
+}
+
+func TestRecordAndReplaySession(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	var cassette bytes.Buffer
+	rec := qe.NewRecorder(&cassette)
+	live := qe.NewClient(testAPIKey, testSecretKey, srv.URL).SetRecorder(rec)
+	liveID, liveFill := session(t, live, srv.WSHost(), func(id string) {
+		if err := srv.WaitForWSClients(1, time.Second); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.AddFill(qe.OrderFillV2Info{MasterOrderId: id, FilledQuantity: "0.4", FilledNotional: "40000"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if err := rec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	recorded := cassette.String()
+	for _, secret := range []string{testAPIKey, testSecretKey, "signature=0", "signature=1"} {
+		if strings.Contains(recorded, secret) {
+			t.Fatalf("cassette leaks %q:\n%s", secret, recorded)
+		}
+	}
+	if !strings.Contains(recorded, `"kind":"ws"`) || !strings.Contains(recorded, "listen_key=REDACTED") {
+		t.Fatalf("cassette misses ws frames:\n%s", recorded)
+	}
+
+	cs, err := qe.LoadCassette(strings.NewReader(recorded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := qe.NewClient("other-key-000000", "other-secret-0000", "http://127.0.0.1:1").SetCassette(cs)
+	replayID, replayFill := session(t, offline, "ws://127.0.0.1:1", nil)
+	if replayID != liveID || replayFill.FilledQuantity != liveFill.FilledQuantity || replayFill.MasterOrderID != liveID {
+		t.Fatalf("replay = %s %#v, live = %s %#v", replayID, replayFill, liveID, liveFill)
+	}
+
+	if _, err := offline.NewGetMasterOrdersV2Service().Do(context.Background()); !errors.Is(err, qe.ErrCassetteMiss) {
+		t.Fatalf("unrecorded request err = %v", err)
+	}
+}
+
+// failingWriter accepts n writes, then fails.
+type failingWriter struct {
+	n      int
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > w.n {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestRecorderReportsWriteErrors(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	w := &failingWriter{n: 1}
+	rec := qe.NewRecorder(w)
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).SetRecorder(rec)
+	for i := 0; i < 3; i++ {
+		if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+			t.Fatalf("request %d: recording must not fail the call: %v", i, err)
+		}
+	}
+	if err := rec.Err(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Err() = %v, want the write error", err)
+	}
+	if w.writes != 2 {
+		t.Fatalf("writes = %d, want recording to stop after the failed write", w.writes)
+	}
+}
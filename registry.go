@@ -0,0 +1,381 @@
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile describes one named account in a ClientRegistry.
+type Profile struct {
+	APIKey string `json:"apiKey" yaml:"apiKey"`
+	// Exactly one of SecretKey, SecretFile (a FileSigner), PrivateKeyFile
+	// (an Ed25519 or RSA PEM key, see LoadPrivateKeySigner) and SignerSocket
+	// (a SocketSigner on a Unix socket) supplies the signature.
+	SecretKey      string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+	SecretFile     string `json:"secretFile,omitempty" yaml:"secretFile,omitempty"`
+	PrivateKeyFile string `json:"privateKeyFile,omitempty" yaml:"privateKeyFile,omitempty"`
+	SignerSocket   string `json:"signerSocket,omitempty" yaml:"signerSocket,omitempty"`
+	// Env selects the default endpoint: "prod" (NewClient, the default) or
+	// "test" (NewTestClient).
+	Env     string `json:"env,omitempty" yaml:"env,omitempty"`
+	BaseURL string `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	WSHost  string `json:"wsHost,omitempty" yaml:"wsHost,omitempty"`
+	// BaseURLs and WSHosts are failover endpoints tried after BaseURL and
+	// WSHost (see Client.SetFailover).
+	BaseURLs   []string `json:"baseURLs,omitempty" yaml:"baseURLs,omitempty"`
+	WSHosts    []string `json:"wsHosts,omitempty" yaml:"wsHosts,omitempty"`
+	TimeOffset int64    `json:"timeOffset,omitempty" yaml:"timeOffset,omitempty"`
+	// RateLimit gives the profile its own limiter of RateLimit requests per
+	// second. Zero means the registry-wide limiter, if any.
+	RateLimit float64 `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+	Burst     int     `json:"burst,omitempty" yaml:"burst,omitempty"`
+}
+
+// RegistryConfig is the ClientRegistry file layout. It is a superset of the
+// qe command's config file, e.g.
+//
+//	default: fund-a
+//	rateLimit: 20   # requests/second shared by profiles without their own
+//	burst: 40
+//	profiles:
+//	  fund-a:
+//	    apiKey: ...
+//	    secretKey: ...
+//	  fund-b:
+//	    apiKey: ...
+//	    secretKey: ...
+//	    rateLimit: 5
+//	  sandbox:
+//	    env: test
+//	    apiKey: ...
+//	    secretKey: ...
+type RegistryConfig struct {
+	Default   string              `json:"default,omitempty" yaml:"default,omitempty"`
+	RateLimit float64             `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+	Burst     int                 `json:"burst,omitempty" yaml:"burst,omitempty"`
+	Profiles  map[string]*Profile `json:"profiles" yaml:"profiles"`
+}
+
+// ClientRegistry holds one Client per named profile. All clients share a
+// single HTTP transport (and so its connection pool); profiles without their
+// own RateLimit also share the registry-wide limiter.
+type ClientRegistry struct {
+	mu        sync.Mutex
+	def       string
+	profiles  map[string]*Profile
+	clients   map[string]*Client
+	base      http.RoundTripper
+	transport http.RoundTripper // base, wrapped by the shared limiter if any
+}
+
+// NewClientRegistry builds a registry from cfg. Clients are created lazily.
+func NewClientRegistry(cfg RegistryConfig) (*ClientRegistry, error) {
+	r := &ClientRegistry{
+		def:      cfg.Default,
+		profiles: make(map[string]*Profile),
+		clients:  make(map[string]*Client),
+		base:     newDefaultHTTPClient().Transport,
+	}
+	r.transport = r.base
+	if cfg.RateLimit > 0 {
+		r.transport = &rateLimitedTransport{base: r.transport, limiter: NewRateLimiter(cfg.RateLimit, cfg.Burst)}
+	}
+	for name, p := range cfg.Profiles {
+		if p == nil {
+			p = new(Profile)
+		}
+		if err := r.Add(name, *p); err != nil {
+			return nil, err
+		}
+	}
+	if r.def != "" {
+		if _, ok := r.profiles[r.def]; !ok {
+			return nil, fmt.Errorf("registry: default profile %q is not defined", r.def)
+		}
+	}
+	return r, nil
+}
+
+// LoadClientRegistry reads a YAML or JSON RegistryConfig from path and then
+// applies the environment: QE_PROFILE_<NAME>_API_KEY, _SECRET_KEY,
+// _SECRET_FILE, _PRIVATE_KEY_FILE, _SIGNER_SOCKET, _ENV, _BASE_URL and
+// _WS_HOST override or define profile <name>, where <NAME> is the profile
+// name upper-cased with '-' and '.' replaced by '_'. A profile
+// defined only through the environment is named by the lower-cased <NAME>.
+// An empty path skips the file.
+func LoadClientRegistry(path string) (*ClientRegistry, error) {
+	var cfg RegistryConfig
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("registry: %w", err)
+		}
+		// YAML is a superset of JSON, so one decoder covers both.
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("registry %s: %w", path, err)
+		}
+	}
+	applyProfileEnv(&cfg, os.Environ())
+	return NewClientRegistry(cfg)
+}
+
+const profileEnvPrefix = "QE_PROFILE_"
+
+var profileEnvFields = []struct {
+	suffix string
+	secret bool
+	field  func(*Profile) *string
+}{
+	{"_API_KEY", false, func(p *Profile) *string { return &p.APIKey }},
+	{"_SECRET_KEY", true, func(p *Profile) *string { return &p.SecretKey }},
+	{"_SECRET_FILE", true, func(p *Profile) *string { return &p.SecretFile }},
+	{"_PRIVATE_KEY_FILE", true, func(p *Profile) *string { return &p.PrivateKeyFile }},
+	{"_SIGNER_SOCKET", true, func(p *Profile) *string { return &p.SignerSocket }},
+	{"_ENV", false, func(p *Profile) *string { return &p.Env }},
+	{"_BASE_URL", false, func(p *Profile) *string { return &p.BaseURL }},
+	{"_WS_HOST", false, func(p *Profile) *string { return &p.WSHost }},
+}
+
+func profileEnvName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+func applyProfileEnv(cfg *RegistryConfig, environ []string) {
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*Profile)
+	}
+	byEnvName := make(map[string]string, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		byEnvName[profileEnvName(name)] = name
+	}
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, profileEnvPrefix) || value == "" {
+			continue
+		}
+		key = strings.TrimPrefix(key, profileEnvPrefix)
+		for _, f := range profileEnvFields {
+			envName, ok := strings.CutSuffix(key, f.suffix)
+			if !ok || envName == "" {
+				continue
+			}
+			name, ok := byEnvName[envName]
+			if !ok {
+				name = strings.ToLower(envName)
+				byEnvName[envName] = name
+			}
+			p := cfg.Profiles[name]
+			if p == nil {
+				p = new(Profile)
+				cfg.Profiles[name] = p
+			}
+			if f.secret {
+				// A secret from the environment replaces one from the file.
+				p.SecretKey, p.SecretFile, p.PrivateKeyFile, p.SignerSocket = "", "", "", ""
+			}
+			*f.field(p) = value
+			break
+		}
+	}
+}
+
+// Add registers profile p under name, replacing any existing profile and
+// dropping its cached client.
+func (r *ClientRegistry) Add(name string, p Profile) error {
+	if name == "" {
+		return errors.New("registry: profile name is required")
+	}
+	if p.APIKey == "" {
+		return fmt.Errorf("registry: profile %q: apiKey is required", name)
+	}
+	secrets := 0
+	for _, v := range []string{p.SecretKey, p.SecretFile, p.PrivateKeyFile, p.SignerSocket} {
+		if v != "" {
+			secrets++
+		}
+	}
+	if secrets != 1 {
+		return fmt.Errorf("registry: profile %q: exactly one of secretKey, secretFile, privateKeyFile and signerSocket is required", name)
+	}
+	switch p.Env {
+	case "", "prod", "test":
+	default:
+		return fmt.Errorf("registry: profile %q: env must be prod or test, got %q", name, p.Env)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[name] = &p
+	delete(r.clients, name)
+	return nil
+}
+
+// Names returns the profile names in sorted order.
+func (r *ClientRegistry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.profiles))
+	for name := range r.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns a copy of the named profile.
+func (r *ClientRegistry) Profile(name string) (Profile, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.profiles[name]
+	if !ok {
+		return Profile{}, false
+	}
+	return *p, true
+}
+
+// Client returns the client for the named profile, creating it on first use.
+func (r *ClientRegistry) Client(name string) (*Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.clients[name]; ok {
+		return c, nil
+	}
+	p, ok := r.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("registry: no profile %q", name)
+	}
+
+	var signer Signer
+	switch {
+	case p.SecretFile != "":
+		signer = NewFileSigner(p.SecretFile)
+	case p.PrivateKeyFile != "":
+		s, err := LoadPrivateKeySigner(p.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("registry: profile %q: %w", name, err)
+		}
+		signer = s
+	case p.SignerSocket != "":
+		signer = NewSocketSigner("unix", p.SignerSocket)
+	}
+
+	var baseURL []string
+	if p.BaseURL != "" {
+		baseURL = []string{p.BaseURL}
+	}
+	var c *Client
+	if p.Env == "test" {
+		c = NewTestClient(p.APIKey, p.SecretKey, baseURL...)
+	} else {
+		c = NewClient(p.APIKey, p.SecretKey, baseURL...)
+	}
+	c.TimeOffset = p.TimeOffset
+	c.Signer = signer
+	c.wsHost = p.WSHost
+	if len(p.BaseURLs) > 0 || len(p.WSHosts) > 0 {
+		cfg := FailoverConfig{WSHosts: p.WSHosts}
+		if len(p.BaseURLs) > 0 {
+			cfg.BaseURLs = append([]string{c.BaseURL}, p.BaseURLs...)
+		}
+		if p.WSHost != "" && len(p.WSHosts) > 0 {
+			cfg.WSHosts = append([]string{p.WSHost}, p.WSHosts...)
+		}
+		c.SetFailover(cfg)
+	}
+
+	transport := r.transport
+	if p.RateLimit > 0 {
+		transport = &rateLimitedTransport{base: r.base, limiter: NewRateLimiter(p.RateLimit, p.Burst)}
+	}
+	c.HTTPClient = &http.Client{Timeout: c.HTTPClient.Timeout, Transport: transport}
+
+	r.clients[name] = c
+	return c, nil
+}
+
+// Default returns the client of the configured default profile, or of the
+// only profile when there is exactly one.
+func (r *ClientRegistry) Default() (*Client, error) {
+	name := r.def
+	if name == "" {
+		names := r.Names()
+		if len(names) != 1 {
+			return nil, errors.New("registry: no default profile configured")
+		}
+		name = names[0]
+	}
+	return r.Client(name)
+}
+
+// ProfileResult is the outcome of a FanOut call for one profile.
+type ProfileResult[T any] struct {
+	Profile string
+	Value   T
+	Err     error
+}
+
+// FanOut calls fn concurrently for every profile and returns the results in
+// profile name order. A failing profile does not stop the others.
+func FanOut[T any](ctx context.Context, r *ClientRegistry, fn func(ctx context.Context, profile string, c *Client) (T, error)) []ProfileResult[T] {
+	names := r.Names()
+	results := make([]ProfileResult[T], len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		results[i].Profile = name
+		c, err := r.Client(name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(res *ProfileResult[T]) {
+			defer wg.Done()
+			res.Value, res.Err = fn(ctx, res.Profile, c)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// ProfileMasterOrder is a master order tagged with the profile it belongs to.
+type ProfileMasterOrder struct {
+	Profile string `json:"profile"`
+	MasterOrderV2Info
+}
+
+// RunningMasterOrders lists the running master orders of every profile.
+// Orders from profiles that answered are returned even when others failed;
+// the error joins the per-profile failures.
+func (r *ClientRegistry) RunningMasterOrders(ctx context.Context) ([]ProfileMasterOrder, error) {
+	results := FanOut(ctx, r, func(ctx context.Context, _ string, c *Client) ([]MasterOrderV2Info, error) {
+		var items []MasterOrderV2Info
+		for page := int32(1); ; page++ {
+			res, err := c.NewGetMasterOrdersV2Service().Status(MasterOrderStatusV2New).Page(page).PageSize(100).Do(ctx)
+			if err != nil {
+				return items, err
+			}
+			items = append(items, res.Items...)
+			if len(res.Items) == 0 || int32(len(items)) >= res.Total {
+				return items, nil
+			}
+		}
+	})
+	var orders []ProfileMasterOrder
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("profile %s: %w", res.Profile, res.Err))
+		}
+		for _, o := range res.Value {
+			orders = append(orders, ProfileMasterOrder{Profile: res.Profile, MasterOrderV2Info: o})
+		}
+	}
+	return orders, errors.Join(errs...)
+}
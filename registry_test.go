@@ -0,0 +1,175 @@
+package qe_connector_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func createTWAP(t *testing.T, c *qe.Client, symbol string) string {
+	t.Helper()
+	res, err := c.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol(symbol).
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity("1").
+		Do(context.Background())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	return res.MasterOrderId
+}
+
+func TestLoadClientRegistryFileAndEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(`
+default: fund-a
+rateLimit: 10
+profiles:
+  fund-a:
+    apiKey: key-a
+    secretKey: secret-a
+  sandbox:
+    env: test
+    apiKey: key-s
+    secretKey: secret-s
+    timeOffset: 250
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("QE_PROFILE_FUND_A_SECRET_KEY", "secret-a-env")
+	t.Setenv("QE_PROFILE_OPS_API_KEY", "key-ops")
+	t.Setenv("QE_PROFILE_OPS_SECRET_KEY", "secret-ops")
+	t.Setenv("QE_PROFILE_OPS_BASE_URL", "http://ops.invalid")
+
+	reg, err := qe.LoadClientRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(reg.Names(), ","); got != "fund-a,ops,sandbox" {
+		t.Fatalf("names = %s", got)
+	}
+
+	def, err := reg.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.SecretKey != "secret-a-env" || def.BaseURL != "https://api.quantumexecute.com" || def.Debug {
+		t.Fatalf("fund-a client = %+v", def)
+	}
+	again, _ := reg.Client("fund-a")
+	if again != def {
+		t.Fatal("clients should be cached per profile")
+	}
+
+	sb, _ := reg.Client("sandbox")
+	if sb.BaseURL != "https://testapi.quantumexecute.com" || sb.TimeOffset != 250 {
+		t.Fatalf("sandbox client = %+v", sb)
+	}
+	if sb.HTTPClient.Transport != def.HTTPClient.Transport {
+		t.Fatal("profiles on the shared limiter should share one transport")
+	}
+	ops, _ := reg.Client("ops")
+	if ops.APIKey != "key-ops" || ops.BaseURL != "http://ops.invalid" {
+		t.Fatalf("ops client = %+v", ops)
+	}
+
+	if _, err := reg.Client("missing"); err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+	if _, err := qe.NewClientRegistry(qe.RegistryConfig{Default: "x"}); err == nil {
+		t.Fatal("expected error for undefined default profile")
+	}
+	if _, err := qe.NewClientRegistry(qe.RegistryConfig{Profiles: map[string]*qe.Profile{"a": {APIKey: "k"}}}); err == nil {
+		t.Fatal("expected error for missing secret")
+	}
+	if _, err := qe.NewClientRegistry(qe.RegistryConfig{Profiles: map[string]*qe.Profile{
+		"a": {APIKey: "k", SecretKey: "s", SecretFile: "/etc/qe/secret"},
+	}}); err == nil {
+		t.Fatal("expected error for two secret sources")
+	}
+
+	t.Setenv("QE_PROFILE_SANDBOX_SIGNER_SOCKET", "/run/qe-signer.sock")
+	reg, err = qe.LoadClientRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb, _ := reg.Client("sandbox"); sb.SecretKey != "" || sb.Signer == nil {
+		t.Fatalf("sandbox should sign through the socket: %+v", sb)
+	}
+}
+
+func TestRegistryRunningMasterOrdersAcrossProfiles(t *testing.T) {
+	a := qetest.NewServer("key-a", "secret-a")
+	defer a.Close()
+	b := qetest.NewServer("key-b", "secret-b")
+	defer b.Close()
+
+	reg, err := qe.NewClientRegistry(qe.RegistryConfig{Profiles: map[string]*qe.Profile{
+		"a":    {APIKey: "key-a", SecretKey: "secret-a", BaseURL: a.URL},
+		"b":    {APIKey: "key-b", SecretKey: "secret-b", BaseURL: b.URL},
+		"down": {APIKey: "key-b", SecretKey: "wrong", BaseURL: b.URL},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := reg.Client("a")
+	cb, _ := reg.Client("b")
+	idA := createTWAP(t, ca, "BTCUSDT")
+	idB1 := createTWAP(t, cb, "ETHUSDT")
+	idB2 := createTWAP(t, cb, "SOLUSDT")
+	b.SetStatus(idB2, "COMPLETED")
+
+	orders, err := reg.RunningMasterOrders(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "profile down") {
+		t.Fatalf("err = %v, want failure tagged with profile down", err)
+	}
+	got := map[string]string{}
+	for _, o := range orders {
+		got[o.Profile+"/"+o.MasterOrderId] = o.Symbol
+	}
+	if len(got) != 2 || got["a/"+idA] != "BTCUSDT" || got["b/"+idB1] != "ETHUSDT" {
+		t.Fatalf("orders = %v", got)
+	}
+}
+
+func TestRateLimiterSharedAcrossProfiles(t *testing.T) {
+	srv := qetest.NewServer("key", "secret")
+	defer srv.Close()
+	reg, err := qe.NewClientRegistry(qe.RegistryConfig{RateLimit: 20, Burst: 1, Profiles: map[string]*qe.Profile{
+		"x": {APIKey: "key", SecretKey: "secret", BaseURL: srv.URL},
+		"y": {APIKey: "key", SecretKey: "secret", BaseURL: srv.URL},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for _, res := range qe.FanOut(context.Background(), reg, func(ctx context.Context, _ string, c *qe.Client) (int, error) {
+		for i := 0; i < 3; i++ {
+			if err := c.NewPingServer().Do(ctx); err != nil {
+				return i, err
+			}
+		}
+		return 3, nil
+	}) {
+		if res.Err != nil {
+			t.Fatalf("%s: %v", res.Profile, res.Err)
+		}
+	}
+	// Six requests at 20/s with a burst of one take at least 250ms when the
+	// bucket is shared; two private buckets would finish in about 100ms.
+	if elapsed := time.Since(start); elapsed < 240*time.Millisecond {
+		t.Fatalf("six requests took %v; limiter not shared", elapsed)
+	}
+}
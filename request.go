@@ -28,6 +28,14 @@ type request struct {
 	header     http.Header
 	body       io.Reader
 	fullURL    string
+	meta       *ResponseMeta
+	noCache    bool
+	// baseURL is the base the request is built against; pinned disables
+	// failover to other bases.
+	baseURL string
+	pinned  bool
+	// creds signs the request, including when it is signed again.
+	creds *credentialSet
 }
 
 // addParam add param with key/value to query string
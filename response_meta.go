@@ -0,0 +1,142 @@
+package qe_connector
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseMeta describes the HTTP exchange behind a call. Pass
+// WithResponseMeta to any Do to have it filled in, for example to report the
+// TraceId of a create call to support:
+//
+//	var meta qe.ResponseMeta
+//	order, err := client.NewCreateMasterOrderV2Service().
+//		// ...
+//		Do(ctx, qe.WithResponseMeta(&meta))
+//	log.Printf("created %s, traceId=%s", order.MasterOrderId, meta.TraceId)
+//
+// It is filled for failed calls too, as far as the call got.
+type ResponseMeta struct {
+	// TraceId and ServerTime (Unix milliseconds) come from the response
+	// envelope.
+	TraceId    string
+	ServerTime int64
+	// StatusCode is the HTTP status, or 0 when no response was received.
+	StatusCode int
+	// RateLimit holds the rate-limit headers of the response: RateLimit-*,
+	// X-RateLimit-*, X-Mbx-Used-Weight* and Retry-After.
+	RateLimit http.Header
+	// Attempts is the number of HTTP requests made for the call; 0 when the
+	// response came from a ReadCache or an identical call in flight.
+	Attempts int
+	// Hedged is set when a hedge copy of the call was sent (see
+	// SetHedging); Attempts then counts both copies.
+	Hedged bool
+	// RoundTrip is the time from sending the request to reading the whole
+	// response body.
+	RoundTrip time.Duration
+}
+
+// WithResponseMeta fills meta with the response metadata of the call.
+func WithResponseMeta(meta *ResponseMeta) RequestOption {
+	return func(r *request) {
+		r.meta = meta
+	}
+}
+
+// rateLimitHeaders returns the rate-limit headers of h, or nil if there are
+// none.
+func rateLimitHeaders(h http.Header) http.Header {
+	var out http.Header
+	for k, v := range h {
+		if k == "Retry-After" || strings.HasPrefix(k, "Ratelimit") ||
+			strings.HasPrefix(k, "X-Ratelimit") || strings.HasPrefix(k, "X-Mbx-Used-Weight") {
+			if out == nil {
+				out = http.Header{}
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// SetAutoTimeOffset makes the client estimate its clock offset from the
+// serverTime of every response and use the estimate instead of TimeOffset
+// to timestamp signed requests, so clock drift does not push requests out
+// of recvWindow. The estimate needs no extra requests; until the first
+// response arrives TimeOffset is used.
+func (c *Client) SetAutoTimeOffset(on bool) *Client {
+	if c.frozen("SetAutoTimeOffset") {
+		return c
+	}
+	if on {
+		c.skew = new(clockSkew)
+	} else {
+		c.skew = nil
+	}
+	return c
+}
+
+// EstimatedTimeOffset returns the clock offset, local minus server time in
+// milliseconds, estimated from response serverTimes. ok is false unless
+// SetAutoTimeOffset is on and a response has been seen.
+func (c *Client) EstimatedTimeOffset() (offset int64, ok bool) {
+	if c.skew == nil {
+		return 0, false
+	}
+	return c.skew.offset()
+}
+
+// timestamp returns the timestamp for a signed request.
+func (c *Client) timestamp() int64 {
+	if offset, ok := c.EstimatedTimeOffset(); ok {
+		return currentTimestamp() - offset
+	}
+	return currentTimestamp() - c.settings().timeOffset
+}
+
+// clockSkewSamples is how many recent samples clockSkew keeps.
+const clockSkewSamples = 8
+
+// clockSkew estimates the offset between the local and server clocks. Each
+// sample assumes the server stamped serverTime halfway through the round
+// trip, so the sample with the shortest round trip among the recent ones
+// is the most accurate.
+type clockSkew struct {
+	mu      sync.Mutex
+	samples [clockSkewSamples]skewSample
+	n       int
+}
+
+type skewSample struct {
+	offset int64
+	rtt    time.Duration
+}
+
+func (s *clockSkew) observe(start time.Time, rtt time.Duration, serverTime int64) {
+	if serverTime <= 0 {
+		return
+	}
+	mid := FormatTimestamp(start.Add(rtt / 2))
+	s.mu.Lock()
+	s.samples[s.n%clockSkewSamples] = skewSample{offset: mid - serverTime, rtt: rtt}
+	s.n++
+	s.mu.Unlock()
+}
+
+func (s *clockSkew) offset() (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.n == 0 {
+		return 0, false
+	}
+	best := s.samples[0]
+	for _, sample := range s.samples[1:min(s.n, clockSkewSamples)] {
+		if sample.rtt < best.rtt {
+			best = sample
+		}
+	}
+	return best.offset, true
+}
@@ -0,0 +1,101 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func TestResponseMetaOnSuccess(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL)
+
+	var create qe.ResponseMeta
+	_, err := c.NewCreateMasterOrderV2Service().
+		ApiKeyId("binding-id").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(trading_enums.AlgorithmTWAP).
+		ExecutionDurationSeconds(600).
+		TotalQuantity("1").
+		Do(context.Background(), qe.WithResponseMeta(&create))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(create.TraceId, "qetest-") || create.StatusCode != http.StatusOK ||
+		create.Attempts != 1 || create.RoundTrip <= 0 || create.RateLimit != nil {
+		t.Fatalf("create meta = %+v", create)
+	}
+	if d := time.Since(time.UnixMilli(create.ServerTime)); d < 0 || d > time.Minute {
+		t.Fatalf("serverTime %d is %v away", create.ServerTime, d)
+	}
+
+	var list qe.ResponseMeta
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&list)); err != nil {
+		t.Fatal(err)
+	}
+	if list.TraceId == "" || list.TraceId == create.TraceId || list.StatusCode != http.StatusOK {
+		t.Fatalf("list meta = %+v", list)
+	}
+}
+
+func TestResponseMetaOnError(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	srv.InjectFault(qetest.Fault{
+		HTTPStatus: http.StatusTooManyRequests,
+		Reason:     "RATE_LIMITED",
+		Header:     http.Header{"Retry-After": {"3"}, "X-Ratelimit-Remaining": {"0"}, "X-Other": {"1"}},
+	})
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL)
+
+	var meta qe.ResponseMeta
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err == nil {
+		t.Fatal("expected an error")
+	}
+	if meta.StatusCode != http.StatusTooManyRequests || meta.TraceId == "" || meta.ServerTime == 0 {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if meta.RateLimit.Get("Retry-After") != "3" || meta.RateLimit.Get("X-Ratelimit-Remaining") != "0" ||
+		meta.RateLimit.Get("X-Other") != "" {
+		t.Fatalf("rate-limit headers = %v", meta.RateLimit)
+	}
+}
+
+func TestAutoTimeOffsetFollowsServerTime(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	// The server clock runs ten minutes ahead of ours.
+	srv.SetClock(func() time.Time { return time.Now().Add(10 * time.Minute) })
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).SetAutoTimeOffset(true)
+	if _, ok := c.EstimatedTimeOffset(); ok {
+		t.Fatal("estimate before any response")
+	}
+
+	// The first signed call is outside recvWindow but its error still
+	// carries serverTime.
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err == nil {
+		t.Fatal("expected a timestamp error")
+	}
+	offset, ok := c.EstimatedTimeOffset()
+	if want := -(10 * time.Minute).Milliseconds(); !ok || offset > want+1000 || offset < want-1000 {
+		t.Fatalf("offset = %d, %v", offset, ok)
+	}
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err != nil {
+		t.Fatalf("with the estimated offset: %v", err)
+	}
+
+	c.SetAutoTimeOffset(false)
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background()); err == nil {
+		t.Fatal("expected a timestamp error with auto offset off")
+	}
+}
@@ -0,0 +1,129 @@
+package qe_connector
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries REST calls that failed without being processed.
+// GETs are retried after transport failures, 429 and 502/503/504; other
+// methods only after a 429 or when the connection could not be established,
+// so a call that may have reached the server is never sent twice. Retried
+// GETs are signed again with a fresh timestamp.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first. The
+	// default is 3.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled for each one
+	// after it up to MaxBackoff. A 429's Retry-After takes precedence. The
+	// defaults are 200ms and 5s.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// SetRetry retries failed calls according to p. Pass nil to turn retries
+// off.
+func (c *Client) SetRetry(p *RetryPolicy) *Client {
+	if c.frozen("SetRetry") {
+		return c
+	}
+	if p == nil {
+		c.retry = nil
+		return c
+	}
+	r := *p
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 3
+	}
+	if r.Backoff <= 0 {
+		r.Backoff = 200 * time.Millisecond
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = 5 * time.Second
+	}
+	c.retry = &r
+	return c
+}
+
+// retryable reports whether a call that ended with res or err may be sent
+// again.
+func retryable(method string, res *http.Response, err error) bool {
+	if err != nil {
+		return canFailover(method, err)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return gatewayFailure(res.StatusCode) && canFailover(method, nil)
+}
+
+// wait returns the delay before retry number n (1 for the first), honoring
+// res's Retry-After.
+func (p *RetryPolicy) wait(n int, res *http.Response) time.Duration {
+	if res != nil {
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return min(time.Duration(s)*time.Second, p.MaxBackoff)
+		}
+	}
+	d := p.Backoff << (n - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// sendWithRetry sends req, failing over and retrying as configured.
+func (c *Client) sendWithRetry(rl *requestLog, req *http.Request, x *exchange) *http.Response {
+	ctx := req.Context()
+	for n := 1; ; n++ {
+		res := c.sendWithFailover(ctx, rl, req, x)
+		p := c.retry
+		if p == nil || n >= p.MaxAttempts || ctx.Err() != nil || !retryable(req.Method, res, x.err) {
+			return res
+		}
+		wait := p.wait(n, res)
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			x.err = ctx.Err()
+			return nil
+		case <-t.C:
+		}
+
+		next, err := c.nextAttempt(ctx, rl, req)
+		if err != nil {
+			x.err = err
+			return nil
+		}
+		req = next
+	}
+}
+
+// nextAttempt returns req ready to be sent again: signed anew when
+// possible, otherwise with its body rewound.
+func (c *Client) nextAttempt(ctx context.Context, rl *requestLog, req *http.Request) (*http.Request, error) {
+	out := req.Clone(ctx)
+	if rl.resign != nil {
+		u, err := rl.resign()
+		if err != nil {
+			return nil, err
+		}
+		out.URL, out.Host = u, ""
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+	return out, nil
+}
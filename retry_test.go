@@ -0,0 +1,84 @@
+package qe_connector_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+func TestRetryGetOnGatewayError(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetRetry(&qe.RetryPolicy{Backoff: time.Millisecond})
+	srv.InjectFault(qetest.Fault{HTTPStatus: http.StatusServiceUnavailable, Times: 2})
+
+	var meta qe.ResponseMeta
+	if _, err := c.NewGetMasterOrdersV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", meta.Attempts)
+	}
+}
+
+func TestRetryNeverResendsMutationOnGatewayError(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetRetry(&qe.RetryPolicy{Backoff: time.Millisecond})
+	srv.InjectFault(qetest.Fault{HTTPStatus: http.StatusServiceUnavailable, Times: 1})
+
+	var meta qe.ResponseMeta
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err == nil {
+		t.Fatal("POST answered with 503 must not be resent")
+	}
+	if meta.Attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", meta.Attempts)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetRetry(&qe.RetryPolicy{Backoff: time.Millisecond})
+	srv.InjectFault(qetest.Fault{
+		HTTPStatus: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"1"}},
+		Times:      1,
+	})
+
+	start := time.Now()
+	var meta qe.ResponseMeta
+	if _, err := c.NewCreateListenKeyV2Service().Do(context.Background(), qe.WithResponseMeta(&meta)); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, want at least Retry-After", elapsed)
+	}
+	if meta.Attempts != 2 || len(srv.Requests()) != 1 {
+		t.Fatalf("attempts = %d, requests = %d", meta.Attempts, len(srv.Requests()))
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+	c := qe.NewClient(testAPIKey, testSecretKey, srv.URL).
+		SetRetry(&qe.RetryPolicy{MaxAttempts: 10, Backoff: time.Hour})
+	srv.InjectFault(qetest.Fault{HTTPStatus: http.StatusServiceUnavailable})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.NewGetMasterOrdersV2Service().Do(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+	if ctx.Err() == nil {
+		t.Fatal("returned before the context ended")
+	}
+}
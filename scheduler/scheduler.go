@@ -0,0 +1,333 @@
+// Package scheduler submits V2 master orders on recurring schedules, e.g.
+// "TWAP 5 BTC every weekday 08:00-10:00 UTC" or "DCA 1000 USDT every 4h".
+//
+// Each Job pairs a cron-like spec (see ParseSchedule) with a function that
+// builds the CreateMasterOrderV2Service for one run. The scheduler persists
+// the last run of every job in a StateStore, so after a restart it detects
+// runs that were missed while it was down, refuses to start a run while the
+// previous run's master order is still working, and emits one AuditRecord
+// per schedule slot.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+// MissedRunPolicy decides what happens to slots that passed while the
+// scheduler was not running.
+type MissedRunPolicy int
+
+const (
+	// SkipMissed records missed slots in the audit log and waits for the
+	// next regular slot.
+	SkipMissed MissedRunPolicy = iota
+	// RunLatestMissed submits the most recent missed slot immediately (if
+	// it is within Job.CatchUpWindow) and records older ones as missed.
+	RunLatestMissed
+)
+
+// maxMissedSlots bounds how many missed slots are audited after a long
+// outage; only the most recent ones are kept.
+const maxMissedSlots = 1000
+
+// Run describes one schedule slot handed to Job.Order.
+type Run struct {
+	Job         string
+	ScheduledAt time.Time
+	// ClientOrderId is derived from the job name and slot; the scheduler
+	// sets it on the order so a slot can never create two master orders.
+	ClientOrderId string
+}
+
+// Job is a recurring order submission.
+type Job struct {
+	// Name identifies the job in the state store and audit log.
+	Name string
+	// Spec is a 5-field cron expression or `@every <duration>`.
+	Spec string
+	// Location is the time zone for cron fields (default UTC).
+	Location *time.Location
+	// Order builds the create request for one run. It is called once per
+	// slot and must return a new service each time.
+	Order func(run Run) *qe.CreateMasterOrderV2Service
+	// MissedRuns selects the missed-slot policy (default SkipMissed).
+	MissedRuns MissedRunPolicy
+	// CatchUpWindow limits how late a RunLatestMissed catch-up may start.
+	// Zero means no limit.
+	CatchUpWindow time.Duration
+	// AllowOverlap disables the check that the previous run's master order
+	// has reached a terminal status.
+	AllowOverlap bool
+}
+
+type jobEntry struct {
+	job      Job
+	schedule Schedule
+
+	// run serialises load and fire for the job; state and loaded are
+	// guarded by it. next is guarded by Scheduler.mu so nextDue can read
+	// it while a slot is being submitted.
+	run    sync.Mutex
+	state  JobState
+	loaded bool
+	next   time.Time
+}
+
+// Scheduler runs Jobs against a client.
+type Scheduler struct {
+	c     *qe.Client
+	store StateStore
+	audit AuditSink
+	opts  []qe.RequestOption
+	now   func() time.Time
+
+	mu   sync.Mutex
+	jobs []*jobEntry
+}
+
+// New creates a scheduler. store and audit are required; use
+// NewMemoryStateStore / AuditSinkFunc for ad-hoc setups.
+func New(c *qe.Client, store StateStore, audit AuditSink, opts ...qe.RequestOption) *Scheduler {
+	return &Scheduler{
+		c:     c,
+		store: store,
+		audit: audit,
+		opts:  opts,
+		now:   time.Now,
+	}
+}
+
+// Add registers a job. Job names must be unique.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" {
+		return errors.New("job name is required")
+	}
+	if job.Order == nil {
+		return fmt.Errorf("job %s: Order is required", job.Name)
+	}
+	schedule, err := ParseSchedule(job.Spec, job.Location)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.jobs {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("job %s already registered", job.Name)
+		}
+	}
+	s.jobs = append(s.jobs, &jobEntry{job: job, schedule: schedule})
+	return nil
+}
+
+// Run processes missed slots, then submits orders as slots come due until
+// ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		if err := s.RunPending(ctx); err != nil {
+			return err
+		}
+		wait := time.Until(s.nextDue())
+		if wait < 0 {
+			wait = 0
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RunPending loads job state on first use and handles every slot that is
+// due at the current time. Run calls it in a loop; it is exported for
+// callers that drive the scheduler from their own ticker.
+//
+// A failing job does not hold up the others: its remaining slots wait for
+// the next call and the errors of all jobs are returned together. A slot
+// only counts as handled once its state has been saved, so a slot whose
+// Save failed is retried on the next call.
+func (s *Scheduler) RunPending(ctx context.Context) error {
+	s.mu.Lock()
+	jobs := append([]*jobEntry(nil), s.jobs...)
+	s.mu.Unlock()
+	now := s.now()
+	var errs []error
+	for _, e := range jobs {
+		if err := s.runJob(ctx, e, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) runJob(ctx context.Context, e *jobEntry, now time.Time) error {
+	e.run.Lock()
+	defer e.run.Unlock()
+	if !e.loaded {
+		return s.load(ctx, e, now)
+	}
+	for {
+		slot := s.next(e)
+		if slot.IsZero() || slot.After(now) {
+			return nil
+		}
+		if err := s.fire(ctx, e, slot); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Scheduler) next(e *jobEntry) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return e.next
+}
+
+func (s *Scheduler) setNext(e *jobEntry, next time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.next = next
+}
+
+func (s *Scheduler) nextDue() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, e := range s.jobs {
+		if !e.next.IsZero() && (next.IsZero() || e.next.Before(next)) {
+			next = e.next
+		}
+	}
+	if next.IsZero() {
+		next = s.now().Add(time.Minute)
+	}
+	return next
+}
+
+// load restores the job's state and reconciles slots that passed while the
+// scheduler was down.
+func (s *Scheduler) load(ctx context.Context, e *jobEntry, now time.Time) error {
+	st, ok, err := s.store.Load(e.job.Name)
+	if err != nil {
+		return fmt.Errorf("load state for %s: %w", e.job.Name, err)
+	}
+	e.loaded = true
+	e.state = st
+	if !ok || st.LastScheduledAt.IsZero() {
+		s.setNext(e, e.schedule.Next(now))
+		return nil
+	}
+
+	var missed []time.Time
+	slot := e.schedule.Next(st.LastScheduledAt)
+	for !slot.IsZero() && !slot.After(now) {
+		missed = append(missed, slot)
+		if len(missed) > maxMissedSlots {
+			missed = missed[1:]
+		}
+		slot = e.schedule.Next(slot)
+	}
+	s.setNext(e, slot)
+
+	var catchUp time.Time
+	if e.job.MissedRuns == RunLatestMissed && len(missed) > 0 {
+		latest := missed[len(missed)-1]
+		if e.job.CatchUpWindow == 0 || now.Sub(latest) <= e.job.CatchUpWindow {
+			catchUp = latest
+			missed = missed[:len(missed)-1]
+		}
+	}
+	for _, m := range missed {
+		e.state.LastScheduledAt = m
+		if err := s.record(AuditRecord{
+			Job:         e.job.Name,
+			ScheduledAt: m,
+			Outcome:     AuditMissed,
+		}); err != nil {
+			return err
+		}
+	}
+	if len(missed) > 0 {
+		if err := s.store.Save(e.job.Name, e.state); err != nil {
+			return fmt.Errorf("save state for %s: %w", e.job.Name, err)
+		}
+	}
+	if !catchUp.IsZero() {
+		return s.fire(ctx, e, catchUp)
+	}
+	return nil
+}
+
+// fire handles a single slot: overlap check, submission, state, audit.
+// The job only moves past slot once the new state has been saved.
+func (s *Scheduler) fire(ctx context.Context, e *jobEntry, slot time.Time) error {
+	run := Run{
+		Job:           e.job.Name,
+		ScheduledAt:   slot,
+		ClientOrderId: fmt.Sprintf("%s-%s", e.job.Name, slot.UTC().Format("20060102T1504")),
+	}
+	rec := AuditRecord{Job: run.Job, ScheduledAt: slot, ClientOrderId: run.ClientOrderId}
+
+	st := e.state
+	prev := st.LastMasterOrderId
+	st.LastScheduledAt = slot
+	if !e.job.AllowOverlap && prev != "" {
+		reply, err := s.c.NewGetMasterOrderDetailV2Service().MasterOrderId(prev).Do(ctx, s.opts...)
+		switch {
+		case err != nil:
+			// Without knowing whether the previous run is still working we
+			// must not start another one.
+			rec.Outcome = AuditFailed
+			rec.PreviousMasterOrderId = prev
+			rec.Error = fmt.Sprintf("overlap check: %v", err)
+			return s.finish(e, st, rec)
+		case !reply.MasterOrder.Status.IsTerminal():
+			rec.Outcome = AuditSkippedOverlap
+			rec.PreviousMasterOrderId = prev
+			return s.finish(e, st, rec)
+		}
+	}
+
+	svc := e.job.Order(run)
+	if svc == nil {
+		rec.Outcome = AuditFailed
+		rec.Error = "Order returned nil service"
+		return s.finish(e, st, rec)
+	}
+	reply, err := svc.ClientOrderId(run.ClientOrderId).Do(ctx, s.opts...)
+	if err != nil {
+		rec.Outcome = AuditFailed
+		rec.Error = err.Error()
+		return s.finish(e, st, rec)
+	}
+	rec.Outcome = AuditSubmitted
+	rec.MasterOrderId = reply.MasterOrderId
+	st.LastMasterOrderId = reply.MasterOrderId
+	st.LastClientOrderId = run.ClientOrderId
+	return s.finish(e, st, rec)
+}
+
+// finish persists st, advances the job past rec's slot and audits it.
+func (s *Scheduler) finish(e *jobEntry, st JobState, rec AuditRecord) error {
+	if err := s.store.Save(e.job.Name, st); err != nil {
+		return fmt.Errorf("save state for %s: %w", e.job.Name, err)
+	}
+	e.state = st
+	s.setNext(e, e.schedule.Next(rec.ScheduledAt))
+	return s.record(rec)
+}
+
+func (s *Scheduler) record(rec AuditRecord) error {
+	rec.RecordedAt = s.now()
+	if err := s.audit.Record(rec); err != nil {
+		return fmt.Errorf("audit %s: %w", rec.Job, err)
+	}
+	return nil
+}
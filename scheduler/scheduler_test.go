@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	cases := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"0 8 * * 1-5", "2026-10-16T09:00:00Z", "2026-10-19T08:00:00Z"}, // Fri 09:00 -> Mon 08:00
+		{"0 8 * * 1-5", "2026-10-19T07:59:00Z", "2026-10-19T08:00:00Z"},
+		{"*/15 * * * *", "2026-10-19T10:07:30Z", "2026-10-19T10:15:00Z"},
+		{"30 23 31 12 *", "2026-10-19T00:00:00Z", "2026-12-31T23:30:00Z"},
+		{"0 0 1 * 0", "2026-10-19T00:00:00Z", "2026-10-25T00:00:00Z"}, // dom OR dow
+		{"@every 4h", "2026-10-19T10:00:00Z", "2026-10-19T14:00:00Z"},
+	}
+	for _, tc := range cases {
+		s, err := ParseSchedule(tc.spec, time.UTC)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q) error = %v", tc.spec, err)
+		}
+		from, _ := time.Parse(time.RFC3339, tc.from)
+		if got := s.Next(from).UTC().Format(time.RFC3339); got != tc.want {
+			t.Fatalf("%q Next(%s) = %s, want %s", tc.spec, tc.from, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "* * * * 8", "*/0 * * * *", "@every 10s"} {
+		if _, err := ParseSchedule(bad, time.UTC); err == nil {
+			t.Fatalf("ParseSchedule(%q) expected error", bad)
+		}
+	}
+}
+
+type fakeStrategyAPI struct {
+	mu        sync.Mutex
+	created   []map[string]interface{}
+	statusFor map[string]string
+}
+
+func (f *fakeStrategyAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/user/trading/v2/master-orders":
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.created = append(f.created, body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":    200,
+			"message": map[string]string{"masterOrderId": "mo_" + body["clientOrderId"].(string), "status": "NEW"},
+		})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/user/trading/v2/master-orders/"):
+		id := strings.TrimPrefix(r.URL.Path, "/user/trading/v2/master-orders/")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":    200,
+			"message": map[string]interface{}{"masterOrder": map[string]string{"masterOrderId": id, "status": f.statusFor[id]}},
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func twapJob(c *qe.Client, name, spec string) Job {
+	return Job{
+		Name: name,
+		Spec: spec,
+		Order: func(run Run) *qe.CreateMasterOrderV2Service {
+			return c.NewCreateMasterOrderV2Service().
+				ApiKeyId("binding-id").
+				Exchange(trading_enums.ExchangeBinance).
+				MarketType(trading_enums.MarketTypeSpot).
+				Symbol("BTCUSDT").
+				Side(trading_enums.OrderSideBuy).
+				Algorithm(trading_enums.AlgorithmTWAP).
+				ExecutionDurationSeconds(7200).
+				TotalQuantity("5")
+		},
+	}
+}
+
+func TestSchedulerDetectsMissedRunsAfterRestart(t *testing.T) {
+	api := &fakeStrategyAPI{statusFor: map[string]string{}}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := qe.NewClient("k", "s", srv.URL)
+
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "state.json"))
+	// Last run Friday 08:00; scheduler comes back Tuesday 09:00.
+	last := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	if err := store.Save("btc-twap", JobState{LastScheduledAt: last}); err != nil {
+		t.Fatal(err)
+	}
+
+	var audit []AuditRecord
+	s := New(client, store, AuditSinkFunc(func(rec AuditRecord) error {
+		audit = append(audit, rec)
+		return nil
+	}))
+	s.now = func() time.Time { return time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC) }
+	job := twapJob(client, "btc-twap", "0 8 * * 1-5")
+	job.MissedRuns = RunLatestMissed
+	job.CatchUpWindow = 2 * time.Hour
+	if err := s.Add(job); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunPending(context.Background()); err != nil {
+		t.Fatalf("RunPending() error = %v", err)
+	}
+
+	if len(audit) != 2 {
+		t.Fatalf("audit = %#v, want missed Monday + submitted Tuesday", audit)
+	}
+	if audit[0].Outcome != AuditMissed || audit[0].ScheduledAt.Day() != 19 {
+		t.Fatalf("audit[0] = %#v, want Monday slot missed", audit[0])
+	}
+	if audit[1].Outcome != AuditSubmitted || audit[1].ClientOrderId != "btc-twap-20261020T0800" {
+		t.Fatalf("audit[1] = %#v, want Tuesday slot submitted", audit[1])
+	}
+	if len(api.created) != 1 || api.created[0]["clientOrderId"] != "btc-twap-20261020T0800" {
+		t.Fatalf("created = %#v", api.created)
+	}
+
+	st, ok, err := store.Load("btc-twap")
+	if err != nil || !ok {
+		t.Fatalf("Load() = %v, %v", ok, err)
+	}
+	if st.LastMasterOrderId != "mo_btc-twap-20261020T0800" || !st.LastScheduledAt.Equal(audit[1].ScheduledAt) {
+		t.Fatalf("persisted state = %#v", st)
+	}
+}
+
+func TestSchedulerSkipsWhilePreviousRunStillWorking(t *testing.T) {
+	api := &fakeStrategyAPI{statusFor: map[string]string{"mo_prev": "PROCESSING"}}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := qe.NewClient("k", "s", srv.URL)
+
+	store := NewMemoryStateStore()
+	now := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+	_ = store.Save("dca", JobState{LastScheduledAt: now.Add(-4 * time.Hour), LastMasterOrderId: "mo_prev"})
+
+	var audit []AuditRecord
+	s := New(client, store, AuditSinkFunc(func(rec AuditRecord) error {
+		audit = append(audit, rec)
+		return nil
+	}))
+	s.now = func() time.Time { return now }
+	job := twapJob(client, "dca", "@every 4h")
+	job.MissedRuns = RunLatestMissed
+	if err := s.Add(job); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunPending(context.Background()); err != nil {
+		t.Fatalf("RunPending() error = %v", err)
+	}
+	if len(audit) != 1 || audit[0].Outcome != AuditSkippedOverlap || audit[0].PreviousMasterOrderId != "mo_prev" {
+		t.Fatalf("audit = %#v, want one skipped_overlap", audit)
+	}
+	if len(api.created) != 0 {
+		t.Fatalf("no order should be created while the previous run is working")
+	}
+
+	api.statusFor["mo_prev"] = "COMPLETED"
+	now = now.Add(4 * time.Hour)
+	if err := s.RunPending(context.Background()); err != nil {
+		t.Fatalf("RunPending() error = %v", err)
+	}
+	if len(api.created) != 1 || audit[len(audit)-1].Outcome != AuditSubmitted {
+		t.Fatalf("expected submission once the previous run completed, audit = %#v", audit)
+	}
+}
+
+// failingStore fails Save for the named job until fail is cleared.
+type failingStore struct {
+	*MemoryStateStore
+	fail string
+}
+
+func (f *failingStore) Save(job string, state JobState) error {
+	if job == f.fail {
+		return errors.New("disk full")
+	}
+	return f.MemoryStateStore.Save(job, state)
+}
+
+func TestSchedulerRetriesUnsavedSlotAndRunsOtherJobs(t *testing.T) {
+	api := &fakeStrategyAPI{statusFor: map[string]string{}}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := qe.NewClient("k", "s", srv.URL)
+
+	store := &failingStore{MemoryStateStore: NewMemoryStateStore()}
+	var audit []AuditRecord
+	s := New(client, store, AuditSinkFunc(func(rec AuditRecord) error {
+		audit = append(audit, rec)
+		return nil
+	}))
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	for _, name := range []string{"a", "b"} {
+		job := twapJob(client, name, "0 8 * * *")
+		order := job.Order
+		job.Order = func(run Run) *qe.CreateMasterOrderV2Service {
+			s.nextDue() // the scheduler lock is not held while submitting
+			return order(run)
+		}
+		if err := s.Add(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.RunPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	store.fail = "a"
+	now = now.Add(time.Hour)
+	err := s.RunPending(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "save state for a") {
+		t.Fatalf("RunPending() error = %v, want save error for a", err)
+	}
+	if len(audit) != 1 || audit[0].Job != "b" || audit[0].Outcome != AuditSubmitted {
+		t.Fatalf("audit = %#v, want b submitted", audit)
+	}
+	if due := s.nextDue(); !due.Equal(now) {
+		t.Fatalf("nextDue() = %s, want the unsaved slot %s", due, now)
+	}
+
+	store.fail = ""
+	if err := s.RunPending(context.Background()); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(audit) != 2 || audit[1].Job != "a" || audit[1].ClientOrderId != "a-20261019T0800" {
+		t.Fatalf("audit = %#v, want a's slot retried", audit)
+	}
+	if st, _, _ := store.Load("a"); st.LastMasterOrderId != "mo_a-20261019T0800" {
+		t.Fatalf("state = %#v", st)
+	}
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// JobState is the per-job state persisted between runs so that a restarted
+// scheduler can detect missed runs and overlapping executions.
+type JobState struct {
+	// LastScheduledAt is the schedule slot of the most recent run attempt.
+	LastScheduledAt time.Time `json:"lastScheduledAt"`
+	// LastMasterOrderId is the master order created by the most recent
+	// successful run, used for the no-overlap check.
+	LastMasterOrderId string `json:"lastMasterOrderId,omitempty"`
+	LastClientOrderId string `json:"lastClientOrderId,omitempty"`
+}
+
+// StateStore persists JobState keyed by job name.
+type StateStore interface {
+	Load(job string) (JobState, bool, error)
+	Save(job string, state JobState) error
+}
+
+// FileStateStore keeps all job states in a single JSON file. Writes go
+// through a synced temp file + rename so a crash never leaves a torn file
+// behind.
+type FileStateStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStateStore returns a store backed by path. The file is created on
+// the first Save.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load returns the state of job; ok is false when the job has never run.
+func (f *FileStateStore) Load(job string) (JobState, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	all, err := f.read()
+	if err != nil {
+		return JobState{}, false, err
+	}
+	st, ok := all[job]
+	return st, ok, nil
+}
+
+// Save stores the state of job.
+func (f *FileStateStore) Save(job string, state JobState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	all, err := f.read()
+	if err != nil {
+		return err
+	}
+	all[job] = state
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *FileStateStore) read() (map[string]JobState, error) {
+	all := map[string]JobState{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return all, nil
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// MemoryStateStore is an in-process StateStore, mainly for tests.
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]JobState
+}
+
+// NewMemoryStateStore returns an empty in-memory store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: map[string]JobState{}}
+}
+
+// Load returns the state of job; ok is false when the job has never run.
+func (m *MemoryStateStore) Load(job string) (JobState, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.states[job]
+	return st, ok, nil
+}
+
+// Save stores the state of job.
+func (m *MemoryStateStore) Save(job string, state JobState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[job] = state
+	return nil
+}
+
+// AuditOutcome classifies an AuditRecord.
+type AuditOutcome string
+
+const (
+	AuditSubmitted      AuditOutcome = "submitted"
+	AuditFailed         AuditOutcome = "failed"
+	AuditMissed         AuditOutcome = "missed"
+	AuditSkippedOverlap AuditOutcome = "skipped_overlap"
+)
+
+// AuditRecord is emitted once per schedule slot, whatever happened to it.
+type AuditRecord struct {
+	Job           string       `json:"job"`
+	ScheduledAt   time.Time    `json:"scheduledAt"`
+	RecordedAt    time.Time    `json:"recordedAt"`
+	Outcome       AuditOutcome `json:"outcome"`
+	MasterOrderId string       `json:"masterOrderId,omitempty"`
+	ClientOrderId string       `json:"clientOrderId,omitempty"`
+	// PreviousMasterOrderId is set for skipped_overlap records.
+	PreviousMasterOrderId string `json:"previousMasterOrderId,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// AuditSink receives audit records.
+type AuditSink interface {
+	Record(rec AuditRecord) error
+}
+
+// AuditSinkFunc adapts a function to AuditSink.
+type AuditSinkFunc func(rec AuditRecord) error
+
+// Record calls f(rec).
+func (f AuditSinkFunc) Record(rec AuditRecord) error {
+	return f(rec)
+}
+
+// JSONLAuditSink writes one JSON object per line to w.
+type JSONLAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLAuditSink returns a sink writing JSON lines to w.
+func NewJSONLAuditSink(w io.Writer) *JSONLAuditSink {
+	return &JSONLAuditSink{w: w}
+}
+
+// Record writes rec as a single JSON line.
+func (s *JSONLAuditSink) Record(rec AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,307 @@
+package qe_connector
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Signer produces the `signature` parameter of a signed request from its
+// canonical payload: the encoded query and form for V1 calls, or the sorted
+// merge of query and JSON body keys for V2 calls (see callAPIV2Envelope).
+//
+// A Client uses its Signer when one is set and otherwise HMAC-SHA256 signs
+// with SecretKey, so a secret handled by a Signer never has to be stored on
+// the Client.
+type Signer interface {
+	Sign(ctx context.Context, payload []byte) (string, error)
+}
+
+// SetSigner makes the client sign requests with s instead of SecretKey.
+// Pass nil to go back to SecretKey.
+func (c *Client) SetSigner(s Signer) *Client {
+	if c.frozen("SetSigner") {
+		return c
+	}
+	c.Signer = s
+	return c
+}
+
+// HMACSigner signs with an in-memory HMAC-SHA256 key.
+type HMACSigner struct {
+	key []byte
+}
+
+// NewHMACSigner copies secret into a new HMACSigner.
+func NewHMACSigner(secret []byte) *HMACSigner {
+	return &HMACSigner{key: append([]byte(nil), secret...)}
+}
+
+// Sign implements Signer.
+func (s *HMACSigner) Sign(_ context.Context, payload []byte) (string, error) {
+	return hmacHex(s.key, payload), nil
+}
+
+func hmacHex(key, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// FileSigner signs with an HMAC key read from a file. The file is re-read
+// whenever its modification time or size changes, so the key can be rotated
+// by replacing the file without restarting the process. Surrounding
+// whitespace in the file is ignored.
+type FileSigner struct {
+	path string
+
+	mu    sync.Mutex
+	key   []byte
+	mtime time.Time
+	size  int64
+}
+
+// NewFileSigner returns a FileSigner for path. The file is read on first use.
+func NewFileSigner(path string) *FileSigner {
+	return &FileSigner{path: path}
+}
+
+// Sign implements Signer.
+func (s *FileSigner) Sign(_ context.Context, payload []byte) (string, error) {
+	key, err := s.current()
+	if err != nil {
+		return "", err
+	}
+	return hmacHex(key, payload), nil
+}
+
+func (s *FileSigner) current() ([]byte, error) {
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("file signer: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key != nil && fi.ModTime().Equal(s.mtime) && fi.Size() == s.size {
+		return s.key, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("file signer: %w", err)
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("file signer: %s is empty", s.path)
+	}
+	s.key, s.mtime, s.size = key, fi.ModTime(), fi.Size()
+	return s.key, nil
+}
+
+// EnvSigner signs with an HMAC key read from an environment variable on
+// every call, so os.Setenv rotates it.
+type EnvSigner struct {
+	name string
+}
+
+// NewEnvSigner returns an EnvSigner reading the variable name.
+func NewEnvSigner(name string) *EnvSigner {
+	return &EnvSigner{name: name}
+}
+
+// Sign implements Signer.
+func (s *EnvSigner) Sign(_ context.Context, payload []byte) (string, error) {
+	key := os.Getenv(s.name)
+	if key == "" {
+		return "", fmt.Errorf("env signer: $%s is not set", s.name)
+	}
+	return hmacHex([]byte(key), payload), nil
+}
+
+// signRequest and signResponse are the SocketSigner wire format: one JSON
+// object per line in each direction.
+type signRequest struct {
+	Payload string `json:"payload"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SocketSigner delegates signing to an out-of-process daemon, typically on a
+// local Unix socket, so the secret never enters the trading process. Each
+// request is a line `{"payload":"..."}` answered by a line
+// `{"signature":"..."}` or `{"error":"..."}`. ServeSigner implements the
+// daemon side.
+//
+// The connection is opened on first use, reused for later calls and
+// re-dialed after an error. Calls are serialised over it; a call gives up
+// when its ctx is done, whether it is waiting for its turn or for the
+// daemon, and no exchange with the daemon takes longer than the timeout
+// (DefaultSocketSignerTimeout unless set with SetTimeout).
+type SocketSigner struct {
+	network, addr string
+	dialer        net.Dialer
+	timeout       time.Duration
+
+	// turn holds a token while a call uses conn.
+	turn chan struct{}
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// DefaultSocketSignerTimeout bounds each SocketSigner exchange, so a hung
+// daemon fails the call instead of blocking every signing caller.
+const DefaultSocketSignerTimeout = 5 * time.Second
+
+// NewSocketSigner returns a SocketSigner for the daemon at addr, e.g.
+// NewSocketSigner("unix", "/run/qe-signer.sock").
+func NewSocketSigner(network, addr string) *SocketSigner {
+	return &SocketSigner{
+		network: network,
+		addr:    addr,
+		timeout: DefaultSocketSignerTimeout,
+		turn:    make(chan struct{}, 1),
+	}
+}
+
+// SetTimeout sets how long dialing the daemon and each request / reply
+// exchange may take. Zero or less restores the default.
+func (s *SocketSigner) SetTimeout(d time.Duration) *SocketSigner {
+	if d <= 0 {
+		d = DefaultSocketSignerTimeout
+	}
+	s.lock()
+	defer s.unlock()
+	s.timeout = d
+	return s
+}
+
+func (s *SocketSigner) lock()   { s.turn <- struct{}{} }
+func (s *SocketSigner) unlock() { <-s.turn }
+
+// Sign implements Signer.
+func (s *SocketSigner) Sign(ctx context.Context, payload []byte) (string, error) {
+	select {
+	case s.turn <- struct{}{}:
+	case <-ctx.Done():
+		return "", fmt.Errorf("socket signer: %w", ctx.Err())
+	}
+	defer s.unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if s.conn == nil {
+		conn, err := s.dialer.DialContext(ctx, s.network, s.addr)
+		if err != nil {
+			return "", fmt.Errorf("socket signer: %w", err)
+		}
+		s.conn, s.r = conn, bufio.NewReader(conn)
+	}
+	conn := s.conn
+	conn.SetDeadline(time.Time{})
+	// Unblock the exchange as soon as ctx is done, whether cancelled or
+	// timed out.
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+
+	res, err := s.roundTrip(payload)
+	if !stop() || err != nil {
+		// A late reply or deadline would leave the connection out of step
+		// with the daemon, so it is not reused.
+		conn.Close()
+		s.conn, s.r = nil, nil
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return "", fmt.Errorf("socket signer: %w", err)
+	}
+	if res.Error != "" {
+		return "", fmt.Errorf("socket signer: %s", res.Error)
+	}
+	return res.Signature, nil
+}
+
+func (s *SocketSigner) roundTrip(payload []byte) (*signResponse, error) {
+	line, err := json.Marshal(signRequest{Payload: string(payload)})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.conn.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	reply, err := s.r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	res := new(signResponse)
+	if err := json.Unmarshal(reply, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Close closes the connection to the daemon, if open.
+func (s *SocketSigner) Close() error {
+	s.lock()
+	defer s.unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn, s.r = nil, nil
+	return err
+}
+
+// ServeSigner answers SocketSigner requests on l with signer until l is
+// closed. A signing daemon is a few lines around it:
+//
+//	l, _ := net.Listen("unix", "/run/qe-signer.sock")
+//	qe.ServeSigner(l, qe.NewFileSigner("/etc/qe/secret"))
+func ServeSigner(l net.Listener, signer Signer) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveSignerConn(conn, signer)
+	}
+}
+
+func serveSignerConn(conn net.Conn, signer Signer) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	enc := json.NewEncoder(conn)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var req signRequest
+		var res signResponse
+		if err := json.Unmarshal(line, &req); err != nil {
+			res.Error = "bad request: " + err.Error()
+		} else if sig, err := signer.Sign(context.Background(), []byte(req.Payload)); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Signature = sig
+		}
+		if err := enc.Encode(res); err != nil {
+			return
+		}
+	}
+}
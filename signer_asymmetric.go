@@ -0,0 +1,106 @@
+package qe_connector
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Ed25519Signer signs requests for an Ed25519 API key. The signature is the
+// standard base64 encoding of the Ed25519 signature over the same canonical
+// payload the HMAC mode signs.
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a signer for key.
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+// Sign implements Signer.
+func (s *Ed25519Signer) Sign(_ context.Context, payload []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, payload)), nil
+}
+
+// Public returns the public key to register with the API key.
+func (s *Ed25519Signer) Public() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// RSASigner signs requests for an RSA API key with RSASSA-PKCS1-v1_5 over
+// SHA-256 of the canonical payload, base64 encoded.
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner returns a signer for key.
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+// Sign implements Signer.
+func (s *RSASigner) Sign(_ context.Context, payload []byte) (string, error) {
+	digest := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Public returns the public key to register with the API key.
+func (s *RSASigner) Public() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+// ParsePrivateKeySigner returns an Ed25519Signer or RSASigner for a PEM
+// encoded private key: PKCS#8 ("PRIVATE KEY", as written by
+// `openssl genpkey`) or PKCS#1 ("RSA PRIVATE KEY"). Encrypted keys are not
+// supported.
+func ParsePrivateKeySigner(pemBytes []byte) (Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("private key: no PEM block found")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("private key: unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return NewEd25519Signer(k), nil
+	case *rsa.PrivateKey:
+		return NewRSASigner(k), nil
+	default:
+		return nil, fmt.Errorf("private key: unsupported key type %T", key)
+	}
+}
+
+// LoadPrivateKeySigner reads a PEM private key file, see
+// ParsePrivateKeySigner.
+func LoadPrivateKeySigner(path string) (Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	return ParsePrivateKeySigner(data)
+}
@@ -0,0 +1,210 @@
+package qe_connector_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+)
+
+// signedCalls exercises both signing paths: a V1-style signed GET and a V2
+// JSON body request.
+func signedCalls(c *qe.Client) error {
+	ctx := context.Background()
+	if _, err := c.NewGetMasterOrdersV2Service().Do(ctx); err != nil {
+		return err
+	}
+	_, err := c.NewCreateListenKeyV2Service().Do(ctx)
+	return err
+}
+
+func TestSignersWithoutSecretKey(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(testSecretKey+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("QE_TEST_SIGNER_SECRET", testSecretKey)
+
+	for name, s := range map[string]qe.Signer{
+		"hmac": qe.NewHMACSigner([]byte(testSecretKey)),
+		"file": qe.NewFileSigner(path),
+		"env":  qe.NewEnvSigner("QE_TEST_SIGNER_SECRET"),
+	} {
+		c := qe.NewClient(testAPIKey, "", srv.URL).SetSigner(s)
+		if err := signedCalls(c); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestAsymmetricSignersAgainstFakeServer(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed, rs := qe.NewEd25519Signer(edKey), qe.NewRSASigner(rsaKey)
+	for apiKey, pub := range map[string]crypto.PublicKey{"ed25519-key": ed.Public(), "rsa-key": rs.Public()} {
+		if err := srv.AddPublicKey(apiKey, pub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := srv.AddPublicKey("dsa-key", "not a key"); err == nil {
+		t.Fatal("expected an error for an unsupported key type")
+	}
+
+	for apiKey, s := range map[string]qe.Signer{"ed25519-key": ed, "rsa-key": rs} {
+		if err := signedCalls(qe.NewClient(apiKey, "", srv.URL).SetSigner(s)); err != nil {
+			t.Errorf("%s: %v", apiKey, err)
+		}
+	}
+	// A signature by another key is rejected.
+	if err := signedCalls(qe.NewClient("rsa-key", "", srv.URL).SetSigner(ed)); err == nil {
+		t.Fatal("expected the Ed25519 signature to be rejected for the RSA key")
+	}
+}
+
+func TestFileSignerPicksUpRotatedKey(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("old-secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := qe.NewClient(testAPIKey, "", srv.URL).SetSigner(qe.NewFileSigner(path))
+	if err := signedCalls(c); err == nil {
+		t.Fatal("expected the old secret to be rejected")
+	}
+	if err := os.WriteFile(path, []byte(testSecretKey), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := signedCalls(c); err != nil {
+		t.Fatalf("after rotation: %v", err)
+	}
+}
+
+type failingSigner struct{}
+
+func (failingSigner) Sign(context.Context, []byte) (string, error) {
+	return "", errors.New("hsm offline")
+}
+
+func TestSocketSignerDelegatesToDaemon(t *testing.T) {
+	srv := qetest.NewServer(testAPIKey, testSecretKey)
+	defer srv.Close()
+
+	// Unix socket paths are length-limited, so avoid the long t.TempDir.
+	dir, err := os.MkdirTemp("", "qesig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	serve := func(name string, s qe.Signer) string {
+		sock := filepath.Join(dir, name)
+		l, err := net.Listen("unix", sock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { l.Close() })
+		go qe.ServeSigner(l, s)
+		return sock
+	}
+
+	signer := qe.NewSocketSigner("unix", serve("ok.sock", qe.NewHMACSigner([]byte(testSecretKey))))
+	defer signer.Close()
+	c := qe.NewClient(testAPIKey, "", srv.URL).SetSigner(signer)
+	for i := 0; i < 2; i++ {
+		if err := signedCalls(c); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+
+	bad := qe.NewSocketSigner("unix", serve("bad.sock", failingSigner{}))
+	defer bad.Close()
+	err = signedCalls(qe.NewClient(testAPIKey, "", srv.URL).SetSigner(bad))
+	if err == nil || !strings.Contains(err.Error(), "hsm offline") {
+		t.Fatalf("err = %v, want daemon error", err)
+	}
+}
+
+func TestSocketSignerGivesUpOnHungDaemon(t *testing.T) {
+	dir, err := os.MkdirTemp("", "qesig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The daemon accepts connections but never answers.
+	sock := filepath.Join(dir, "hung.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	signer := qe.NewSocketSigner("unix", sock).SetTimeout(100 * time.Millisecond)
+	defer signer.Close()
+
+	// The default timeout bounds a call whose ctx never ends.
+	start := time.Now()
+	if _, err := signer.Sign(context.Background(), []byte("a=1")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Fatalf("Sign took %v", d)
+	}
+
+	// Cancelling ctx unblocks a call waiting on the daemon, and one waiting
+	// for its turn behind it.
+	signer.SetTimeout(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := signer.Sign(ctx, []byte("a=1"))
+			errs <- err
+		}()
+	}
+	time.AfterFunc(50*time.Millisecond, cancel)
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("err = %v, want canceled", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Sign did not return after cancel")
+		}
+	}
+}
@@ -0,0 +1,511 @@
+// Package simulator dry-runs V2 master orders offline. It slices a
+// CreateMasterOrderV2Service request over a synthetic or recorded price and
+// volume path the way the TWAP / VWAP / POV algorithms schedule child
+// orders, and emits the WsOrderFillDetail / WsMasterOrderDetail sequence the
+// WS stream would deliver. Runs are fully deterministic, so they are suited
+// to unit-testing event handlers and to estimating schedules.
+//
+// The model is intentionally simple: one child order per bar, filled in
+// full at the bar's bid or ask. Within that model it honours the order
+// parameters as documented for the create endpoint:
+//
+//   - TWAP targets time-linear progress, VWAP targets the cumulative volume
+//     share of the path, POV trades PovLimit of each bar's volume.
+//   - PovLimit caps and PovMinLimit floors each child at that share of the
+//     bar's market volume.
+//   - UpTolerance / LowTolerance let progress run ahead of the target when
+//     the price is better than arrival and lag it when worse; StrictUpBound
+//     never lets PovMinLimit push progress above the upper band.
+//   - MustComplete (default true) catches up to the lower band even above
+//     PovLimit and sweeps the remainder in the last bar; false never catches
+//     up.
+//   - WorstPrice stops trading in bars priced beyond it.
+//   - TailOrderProtection (default true) folds a remainder smaller than
+//     MinOrderQty into the current child; otherwise the order ends with
+//     COMPLETED_WITHTAIL.
+//   - EnableMake / MakerRateLimit decide which children rest as maker.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+// Slice reason codes.
+const (
+	ReasonSchedule   = "schedule"
+	ReasonPovCap     = "pov_cap"
+	ReasonCatchUp    = "catch_up"
+	ReasonWorstPrice = "worst_price"
+	ReasonBelowMin   = "below_min"
+	ReasonTail       = "tail"
+)
+
+// Slice is the simulator's decision for one bar.
+type Slice struct {
+	Time         time.Time
+	MarketPrice  float64
+	MarketVolume float64
+	// Target is the scheduled cumulative progress (0-1) at the end of the
+	// bar; zero for POV.
+	Target float64
+	// Quantity is the child order size in base currency; zero when the bar
+	// was skipped.
+	Quantity      float64
+	Price         float64
+	Maker         bool
+	Progress      float64
+	Participation float64
+	Reason        string
+}
+
+// Event is one WS push, in emission order. Exactly one field is set.
+type Event struct {
+	Time        time.Time
+	MasterOrder *qe.WsMasterOrderDetail
+	Fill        *qe.WsOrderFillDetail
+}
+
+// Result is the outcome of a simulated execution.
+type Result struct {
+	ArrivalPrice float64
+	Slices       []Slice
+	Events       []Event
+	Final        qe.WsMasterOrderDetail
+}
+
+// Replay feeds the events to handlers in order, stopping at the first
+// handler error.
+func (r *Result) Replay(h *qe.WebSocketEventHandlers) error {
+	for _, e := range r.Events {
+		var err error
+		switch {
+		case e.Fill != nil && h.OnOrderFillDetail != nil:
+			err = h.OnOrderFillDetail(e.Fill)
+		case e.MasterOrder != nil && h.OnMasterOrderDetail != nil:
+			err = h.OnMasterOrderDetail(e.MasterOrder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Simulator runs one master order against a path.
+type Simulator struct {
+	order         *qe.OrderTemplate
+	bars          []Bar
+	masterOrderId string
+	minOrderQty   float64
+	spreadBps     float64
+}
+
+// New creates a simulator for the order that svc would create.
+func New(svc *qe.CreateMasterOrderV2Service, bars []Bar) *Simulator {
+	return &Simulator{
+		order:         svc.Template(),
+		bars:          bars,
+		masterOrderId: "sim-1",
+		spreadBps:     2,
+	}
+}
+
+// MasterOrderId sets the ID used in emitted events (default "sim-1").
+func (s *Simulator) MasterOrderId(id string) *Simulator {
+	s.masterOrderId = id
+	return s
+}
+
+// MinOrderQty sets the exchange minimum order size in base currency used for
+// tail handling (default 0, i.e. no minimum).
+func (s *Simulator) MinOrderQty(qty float64) *Simulator {
+	s.minOrderQty = qty
+	return s
+}
+
+// SpreadBps sets the bid/ask spread assumed for bars without quotes
+// (default 2 bps).
+func (s *Simulator) SpreadBps(bps float64) *Simulator {
+	s.spreadBps = bps
+	return s
+}
+
+type params struct {
+	side         float64
+	byNotional   bool
+	total        float64
+	start, end   time.Time
+	duration     time.Duration
+	povLimit     float64
+	povMinLimit  float64
+	up, low      float64
+	strictUp     bool
+	mustComplete bool
+	tailProtect  bool
+	makerShare   float64
+	worstPrice   float64
+}
+
+func (s *Simulator) params() (*params, error) {
+	t := s.order
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	if len(s.bars) == 0 {
+		return nil, errors.New("simulator: empty path")
+	}
+	p := &params{
+		side:         1,
+		povLimit:     1,
+		mustComplete: boolOr(t.MustComplete, true),
+		tailProtect:  boolOr(t.TailOrderProtection, true),
+		strictUp:     boolOr(t.StrictUpBound, false),
+		makerShare:   0.5,
+	}
+	if t.Side == trading_enums.OrderSideSell {
+		p.side = -1
+	}
+	if t.OrderNotional != nil {
+		p.byNotional = true
+		p.total = num(t.OrderNotional, 0)
+	} else {
+		p.total = num(t.TotalQuantity, 0)
+	}
+	if p.total <= 0 {
+		return nil, errors.New("simulator: order size must be positive")
+	}
+	if t.Algorithm == trading_enums.AlgorithmPOV {
+		p.povLimit = 0.05
+	}
+	p.povLimit = num(t.PovLimit, p.povLimit)
+	p.povMinLimit = math.Max(num(t.PovMinLimit, 0), 0)
+	p.up = math.Max(num(t.UpTolerance, 0), 0)
+	p.low = math.Max(num(t.LowTolerance, 0), 0)
+	p.worstPrice = num(t.WorstPrice, 0)
+	if m := num(t.MakerRateLimit, -1); m >= 0 {
+		p.makerShare = m
+	}
+	if !boolOr(t.EnableMake, true) {
+		p.makerShare = 0
+	}
+
+	p.start = s.bars[0].Time
+	if t.StartTimeMs != nil {
+		p.start = time.UnixMilli(*t.StartTimeMs)
+	}
+	if t.ExecutionDurationSeconds != nil {
+		p.duration = time.Duration(*t.ExecutionDurationSeconds) * time.Second
+		p.end = p.start.Add(p.duration)
+	} else if t.Algorithm != trading_enums.AlgorithmPOV {
+		return nil, fmt.Errorf("simulator: executionDurationSeconds is required for %s", t.Algorithm)
+	} else {
+		p.end = s.bars[len(s.bars)-1].Time.Add(time.Nanosecond)
+	}
+	return p, nil
+}
+
+// Run simulates the execution.
+func (s *Simulator) Run() (*Result, error) {
+	p, err := s.params()
+	if err != nil {
+		return nil, err
+	}
+	var window []Bar
+	for _, b := range s.bars {
+		if !b.Time.Before(p.start) && b.Time.Before(p.end) {
+			window = append(window, b)
+		}
+	}
+	if len(window) == 0 {
+		return nil, errors.New("simulator: no bars inside the execution window")
+	}
+	targets := s.targets(p, window)
+
+	res := &Result{ArrivalPrice: window[0].Price}
+	st := &state{sim: s, p: p, res: res}
+	st.emitMaster(window[0].Time, qe.MasterOrderStatusV2New)
+
+	eps := p.total * 1e-9
+	tailLeft := false
+	for i, bar := range window {
+		remaining := p.total - st.filled
+		if remaining <= eps {
+			break
+		}
+		mkt := bar.Volume
+		if p.byNotional {
+			mkt *= bar.Price
+		}
+		slice := Slice{Time: bar.Time, MarketPrice: bar.Price, MarketVolume: bar.Volume, Target: targets[i], Reason: ReasonSchedule}
+		last := i == len(window)-1
+
+		var qty float64
+		if s.order.Algorithm == trading_enums.AlgorithmPOV {
+			qty = math.Max(p.povLimit*mkt, p.povMinLimit*mkt)
+			if p.mustComplete && last && p.duration > 0 {
+				qty, slice.Reason = remaining, ReasonCatchUp
+			}
+		} else {
+			target := targets[i] * p.total
+			upper := math.Min(target+p.up*p.total, p.total)
+			lower := target - p.low*p.total
+			aim := lower
+			if p.side*(res.ArrivalPrice-bar.Price) > 0 {
+				aim = upper
+			}
+			want := math.Max(aim-st.filled, 0)
+			qty = math.Min(want, p.povLimit*mkt)
+			if qty < want {
+				slice.Reason = ReasonPovCap
+			}
+			qty = math.Max(qty, math.Min(p.povMinLimit*mkt, remaining))
+			if p.strictUp {
+				qty = math.Min(qty, math.Max(upper-st.filled, 0))
+			}
+			if p.mustComplete && st.filled+qty < lower-eps {
+				qty, slice.Reason = lower-st.filled, ReasonCatchUp
+			}
+			if p.mustComplete && last {
+				qty, slice.Reason = remaining, ReasonCatchUp
+			}
+		}
+		qty = math.Min(qty, remaining)
+
+		if p.worstPrice > 0 && p.side*(bar.Price-p.worstPrice) > 0 {
+			slice.Quantity, slice.Reason = 0, ReasonWorstPrice
+			slice.Progress = st.filled / p.total
+			res.Slices = append(res.Slices, slice)
+			continue
+		}
+		if s.minOrderQty > 0 && qty > eps {
+			if s.toBase(p, qty, bar.Price) < s.minOrderQty && remaining-qty > eps {
+				qty, slice.Reason = 0, ReasonBelowMin
+			} else if rest := remaining - qty; rest > eps && s.toBase(p, rest, bar.Price) < s.minOrderQty {
+				if p.tailProtect {
+					qty, slice.Reason = remaining, ReasonTail
+				} else {
+					tailLeft = true
+				}
+			}
+		}
+		if qty > eps {
+			st.fill(&slice, bar, qty)
+		}
+		slice.Progress = st.filled / p.total
+		res.Slices = append(res.Slices, slice)
+		if tailLeft {
+			break
+		}
+	}
+
+	status := qe.MasterOrderStatusV2Expired
+	switch {
+	case p.total-st.filled <= eps:
+		status = qe.MasterOrderStatusV2Completed
+	case tailLeft:
+		status = qe.MasterOrderStatusV2CompletedWithTail
+	}
+	finish := res.Slices[len(res.Slices)-1].Time
+	st.finished = finish.UnixMilli()
+	st.emitMaster(finish, status)
+	res.Final = *res.Events[len(res.Events)-1].MasterOrder
+	return res, nil
+}
+
+// targets returns the scheduled cumulative progress at the end of each bar.
+func (s *Simulator) targets(p *params, window []Bar) []float64 {
+	out := make([]float64, len(window))
+	if s.order.Algorithm == trading_enums.AlgorithmPOV {
+		return out
+	}
+	var totalVol float64
+	for _, b := range window {
+		totalVol += b.Volume
+	}
+	var cumVol float64
+	for i, b := range window {
+		cumVol += b.Volume
+		if s.order.Algorithm == trading_enums.AlgorithmVWAP && totalVol > 0 {
+			out[i] = cumVol / totalVol
+			continue
+		}
+		end := p.end
+		if i+1 < len(window) {
+			end = window[i+1].Time
+		}
+		out[i] = math.Min(float64(end.Sub(p.start))/float64(p.duration), 1)
+	}
+	return out
+}
+
+func (s *Simulator) toBase(p *params, units, price float64) float64 {
+	if p.byNotional {
+		return units / price
+	}
+	return units
+}
+
+func (s *Simulator) quotes(b Bar) (bid, ask float64) {
+	if b.Bid > 0 && b.Ask > 0 {
+		return b.Bid, b.Ask
+	}
+	half := b.Price * s.spreadBps / 2e4
+	return b.Price - half, b.Price + half
+}
+
+type state struct {
+	sim            *Simulator
+	p              *params
+	res            *Result
+	filled         float64 // in order units (base qty or quote notional)
+	filledQty      float64
+	filledNotional float64
+	makerNotional  float64
+	children       int
+	finished       int64
+}
+
+func (st *state) fill(slice *Slice, bar Bar, units float64) {
+	s, p := st.sim, st.p
+	maker := p.makerShare > 0 && slice.Reason != ReasonTail && slice.Reason != ReasonCatchUp &&
+		(st.filledNotional == 0 || st.makerNotional/st.filledNotional < p.makerShare)
+	bid, ask := s.quotes(bar)
+	price := ask
+	if (p.side > 0) == maker {
+		price = bid
+	}
+	qty := units
+	if p.byNotional {
+		qty = units / price
+	}
+	notional := qty * price
+
+	st.children++
+	st.filled += units
+	st.filledQty += qty
+	st.filledNotional += notional
+	if maker {
+		st.makerNotional += notional
+	}
+	slice.Quantity, slice.Price, slice.Maker = qty, price, maker
+	if bar.Volume > 0 {
+		slice.Participation = qty / bar.Volume
+	}
+
+	orderType := "MARKET"
+	if maker {
+		orderType = "LIMIT"
+	}
+	ts := bar.Time.UTC().Format(time.RFC3339)
+	id := fmt.Sprintf("%s-%d", s.masterOrderId, st.children)
+	st.res.Events = append(st.res.Events, Event{Time: bar.Time, Fill: &qe.WsOrderFillDetail{
+		ID:               id,
+		OrderID:          id,
+		OrderCreatedTime: ts,
+		MasterOrderID:    s.masterOrderId,
+		Exchange:         string(s.order.Exchange),
+		Category:         trading_enums.Category(strings.ToLower(string(s.order.MarketType))),
+		Symbol:           s.order.Symbol,
+		Side:             s.order.Side,
+		FilledNotional:   dec(notional),
+		FilledQuantity:   dec(qty),
+		AveragePrice:     dec(price),
+		Price:            dec(price),
+		Quantity:         dec(qty),
+		Status:           qe.OrderFillStatusV2Filled,
+		OrderType:        orderType,
+		CreatedAt:        ts,
+		UpdatedAt:        ts,
+	}})
+	st.emitMaster(bar.Time, qe.MasterOrderStatusV2Processing)
+}
+
+func (st *state) emitMaster(at time.Time, status qe.MasterOrderStatusV2) {
+	t := st.sim.order
+	d := &qe.WsMasterOrderDetail{
+		CreatedAt:           st.res.firstTime(at),
+		UpdatedAt:           at.UTC().Format(time.RFC3339),
+		MasterOrderID:       st.sim.masterOrderId,
+		ApiKeyID:            t.ApiKeyId,
+		Exchange:            string(t.Exchange),
+		MarketType:          t.MarketType,
+		Category:            trading_enums.Category(strings.ToLower(string(t.MarketType))),
+		Symbol:              t.Symbol,
+		Side:                t.Side,
+		Algorithm:           t.Algorithm,
+		StartTimeMs:         qe.FlexInt64(st.p.start.UnixMilli()),
+		MustComplete:        st.p.mustComplete,
+		TailOrderProtection: st.p.tailProtect,
+		StrictUpBound:       st.p.strictUp,
+		Status:              status,
+		FinishedMs:          qe.FlexInt64(st.finished),
+		CumFilledQty:        dec(st.filledQty),
+		CumFilledNotional:   dec(st.filledNotional),
+	}
+	if t.ClientOrderId != nil {
+		d.ClientOrderID = *t.ClientOrderId
+	}
+	if t.TotalQuantity != nil {
+		d.TotalQuantity = qe.FlexDecimalString(*t.TotalQuantity)
+	}
+	if t.OrderNotional != nil {
+		d.OrderNotional = qe.FlexDecimalString(*t.OrderNotional)
+	}
+	if t.ExecutionDurationSeconds != nil {
+		d.ExecutionDurationSeconds = qe.FlexInt64(*t.ExecutionDurationSeconds)
+	}
+	if st.filledQty > 0 {
+		d.AvgFilledPrice = dec(st.filledNotional / st.filledQty)
+		d.MakerRate = dec(st.makerNotional / st.filledNotional)
+	}
+	if st.p.byNotional {
+		d.CompletedQuantity = dec(st.filledNotional)
+	} else {
+		d.CompletedQuantity = dec(st.filledQty)
+	}
+	st.res.Events = append(st.res.Events, Event{Time: at, MasterOrder: d})
+}
+
+func (r *Result) firstTime(fallback time.Time) string {
+	if len(r.Events) > 0 {
+		if mo := r.Events[0].MasterOrder; mo != nil {
+			return mo.CreatedAt
+		}
+	}
+	return fallback.UTC().Format(time.RFC3339)
+}
+
+func num(s *string, def float64) float64 {
+	if s == nil {
+		return def
+	}
+	v, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func boolOr(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
+
+func dec(v float64) qe.FlexDecimalString {
+	s := strconv.FormatFloat(v, 'f', 8, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		s = "0"
+	}
+	return qe.FlexDecimalString(s)
+}
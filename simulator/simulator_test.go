@@ -0,0 +1,169 @@
+package simulator
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+var t0 = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func order(algo trading_enums.Algorithm, qty string) *qe.CreateMasterOrderV2Service {
+	return qe.NewClient("k", "s").NewCreateMasterOrderV2Service().
+		ApiKeyId("binding").
+		Exchange(trading_enums.ExchangeBinance).
+		MarketType(trading_enums.MarketTypeSpot).
+		Symbol("BTCUSDT").
+		Side(trading_enums.OrderSideBuy).
+		Algorithm(algo).
+		ExecutionDurationSeconds(600).
+		TotalQuantity(qty)
+}
+
+func flat(n int, price, volume float64) []Bar {
+	return SyntheticPath(PathConfig{Start: t0, Interval: time.Minute, Bars: n, StartPrice: price, Volume: volume})
+}
+
+func TestTWAPFollowsLinearSchedule(t *testing.T) {
+	res, err := New(order(trading_enums.AlgorithmTWAP, "10"), flat(10, 100, 1000)).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Slices) != 10 {
+		t.Fatalf("slices = %d", len(res.Slices))
+	}
+	for i, s := range res.Slices {
+		want := float64(i+1) / 10
+		if math.Abs(s.Target-want) > 1e-9 || math.Abs(s.Progress-want) > 1e-9 {
+			t.Fatalf("slice %d target=%v progress=%v want %v", i, s.Target, s.Progress, want)
+		}
+	}
+	if res.Final.Status != qe.MasterOrderStatusV2Completed || res.Final.CumFilledQty != "10" {
+		t.Fatalf("final = %#v", res.Final)
+	}
+	// NEW, then fill + PROCESSING per child, then the terminal update.
+	if len(res.Events) != 1+2*10+1 || res.Events[0].MasterOrder.Status != "NEW" || res.Events[1].Fill == nil {
+		t.Fatalf("events = %d", len(res.Events))
+	}
+	if mr := res.Final.MakerRate.String(); mr == "0" || mr == "1" {
+		t.Fatalf("maker rate = %s, expected a mix", mr)
+	}
+}
+
+func TestPOVCapAndWorstPrice(t *testing.T) {
+	bars := flat(10, 100, 100)
+	for i := 3; i < 6; i++ {
+		bars[i].Price = 120
+	}
+	svc := order(trading_enums.AlgorithmPOV, "100").PovLimit("0.1").WorstPrice("110")
+	res, err := New(svc, bars).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range res.Slices {
+		switch {
+		case i >= 3 && i < 6:
+			if s.Quantity != 0 || s.Reason != ReasonWorstPrice {
+				t.Fatalf("slice %d traded beyond worst price: %#v", i, s)
+			}
+		case i == 9:
+			// MustComplete sweeps the remainder at the end of the window.
+			if s.Reason != ReasonCatchUp {
+				t.Fatalf("last slice = %#v", s)
+			}
+		default:
+			if math.Abs(s.Participation-0.1) > 1e-9 {
+				t.Fatalf("slice %d participation = %v", i, s.Participation)
+			}
+		}
+	}
+
+	res, err = New(svc.MustComplete(false), bars).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Final.Status != qe.MasterOrderStatusV2Expired || res.Final.CumFilledQty != "70" {
+		t.Fatalf("final without mustComplete = %s %s", res.Final.Status, res.Final.CumFilledQty)
+	}
+}
+
+func TestTailOrderProtection(t *testing.T) {
+	bars := flat(3, 100, 1000)
+	// 0.35 per bar with a 0.4 minimum leaves 0.35 after the second child,
+	// below the minimum.
+	svc := order(trading_enums.AlgorithmTWAP, "1.05").ExecutionDurationSeconds(180)
+
+	res, err := New(svc, bars).MinOrderQty(0.4).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Final.Status != qe.MasterOrderStatusV2Completed || res.Slices[len(res.Slices)-1].Reason != ReasonTail {
+		t.Fatalf("protected tail: status=%s slices=%#v", res.Final.Status, res.Slices)
+	}
+
+	res, err = New(svc.TailOrderProtection(false), bars).MinOrderQty(0.4).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Final.Status != qe.MasterOrderStatusV2CompletedWithTail {
+		t.Fatalf("unprotected tail status = %s", res.Final.Status)
+	}
+}
+
+func TestReplayIsDeterministic(t *testing.T) {
+	path := SyntheticPath(PathConfig{Seed: 7, Start: t0, Interval: time.Minute, Bars: 30,
+		StartPrice: 100, Volatility: 0.002, Volume: 500, VolumeJitter: 0.5})
+	svc := order(trading_enums.AlgorithmVWAP, "50").ExecutionDurationSeconds(1800).
+		UpTolerance("0.05").LowTolerance("0.05").PovLimit("0.2")
+
+	a, err := New(svc, path).MasterOrderId("mo-1").Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := New(svc, path).MasterOrderId("mo-1").Run()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("two runs over the same path differ")
+	}
+
+	var fills, updates int
+	var last qe.MasterOrderStatusV2
+	err = a.Replay(&qe.WebSocketEventHandlers{
+		OnOrderFillDetail: func(f *qe.WsOrderFillDetail) error {
+			if !strings.HasPrefix(f.ID, "mo-1-") {
+				t.Fatalf("fill id = %s", f.ID)
+			}
+			fills++
+			return nil
+		},
+		OnMasterOrderDetail: func(m *qe.WsMasterOrderDetail) error {
+			updates++
+			last = m.Status
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fills == 0 || updates != fills+2 || last != a.Final.Status {
+		t.Fatalf("replayed %d fills, %d updates, last=%s", fills, updates, last)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	bars, err := ReadCSV(strings.NewReader("time,price,volume,bid,ask\n" +
+		"2026-01-02T00:00:00Z,100,10,99.9,100.1\n1767312060000,101,12,,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bars) != 2 || bars[0].Bid != 99.9 || bars[1].Price != 101 || !bars[1].Time.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("bars = %#v", bars)
+	}
+	if _, err := ReadCSV(strings.NewReader("time,price\n1,2\n")); err == nil {
+		t.Fatal("expected missing volume column error")
+	}
+}
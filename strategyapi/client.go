@@ -0,0 +1,290 @@
+package strategyapi
+
+import (
+	"context"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+// New returns the StrategyAPI that calls the strategy-api through c.
+func New(c *qe.Client) StrategyAPI {
+	return &client{c: c}
+}
+
+type client struct {
+	c *qe.Client
+}
+
+func (a *client) CreateOrder(ctx context.Context, req CreateOrderRequest, opts ...qe.RequestOption) (*qe.CreateMasterOrderV2Reply, error) {
+	s := a.c.NewCreateMasterOrderV2Service().
+		ApiKeyId(req.ApiKeyId).
+		Exchange(req.Exchange).
+		MarketType(req.MarketType).
+		Symbol(req.Symbol).
+		Side(req.Side).
+		Algorithm(req.Algorithm)
+	if req.ExecutionDurationSeconds != 0 {
+		s.ExecutionDurationSeconds(req.ExecutionDurationSeconds)
+	}
+	if req.StartTimeMs != 0 {
+		s.StartTimeMs(req.StartTimeMs)
+	}
+	if req.TotalQuantity != "" {
+		s.TotalQuantity(req.TotalQuantity)
+	}
+	if req.OrderNotional != "" {
+		s.OrderNotional(req.OrderNotional)
+	}
+	if req.MarginType != "" {
+		s.MarginType(req.MarginType)
+	}
+	if req.WorstPrice != "" {
+		s.WorstPrice(req.WorstPrice)
+	}
+	if req.MakerRateLimit != "" {
+		s.MakerRateLimit(req.MakerRateLimit)
+	}
+	if req.PovLimit != "" {
+		s.PovLimit(req.PovLimit)
+	}
+	if req.PovMinLimit != "" {
+		s.PovMinLimit(req.PovMinLimit)
+	}
+	if req.UpTolerance != "" {
+		s.UpTolerance(req.UpTolerance)
+	}
+	if req.LowTolerance != "" {
+		s.LowTolerance(req.LowTolerance)
+	}
+	if req.ClientOrderId != "" {
+		s.ClientOrderId(req.ClientOrderId)
+	}
+	if req.Notes != "" {
+		s.Notes(req.Notes)
+	}
+	setBool(req.ReduceOnly, s.ReduceOnly)
+	setBool(req.IsMargin, s.IsMargin)
+	setBool(req.MustComplete, s.MustComplete)
+	setBool(req.StrictUpBound, s.StrictUpBound)
+	setBool(req.TailOrderProtection, s.TailOrderProtection)
+	setBool(req.EnableMake, s.EnableMake)
+	setBool(req.IsTargetPosition, s.IsTargetPosition)
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) ListOrders(ctx context.Context, req ListOrdersRequest, opts ...qe.RequestOption) (*qe.GetMasterOrdersV2Reply, error) {
+	s := a.c.NewGetMasterOrdersV2Service()
+	if req.Page != 0 {
+		s.Page(req.Page)
+	}
+	if req.PageSize != 0 {
+		s.PageSize(req.PageSize)
+	}
+	if req.Status != "" {
+		s.Status(req.Status)
+	}
+	if req.Exchange != "" {
+		s.Exchange(req.Exchange)
+	}
+	if req.Symbol != "" {
+		s.Symbol(req.Symbol)
+	}
+	if req.Algorithm != "" {
+		s.Algorithm(req.Algorithm)
+	}
+	if req.ApiKeyId != "" {
+		s.ApiKeyId(req.ApiKeyId)
+	}
+	if req.MasterOrderId != "" {
+		s.MasterOrderId(req.MasterOrderId)
+	}
+	if req.StartTime != "" {
+		s.StartTime(req.StartTime)
+	}
+	if req.EndTime != "" {
+		s.EndTime(req.EndTime)
+	}
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) GetOrder(ctx context.Context, masterOrderId string, opts ...qe.RequestOption) (*qe.GetMasterOrderDetailV2Reply, error) {
+	return a.c.NewGetMasterOrderDetailV2Service().MasterOrderId(masterOrderId).Do(ctx, opts...)
+}
+
+func (a *client) GetOrderByClientOrderId(ctx context.Context, clientOrderId string, opts ...qe.RequestOption) (*qe.GetMasterOrderDetailV2Reply, error) {
+	return a.c.NewGetMasterOrderDetailByClientOrderIdV2Service().ClientOrderId(clientOrderId).Do(ctx, opts...)
+}
+
+func (a *client) UpdateOrder(ctx context.Context, req UpdateOrderRequest, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error) {
+	s := a.c.NewUpdateMasterOrderParamsV2Service().MasterOrderId(req.MasterOrderId)
+	if req.TotalQuantity != "" {
+		s.TotalQuantity(req.TotalQuantity)
+	}
+	if req.OrderNotional != "" {
+		s.OrderNotional(req.OrderNotional)
+	}
+	if req.UpTolerance != "" {
+		s.UpTolerance(req.UpTolerance)
+	}
+	if req.LowTolerance != "" {
+		s.LowTolerance(req.LowTolerance)
+	}
+	if req.MakerRateLimit != "" {
+		s.MakerRateLimit(req.MakerRateLimit)
+	}
+	if req.PovLimit != "" {
+		s.PovLimit(req.PovLimit)
+	}
+	if req.PovMinLimit != "" {
+		s.PovMinLimit(req.PovMinLimit)
+	}
+	if req.WorstPrice != "" {
+		s.WorstPrice(req.WorstPrice)
+	}
+	if req.ExecutionDurationSeconds != 0 {
+		s.ExecutionDurationSeconds(req.ExecutionDurationSeconds)
+	}
+	setBool(req.EnableMake, s.EnableMake)
+	setBool(req.StrictUpBound, s.StrictUpBound)
+	setBool(req.TailOrderProtection, s.TailOrderProtection)
+	setBool(req.MustComplete, s.MustComplete)
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) CancelOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error) {
+	return a.c.NewCancelMasterOrderV2Service().MasterOrderId(masterOrderId).Reason(reason).Do(ctx, opts...)
+}
+
+func (a *client) PauseOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error) {
+	return a.c.NewPauseMasterOrderV2Service().MasterOrderId(masterOrderId).Reason(reason).Do(ctx, opts...)
+}
+
+func (a *client) ResumeOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error) {
+	return a.c.NewResumeMasterOrderV2Service().MasterOrderId(masterOrderId).Reason(reason).Do(ctx, opts...)
+}
+
+func (a *client) BatchCancelOrders(ctx context.Context, masterOrderIds []string, reason string, opts ...qe.RequestOption) (*qe.BatchCancelMasterOrdersV2Reply, error) {
+	return a.c.NewBatchCancelMasterOrdersV2Service().MasterOrderIds(masterOrderIds).Reason(reason).Do(ctx, opts...)
+}
+
+func (a *client) ListFills(ctx context.Context, req ListFillsRequest, opts ...qe.RequestOption) (*qe.GetOrderFillsV2Reply, error) {
+	s := a.c.NewGetOrderFillsV2Service()
+	if req.Page != 0 {
+		s.Page(req.Page)
+	}
+	if req.PageSize != 0 {
+		s.PageSize(req.PageSize)
+	}
+	if req.MasterOrderId != "" {
+		s.MasterOrderId(req.MasterOrderId)
+	}
+	if req.OrderId != "" {
+		s.OrderId(req.OrderId)
+	}
+	if req.ClientOrderId != "" {
+		s.ClientOrderId(req.ClientOrderId)
+	}
+	if req.Symbol != "" {
+		s.Symbol(req.Symbol)
+	}
+	if req.Status != "" {
+		s.Status(req.Status)
+	}
+	if req.StartTime != "" {
+		s.StartTime(req.StartTime)
+	}
+	if req.EndTime != "" {
+		s.EndTime(req.EndTime)
+	}
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) GetTCA(ctx context.Context, req TCARequest, opts ...qe.RequestOption) ([]*qe.TCAAnalysisV2Info, error) {
+	s := a.c.NewGetTCAAnalysisV2Service()
+	if req.Symbol != "" {
+		s.Symbol(req.Symbol)
+	}
+	if req.Category != "" {
+		s.Category(req.Category)
+	}
+	if req.Strategy != "" {
+		s.Strategy(req.Strategy)
+	}
+	if req.ApiKeyId != "" {
+		s.ApiKeyId(req.ApiKeyId)
+	}
+	if req.StartTime != 0 {
+		s.StartTime(req.StartTime)
+	}
+	if req.EndTime != 0 {
+		s.EndTime(req.EndTime)
+	}
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) ListExchangeApis(ctx context.Context, req ListExchangeApisRequest, opts ...qe.RequestOption) (*qe.ListExchangeApisV2Reply, error) {
+	s := a.c.NewListExchangeApisV2Service()
+	if req.Page != 0 {
+		s.Page(req.Page)
+	}
+	if req.PageSize != 0 {
+		s.PageSize(req.PageSize)
+	}
+	if req.Exchange != "" {
+		s.Exchange(req.Exchange)
+	}
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) GetAccountBalance(ctx context.Context, bindingId string, opts ...qe.RequestOption) (*qe.AccountBalanceReply, error) {
+	return a.c.NewGetAccountBalanceService().BindingId(bindingId).Do(ctx, opts...)
+}
+
+func (a *client) GetMarginBalance(ctx context.Context, bindingId string, opts ...qe.RequestOption) (*qe.MarginBalanceReply, error) {
+	return a.c.NewGetMarginBalanceService().BindingId(bindingId).Do(ctx, opts...)
+}
+
+func (a *client) ListPairs(ctx context.Context, req ListPairsRequest, opts ...qe.RequestOption) (*qe.TradingPairMessage, error) {
+	s := a.c.NewTradingPairsService()
+	if req.Page != 0 {
+		s.Page(req.Page)
+	}
+	if req.PageSize != 0 {
+		s.PageSize(req.PageSize)
+	}
+	if req.Exchange != "" {
+		s.Exchange(req.Exchange)
+	}
+	if req.MarketType != "" {
+		s.MarketType(req.MarketType)
+	}
+	if req.IsCoin != nil {
+		s.IsCoin(*req.IsCoin)
+	}
+	return s.Do(ctx, opts...)
+}
+
+func (a *client) CreateListenKey(ctx context.Context, opts ...qe.RequestOption) (*qe.CreateListenKeyReply, error) {
+	return a.c.NewCreateListenKeyV2Service().Do(ctx, opts...)
+}
+
+func (a *client) Subscribe(ctx context.Context, listenKey string, h *qe.WebSocketEventHandlers) (Stream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ws := a.c.NewWebSocketService()
+	if h != nil {
+		ws.SetHandlers(h)
+	}
+	if err := ws.Connect(listenKey); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// setBool calls set with *b when b is not nil.
+func setBool[S any](b *bool, set func(bool) S) {
+	if b != nil {
+		set(*b)
+	}
+}
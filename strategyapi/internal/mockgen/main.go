@@ -0,0 +1,253 @@
+// Command mockgen writes function-field mocks for the interfaces of the
+// strategyapi package. Run it through go generate in strategyapi.
+//
+// Each interface with methods of its own becomes a struct with one
+// <Method>Func field per method; interfaces that only embed others become a
+// struct embedding their mocks. Calls are recorded and an unset field
+// panics, so a test states exactly which calls it expects.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	pkgName    = "strategyapi"
+	pkgPath    = "github.com/Quantum-Execute/qe-connector-go/strategyapi"
+	mockPkg    = "strategyapimock"
+	generator  = "mockgen"
+	skipStream = "Stream"
+)
+
+func main() {
+	in := flag.String("in", "strategyapi.go", "file declaring the interfaces")
+	out := flag.String("out", "strategyapimock/mock_gen.go", "file to write")
+	flag.Parse()
+
+	src, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(*in, src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type iface struct {
+	name    string
+	embeds  []string
+	methods []*ast.Field
+}
+
+// generate returns the mock file for the interfaces declared in src.
+func generate(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	var ifaces []iface
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			// Stream is implemented by *qe.WebSocketService; tests use the
+			// real thing or their own fake.
+			if !ok || ts.Name.Name == skipStream {
+				continue
+			}
+			i := iface{name: ts.Name.Name}
+			for _, m := range it.Methods.List {
+				if len(m.Names) == 0 {
+					i.embeds = append(i.embeds, m.Type.(*ast.Ident).Name)
+					continue
+				}
+				i.methods = append(i.methods, m)
+			}
+			ifaces = append(ifaces, i)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by %s from %s; DO NOT EDIT.\n\n", generator, filepath.Base(filename))
+	fmt.Fprintf(&b, "// Package %s provides mocks of the %s interfaces.\n", mockPkg, pkgName)
+	fmt.Fprintf(&b, "package %s\n\n", mockPkg)
+	fmt.Fprintf(&b, "import (\n\t\"context\"\n\t\"sync\"\n\n")
+	fmt.Fprintf(&b, "\tqe \"github.com/Quantum-Execute/qe-connector-go\"\n\t%q\n)\n\n", pkgPath)
+	b.WriteString(callType)
+	for _, i := range ifaces {
+		if err := writeMock(&b, fset, i); err != nil {
+			return nil, err
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+const callType = `// Call is one recorded call of a mock.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+func (r *recorder) recorded() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+`
+
+func writeMock(b *bytes.Buffer, fset *token.FileSet, i iface) error {
+	if len(i.methods) == 0 {
+		fmt.Fprintf(b, "// %s mocks %s.%s by embedding the mocks of its parts; read\n", i.name, pkgName, i.name)
+		fmt.Fprintf(b, "// recorded calls from each part, e.g. m.%s.Calls().\n", i.embeds[0])
+		fmt.Fprintf(b, "type %s struct {\n", i.name)
+		for _, e := range i.embeds {
+			fmt.Fprintf(b, "\t%s\n", e)
+		}
+		fmt.Fprintf(b, "}\n\nvar _ %s.%s = (*%s)(nil)\n\n", pkgName, i.name, i.name)
+		return nil
+	}
+
+	fmt.Fprintf(b, "// %s mocks %s.%s. Set the Func field of each method the test\n", i.name, pkgName, i.name)
+	fmt.Fprintf(b, "// expects; calling a method whose field is nil panics.\n")
+	fmt.Fprintf(b, "type %s struct {\n", i.name)
+	for _, m := range i.methods {
+		sig, err := expr(fset, m.Type)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "\t%sFunc %s\n", m.Names[0].Name, sig)
+	}
+	fmt.Fprintf(b, "\n\trec recorder\n}\n\nvar _ %s.%s = (*%s)(nil)\n\n", pkgName, i.name, i.name)
+	fmt.Fprintf(b, "// Calls returns the calls made so far, oldest first.\n")
+	fmt.Fprintf(b, "func (m *%s) Calls() []Call {\n\treturn m.rec.recorded()\n}\n\n", i.name)
+
+	for _, m := range i.methods {
+		name := m.Names[0].Name
+		ft := m.Type.(*ast.FuncType)
+		var params, args, call []string
+		for n, p := range ft.Params.List {
+			typ, err := expr(fset, p.Type)
+			if err != nil {
+				return err
+			}
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", n))}
+			}
+			for _, id := range names {
+				params = append(params, id.Name+" "+typ)
+				args = append(args, id.Name)
+				if _, ok := p.Type.(*ast.Ellipsis); ok {
+					call = append(call, id.Name+"...")
+				} else {
+					call = append(call, id.Name)
+				}
+			}
+		}
+		results, err := expr(fset, ft.Results)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "func (m *%s) %s(%s) %s {\n", i.name, name, strings.Join(params, ", "), results)
+		fmt.Fprintf(b, "\tm.rec.record(%q, %s)\n", name, strings.Join(args, ", "))
+		fmt.Fprintf(b, "\tif m.%sFunc == nil {\n", name)
+		fmt.Fprintf(b, "\t\tpanic(\"%s: unexpected call to %s.%s\")\n", mockPkg, i.name, name)
+		fmt.Fprintf(b, "\t}\n\treturn m.%sFunc(%s)\n}\n\n", name, strings.Join(call, ", "))
+	}
+	return nil
+}
+
+// expr prints n with the exported identifiers of the strategyapi package
+// qualified, as the mock package sees them.
+func expr(fset *token.FileSet, n ast.Node) (string, error) {
+	var b bytes.Buffer
+	if fl, ok := n.(*ast.FieldList); ok {
+		// A results list: (T, error) or a single type.
+		var parts []string
+		for _, f := range fl.List {
+			s, err := expr(fset, f.Type)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		if len(parts) == 1 {
+			return parts[0], nil
+		}
+		return "(" + strings.Join(parts, ", ") + ")", nil
+	}
+	if err := printer.Fprint(&b, fset, qualify(n)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// qualify returns a copy of n with local exported type names prefixed by
+// the package name.
+func qualify(n ast.Node) ast.Node {
+	switch t := n.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent(pkgName), Sel: ast.NewIdent(t.Name)}
+		}
+		return ast.NewIdent(t.Name)
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(t.X).(ast.Expr)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualify(t.Elt).(ast.Expr)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(t.Elt).(ast.Expr)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(t.Key).(ast.Expr), Value: qualify(t.Value).(ast.Expr)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(t.Params), Results: qualifyFields(t.Results)}
+	default:
+		// Selectors (qe.X, context.Context) are already qualified.
+		return n
+	}
+}
+
+func qualifyFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	out := &ast.FieldList{}
+	for _, f := range fl.List {
+		out.List = append(out.List, &ast.Field{Names: f.Names, Type: qualify(f.Type).(ast.Expr)})
+	}
+	return out
+}
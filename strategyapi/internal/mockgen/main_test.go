@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGeneratedMocksUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../strategyapi.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate("strategyapi.go", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../strategyapimock/mock_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// .gitattributes checks text files out with CRLF line endings.
+	if !bytes.Equal(bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n")), want) {
+		t.Fatal("strategyapimock/mock_gen.go is stale; run go generate ./strategyapi")
+	}
+}
@@ -0,0 +1,204 @@
+// Package strategyapi describes the strategy-api as small domain
+// interfaces, so business code can depend on Orders, Fills or TCA instead of
+// *qe.Client and be tested with the function-field mocks in
+// strategyapi/strategyapimock:
+//
+//	type Rebalancer struct{ Orders strategyapi.Orders }
+//
+//	api := strategyapi.New(client)             // real implementation
+//	r := Rebalancer{Orders: api}
+//
+//	m := &strategyapimock.Orders{CreateOrderFunc: ...} // in tests
+//	r = Rebalancer{Orders: m}
+//
+// Requests are plain structs whose zero fields are left out of the call, and
+// replies are the SDK's V2 DTOs. Every method accepts the usual
+// qe.RequestOptions, e.g. qe.WithResponseMeta. Decorators (logging,
+// authorization, dry runs) wrap an interface value and embed it to inherit
+// the methods they do not change.
+package strategyapi
+
+//go:generate go run ./internal/mockgen -in strategyapi.go -out strategyapimock/mock_gen.go
+
+import (
+	"context"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+// StrategyAPI is the whole strategy-api.
+type StrategyAPI interface {
+	Orders
+	Fills
+	TCA
+	Accounts
+	Pairs
+	Streams
+}
+
+// Orders manages V2 master orders.
+type Orders interface {
+	CreateOrder(ctx context.Context, req CreateOrderRequest, opts ...qe.RequestOption) (*qe.CreateMasterOrderV2Reply, error)
+	ListOrders(ctx context.Context, req ListOrdersRequest, opts ...qe.RequestOption) (*qe.GetMasterOrdersV2Reply, error)
+	GetOrder(ctx context.Context, masterOrderId string, opts ...qe.RequestOption) (*qe.GetMasterOrderDetailV2Reply, error)
+	GetOrderByClientOrderId(ctx context.Context, clientOrderId string, opts ...qe.RequestOption) (*qe.GetMasterOrderDetailV2Reply, error)
+	UpdateOrder(ctx context.Context, req UpdateOrderRequest, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error)
+	CancelOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error)
+	PauseOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error)
+	ResumeOrder(ctx context.Context, masterOrderId, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error)
+	BatchCancelOrders(ctx context.Context, masterOrderIds []string, reason string, opts ...qe.RequestOption) (*qe.BatchCancelMasterOrdersV2Reply, error)
+}
+
+// Fills lists the child order fills of master orders.
+type Fills interface {
+	ListFills(ctx context.Context, req ListFillsRequest, opts ...qe.RequestOption) (*qe.GetOrderFillsV2Reply, error)
+}
+
+// TCA reads transaction cost analysis.
+type TCA interface {
+	GetTCA(ctx context.Context, req TCARequest, opts ...qe.RequestOption) ([]*qe.TCAAnalysisV2Info, error)
+}
+
+// Accounts reads exchange API bindings and their balances.
+type Accounts interface {
+	ListExchangeApis(ctx context.Context, req ListExchangeApisRequest, opts ...qe.RequestOption) (*qe.ListExchangeApisV2Reply, error)
+	GetAccountBalance(ctx context.Context, bindingId string, opts ...qe.RequestOption) (*qe.AccountBalanceReply, error)
+	GetMarginBalance(ctx context.Context, bindingId string, opts ...qe.RequestOption) (*qe.MarginBalanceReply, error)
+}
+
+// Pairs lists tradable pairs.
+type Pairs interface {
+	ListPairs(ctx context.Context, req ListPairsRequest, opts ...qe.RequestOption) (*qe.TradingPairMessage, error)
+}
+
+// Streams opens push streams of order updates.
+type Streams interface {
+	CreateListenKey(ctx context.Context, opts ...qe.RequestOption) (*qe.CreateListenKeyReply, error)
+	// Subscribe connects to the push stream of listenKey and delivers its
+	// messages to h until the Stream is closed.
+	Subscribe(ctx context.Context, listenKey string, h *qe.WebSocketEventHandlers) (Stream, error)
+}
+
+// Stream is an open push stream; *qe.WebSocketService implements it.
+type Stream interface {
+	IsConnected() bool
+	Close() error
+}
+
+// CreateOrderRequest creates a master order; see
+// qe.CreateMasterOrderV2Service for the meaning and rules of each field.
+type CreateOrderRequest struct {
+	ApiKeyId   string
+	Exchange   trading_enums.Exchange
+	MarketType trading_enums.MarketType
+	Symbol     string
+	Side       trading_enums.OrderSide
+	Algorithm  trading_enums.Algorithm
+
+	ExecutionDurationSeconds int64
+	StartTimeMs              int64
+	// Exactly one of TotalQuantity and OrderNotional is required.
+	TotalQuantity  string
+	OrderNotional  string
+	MarginType     trading_enums.MarginType
+	WorstPrice     string
+	MakerRateLimit string
+	PovLimit       string
+	PovMinLimit    string
+	UpTolerance    string
+	LowTolerance   string
+	ClientOrderId  string
+	Notes          string
+
+	// Nil booleans are left to the server default.
+	ReduceOnly          *bool
+	IsMargin            *bool
+	MustComplete        *bool
+	StrictUpBound       *bool
+	TailOrderProtection *bool
+	EnableMake          *bool
+	IsTargetPosition    *bool
+}
+
+// ListOrdersRequest filters master orders.
+type ListOrdersRequest struct {
+	Page          int32
+	PageSize      int32
+	Status        qe.MasterOrderStatusV2
+	Exchange      string
+	Symbol        string
+	Algorithm     trading_enums.Algorithm
+	ApiKeyId      string
+	MasterOrderId string
+	// StartTime and EndTime are RFC 3339 timestamps.
+	StartTime string
+	EndTime   string
+}
+
+// UpdateOrderRequest changes parameters of a running master order; see
+// qe.UpdateMasterOrderParamsV2Service.
+type UpdateOrderRequest struct {
+	MasterOrderId string
+
+	TotalQuantity            string
+	OrderNotional            string
+	UpTolerance              string
+	LowTolerance             string
+	MakerRateLimit           string
+	PovLimit                 string
+	PovMinLimit              string
+	WorstPrice               string
+	ExecutionDurationSeconds int64
+
+	EnableMake          *bool
+	StrictUpBound       *bool
+	TailOrderProtection *bool
+	MustComplete        *bool
+}
+
+// ListFillsRequest filters fills.
+type ListFillsRequest struct {
+	Page          int32
+	PageSize      int32
+	MasterOrderId string
+	OrderId       string
+	ClientOrderId string
+	Symbol        string
+	Status        string
+	// StartTime and EndTime are RFC 3339 timestamps.
+	StartTime string
+	EndTime   string
+}
+
+// TCARequest filters TCA results.
+type TCARequest struct {
+	Symbol   string
+	Category string
+	Strategy string
+	ApiKeyId string
+	// StartTime and EndTime are Unix milliseconds.
+	StartTime int64
+	EndTime   int64
+}
+
+// ListExchangeApisRequest filters exchange API bindings.
+type ListExchangeApisRequest struct {
+	Page     int32
+	PageSize int32
+	Exchange trading_enums.Exchange
+}
+
+// ListPairsRequest filters trading pairs.
+type ListPairsRequest struct {
+	Page       int32
+	PageSize   int32
+	Exchange   trading_enums.Exchange
+	MarketType trading_enums.TradingPairMarketType
+	IsCoin     *bool
+}
+
+// Bool returns a pointer to b, for the optional booleans of requests.
+func Bool(b bool) *bool {
+	return &b
+}
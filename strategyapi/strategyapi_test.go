@@ -0,0 +1,161 @@
+package strategyapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/qetest"
+	"github.com/Quantum-Execute/qe-connector-go/strategyapi"
+	"github.com/Quantum-Execute/qe-connector-go/strategyapi/strategyapimock"
+)
+
+func twap(symbol string) strategyapi.CreateOrderRequest {
+	return strategyapi.CreateOrderRequest{
+		ApiKeyId:                 "binding-id",
+		Exchange:                 trading_enums.ExchangeBinance,
+		MarketType:               trading_enums.MarketTypeSpot,
+		Symbol:                   symbol,
+		Side:                     trading_enums.OrderSideBuy,
+		Algorithm:                trading_enums.AlgorithmTWAP,
+		ExecutionDurationSeconds: 600,
+		TotalQuantity:            "1",
+		MustComplete:             strategyapi.Bool(true),
+	}
+}
+
+func jsonHas(body []byte, s string) bool {
+	return bytes.Contains(body, []byte(s))
+}
+
+func TestClientImplementation(t *testing.T) {
+	srv := qetest.NewServer("api-key", "secret-key")
+	defer srv.Close()
+	c, err := qe.New(qe.WithBaseURL(srv.URL), qe.WithWSHost(srv.WSHost()), qe.WithCredentials("api-key", "secret-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var api strategyapi.StrategyAPI = strategyapi.New(c)
+	ctx := context.Background()
+
+	created, err := api.CreateOrder(ctx, twap("BTCUSDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Requests()[0].Body; !jsonHas(got, `"mustComplete":true`) || jsonHas(got, "reduceOnly") {
+		t.Fatalf("create body = %s", got)
+	}
+	if _, err := api.CreateOrder(ctx, twap("ETHUSDT")); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := api.ListOrders(ctx, strategyapi.ListOrdersRequest{Symbol: "BTCUSDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].MasterOrderId != created.MasterOrderId {
+		t.Fatalf("list = %+v", list)
+	}
+	detail, err := api.GetOrder(ctx, created.MasterOrderId)
+	if err != nil || detail.MasterOrder.Symbol != "BTCUSDT" {
+		t.Fatalf("detail = %+v, err = %v", detail, err)
+	}
+	if _, err := api.CancelOrder(ctx, created.MasterOrderId, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.ListFills(ctx, strategyapi.ListFillsRequest{MasterOrderId: created.MasterOrderId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.ListPairs(ctx, strategyapi.ListPairsRequest{Exchange: trading_enums.ExchangeBinance}); err != nil {
+		t.Fatal(err)
+	}
+
+	lk, err := api.CreateListenKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := api.Subscribe(ctx, lk.ListenKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if err := srv.WaitForWSClients(1, time.Second); err != nil || !stream.IsConnected() {
+		t.Fatalf("stream connected = %v, err = %v", stream.IsConnected(), err)
+	}
+}
+
+// cancelStale is business code that only needs Orders.
+func cancelStale(ctx context.Context, orders strategyapi.Orders, symbol string) (int, error) {
+	list, err := orders.ListOrders(ctx, strategyapi.ListOrdersRequest{Symbol: symbol, Status: qe.MasterOrderStatusV2Paused})
+	if err != nil {
+		return 0, err
+	}
+	for _, o := range list.Items {
+		if _, err := orders.CancelOrder(ctx, o.MasterOrderId, "stale"); err != nil {
+			return 0, err
+		}
+	}
+	return len(list.Items), nil
+}
+
+func TestMockOrders(t *testing.T) {
+	var cancelled []string
+	m := &strategyapimock.Orders{
+		ListOrdersFunc: func(ctx context.Context, req strategyapi.ListOrdersRequest, opts ...qe.RequestOption) (*qe.GetMasterOrdersV2Reply, error) {
+			return &qe.GetMasterOrdersV2Reply{Items: []qe.MasterOrderV2Info{{MasterOrderId: "mo-1"}, {MasterOrderId: "mo-2"}}}, nil
+		},
+		CancelOrderFunc: func(ctx context.Context, id, reason string, opts ...qe.RequestOption) (*qe.MasterOrderActionV2Reply, error) {
+			cancelled = append(cancelled, id)
+			return &qe.MasterOrderActionV2Reply{}, nil
+		},
+	}
+	n, err := cancelStale(context.Background(), m, "BTCUSDT")
+	if err != nil || n != 2 || len(cancelled) != 2 {
+		t.Fatalf("n = %d, cancelled = %v, err = %v", n, cancelled, err)
+	}
+	calls := m.Calls()
+	if len(calls) != 3 || calls[0].Method != "ListOrders" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	if req := calls[0].Args[1].(strategyapi.ListOrdersRequest); req.Symbol != "BTCUSDT" {
+		t.Fatalf("ListOrders request = %+v", req)
+	}
+
+	// A method without a Func panics.
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	_, _ = m.GetOrder(context.Background(), "mo-1")
+}
+
+// dryRun is a decorator: it embeds the real Orders and overrides writes.
+type dryRun struct {
+	strategyapi.Orders
+}
+
+func (dryRun) CreateOrder(context.Context, strategyapi.CreateOrderRequest, ...qe.RequestOption) (*qe.CreateMasterOrderV2Reply, error) {
+	return nil, errors.New("dry run")
+}
+
+func TestDecorator(t *testing.T) {
+	api := &strategyapimock.StrategyAPI{}
+	api.Orders.GetOrderFunc = func(ctx context.Context, id string, opts ...qe.RequestOption) (*qe.GetMasterOrderDetailV2Reply, error) {
+		return &qe.GetMasterOrderDetailV2Reply{}, nil
+	}
+	var orders strategyapi.Orders = dryRun{api}
+	if _, err := orders.CreateOrder(context.Background(), twap("BTCUSDT")); err == nil {
+		t.Fatal("dry run created an order")
+	}
+	if _, err := orders.GetOrder(context.Background(), "mo-1"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(api.Orders.Calls()); n != 1 {
+		t.Fatalf("%d calls reached the wrapped API", n)
+	}
+}
@@ -0,0 +1,295 @@
+package qe_connector
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalTCA computes TCAAnalysisV2Info metrics on the client side from a
+// master order and its child order fills, incrementally while the order is
+// still running. GetTCAAnalysisV2Service only returns server rows once an
+// order has finished (and they can lag); LocalTCA lets callers watch
+// intra-execution slippage and cross-check the server numbers with
+// CompareTCA.
+//
+// Fills can be fed from GetOrderFillsV2Service pages (AddFill) or from the
+// WS order_data stream:
+//
+//	handlers.OnOrderFillDetail = tca.HandleOrderFillDetail
+//
+// Each child order is keyed by its `id`; a later update for the same child
+// replaces the earlier one, so cumulative WS pushes are not double counted.
+//
+// Computed fields: OrderQuantity, OrderNotional, ArrivalPrice,
+// FilledQuantity, FilledNotional, MakerFilledNotional, TakerFilledNotional,
+// MakerRate, ExecutionRate, ChildOrderCount, AverageFillPrice, Slippage and
+// SlippagePct; TwapSlippagePct / VwapSlippagePct when Benchmarks is set.
+// Market-data metrics (Spread, IntervalReturn, ParticipationRate, far-touch
+// variants, FeeSavingPct) are left at zero.
+//
+// Sign convention: slippage is positive when the execution beat the arrival
+// price (bought below / sold above it).
+type LocalTCA struct {
+	mu sync.Mutex
+
+	masterOrderId string
+	strategy      string
+	category      string
+	startTime     string
+	finishedTime  string
+	side          float64
+	orderQty      float64
+	orderNotional float64
+	byNotional    bool
+
+	arrivalPrice float64
+	twap, vwap   float64
+	isMaker      func(orderType string) bool
+
+	children map[string]tcaChild
+	// anonymous counts fills without an id; each is kept under its own
+	// "_anon-<n>" key, which cannot clash with a server-assigned id.
+	anonymous int
+}
+
+type tcaChild struct {
+	qty      float64
+	notional float64
+	maker    bool
+}
+
+// NewLocalTCA starts a local TCA computation for order.
+func NewLocalTCA(order *MasterOrderV2Info) *LocalTCA {
+	t := &LocalTCA{
+		isMaker:  defaultTCAMakerClassifier,
+		children: map[string]tcaChild{},
+	}
+	t.UpdateMasterOrder(order)
+	return t
+}
+
+// ArrivalPrice sets the benchmark price at order arrival (e.g. the mid price
+// when the order was created). If it is never set, the average price of the
+// first filled child order is used as a proxy.
+func (t *LocalTCA) ArrivalPrice(price float64) *LocalTCA {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.arrivalPrice = price
+	return t
+}
+
+// Benchmarks sets market TWAP / VWAP over the execution window so that
+// TwapSlippagePct / VwapSlippagePct are computed. Zero leaves a metric unset.
+func (t *LocalTCA) Benchmarks(twap, vwap float64) *LocalTCA {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.twap, t.vwap = twap, vwap
+	return t
+}
+
+// MakerClassifier overrides how a child order's `orderType` is classified
+// as maker (passive) or taker. By default MARKET / IOC / FOK / TAKER order
+// types count as taker and everything else as maker.
+func (t *LocalTCA) MakerClassifier(isMaker func(orderType string) bool) *LocalTCA {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.isMaker = isMaker
+	return t
+}
+
+// UpdateMasterOrder refreshes the order-level fields (sizing, status times),
+// e.g. after an UpdateMasterOrderParamsV2Service call.
+func (t *LocalTCA) UpdateMasterOrder(order *MasterOrderV2Info) {
+	if order == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.masterOrderId = order.MasterOrderId
+	t.strategy = string(order.Algorithm)
+	t.category = string(order.Category)
+	t.startTime = order.CreatedAt
+	t.side = tcaSideSign(string(order.Side))
+	if order.FinishedMs != nil && order.FinishedMs.Int64() > 0 {
+		t.finishedTime = time.UnixMilli(order.FinishedMs.Int64()).UTC().Format(time.RFC3339)
+	}
+	t.orderQty, t.orderNotional, t.byNotional = 0, 0, false
+	if order.TotalQuantity != nil {
+		t.orderQty = parseTCAFloat(*order.TotalQuantity)
+	}
+	if order.OrderNotional != nil {
+		t.orderNotional = parseTCAFloat(*order.OrderNotional)
+		t.byNotional = t.orderQty == 0
+	}
+}
+
+// AddFill adds or replaces one child order from the REST fills list.
+func (t *LocalTCA) AddFill(fill *OrderFillV2Info) {
+	if fill == nil {
+		return
+	}
+	t.addChild(fill.MasterOrderId, fill.Id, fill.OrderType,
+		fill.FilledQuantity.String(), fill.FilledNotional.String(), fill.AveragePrice.String())
+}
+
+// AddFills adds a page of child orders.
+func (t *LocalTCA) AddFills(fills []OrderFillV2Info) {
+	for i := range fills {
+		t.AddFill(&fills[i])
+	}
+}
+
+// HandleOrderFillDetail feeds a WS order_data push. Its signature matches
+// WebSocketEventHandlers.OnOrderFillDetail; pushes for other master orders
+// are ignored.
+func (t *LocalTCA) HandleOrderFillDetail(msg *WsOrderFillDetail) error {
+	if msg == nil {
+		return nil
+	}
+	t.addChild(msg.MasterOrderID, msg.ID, msg.OrderType,
+		msg.FilledQuantity.String(), msg.FilledNotional.String(), msg.AveragePrice.String())
+	return nil
+}
+
+func (t *LocalTCA) addChild(masterOrderId, id, orderType, qty, notional, avgPrice string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.masterOrderId != "" && masterOrderId != "" && masterOrderId != t.masterOrderId {
+		return
+	}
+	c := tcaChild{
+		qty:      parseTCAFloat(qty),
+		notional: parseTCAFloat(notional),
+		maker:    t.isMaker(orderType),
+	}
+	if c.notional == 0 && c.qty > 0 {
+		c.notional = c.qty * parseTCAFloat(avgPrice)
+	}
+	if t.arrivalPrice == 0 && c.qty > 0 {
+		t.arrivalPrice = c.notional / c.qty
+	}
+	if id == "" {
+		t.anonymous++
+		id = "_anon-" + strconv.Itoa(t.anonymous)
+	}
+	t.children[id] = c
+}
+
+// Snapshot returns the metrics computed from everything seen so far.
+func (t *LocalTCA) Snapshot() *TCAAnalysisV2Info {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := &TCAAnalysisV2Info{
+		MasterOrderId:   t.masterOrderId,
+		StartTime:       t.startTime,
+		FinishedTime:    t.finishedTime,
+		Strategy:        t.strategy,
+		Category:        t.category,
+		OrderQuantity:   t.orderQty,
+		OrderNotional:   t.orderNotional,
+		ArrivalPrice:    t.arrivalPrice,
+		ChildOrderCount: int32(len(t.children)),
+	}
+	if ts, err := time.Parse(time.RFC3339, t.startTime); err == nil {
+		res.Date = ts.UTC().Format("20060102")
+	}
+	for _, c := range t.children {
+		res.FilledQuantity += c.qty
+		res.FilledNotional += c.notional
+		if c.maker {
+			res.MakerFilledNotional += c.notional
+		} else {
+			res.TakerFilledNotional += c.notional
+		}
+	}
+	if res.FilledNotional > 0 {
+		res.MakerRate = res.MakerFilledNotional / res.FilledNotional
+	}
+	switch {
+	case t.byNotional && t.orderNotional > 0:
+		res.ExecutionRate = res.FilledNotional / t.orderNotional
+	case t.orderQty > 0:
+		res.ExecutionRate = res.FilledQuantity / t.orderQty
+	}
+	if res.FilledQuantity > 0 {
+		res.AverageFillPrice = res.FilledNotional / res.FilledQuantity
+		if t.arrivalPrice > 0 {
+			res.Slippage = t.side * (t.arrivalPrice - res.AverageFillPrice)
+			res.SlippagePct = res.Slippage / t.arrivalPrice
+		}
+		if t.twap > 0 {
+			res.TwapSlippagePct = t.side * (t.twap - res.AverageFillPrice) / t.twap
+		}
+		if t.vwap > 0 {
+			res.VwapSlippagePct = t.side * (t.vwap - res.AverageFillPrice) / t.vwap
+		}
+	}
+	return res
+}
+
+// TCADifference is one metric on which a local and a server TCA row
+// disagree.
+type TCADifference struct {
+	Field  string
+	Local  float64
+	Server float64
+}
+
+// CompareTCA cross-checks a LocalTCA snapshot against a server row from
+// GetTCAAnalysisV2Service. A field differs when the relative gap exceeds
+// relTolerance (e.g. 0.001 for 0.1%). Fields the local engine does not
+// compute are skipped. The result is sorted by field name.
+func CompareTCA(local, server *TCAAnalysisV2Info, relTolerance float64) []TCADifference {
+	pairs := map[string][2]float64{
+		"filledQuantity":   {local.FilledQuantity, server.FilledQuantity},
+		"filledNotional":   {local.FilledNotional, server.FilledNotional},
+		"averageFillPrice": {local.AverageFillPrice, server.AverageFillPrice},
+		"executionRate":    {local.ExecutionRate, server.ExecutionRate},
+		"makerRate":        {local.MakerRate, server.MakerRate},
+		"childOrderCount":  {float64(local.ChildOrderCount), float64(server.ChildOrderCount)},
+	}
+	if local.ArrivalPrice > 0 && server.ArrivalPrice > 0 {
+		pairs["slippagePct"] = [2]float64{local.SlippagePct, server.SlippagePct}
+	}
+	var diffs []TCADifference
+	for field, p := range pairs {
+		scale := math.Max(math.Abs(p[0]), math.Abs(p[1]))
+		if scale == 0 {
+			continue
+		}
+		if math.Abs(p[0]-p[1])/scale > relTolerance {
+			diffs = append(diffs, TCADifference{Field: field, Local: p[0], Server: p[1]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+func defaultTCAMakerClassifier(orderType string) bool {
+	ot := strings.ToUpper(orderType)
+	for _, taker := range []string{"MARKET", "IOC", "FOK", "TAKER"} {
+		if strings.Contains(ot, taker) {
+			return false
+		}
+	}
+	return true
+}
+
+func tcaSideSign(side string) float64 {
+	if strings.EqualFold(side, "sell") {
+		return -1
+	}
+	return 1
+}
+
+func parseTCAFloat(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package qe_connector
+
+import (
+	"math"
+	"testing"
+)
+
+func tcaApprox(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestLocalTCAIncrementalMetrics(t *testing.T) {
+	qty := "2"
+	tca := NewLocalTCA(&MasterOrderV2Info{
+		MasterOrderId: "mo_1",
+		Side:          "buy",
+		Algorithm:     "TWAP",
+		Category:      "SPOT",
+		TotalQuantity: &qty,
+		CreatedAt:     "2026-10-19T08:00:00Z",
+	}).ArrivalPrice(100)
+
+	tca.AddFills([]OrderFillV2Info{
+		{Id: "c1", MasterOrderId: "mo_1", OrderType: "LIMIT", FilledQuantity: "0.5", FilledNotional: "49.5"},
+		{Id: "c2", MasterOrderId: "mo_1", OrderType: "MARKET", FilledQuantity: "0.5", AveragePrice: "100.5"},
+	})
+	// A cumulative WS update for c1 replaces the earlier snapshot; a push
+	// for another master order is ignored.
+	_ = tca.HandleOrderFillDetail(&WsOrderFillDetail{ID: "c1", MasterOrderID: "mo_1", OrderType: "LIMIT", FilledQuantity: "1", FilledNotional: "99"})
+	_ = tca.HandleOrderFillDetail(&WsOrderFillDetail{ID: "x", MasterOrderID: "mo_other", FilledQuantity: "5", FilledNotional: "500"})
+
+	got := tca.Snapshot()
+	if got.ChildOrderCount != 2 || !tcaApprox(got.FilledQuantity, 1.5) || !tcaApprox(got.FilledNotional, 149.25) {
+		t.Fatalf("fills aggregated wrongly: %#v", got)
+	}
+	if !tcaApprox(got.ExecutionRate, 0.75) || !tcaApprox(got.MakerRate, 99/149.25) {
+		t.Fatalf("rates = exec %v maker %v", got.ExecutionRate, got.MakerRate)
+	}
+	if !tcaApprox(got.AverageFillPrice, 99.5) || !tcaApprox(got.Slippage, 0.5) || !tcaApprox(got.SlippagePct, 0.005) {
+		t.Fatalf("price metrics = avg %v slip %v pct %v", got.AverageFillPrice, got.Slippage, got.SlippagePct)
+	}
+	if got.Date != "20261019" || got.Strategy != "TWAP" {
+		t.Fatalf("order fields = %#v", got)
+	}
+}
+
+func TestLocalTCASellNotionalAndCompare(t *testing.T) {
+	notional := "1000"
+	tca := NewLocalTCA(&MasterOrderV2Info{MasterOrderId: "mo_2", Side: "sell", OrderNotional: &notional})
+	tca.AddFill(&OrderFillV2Info{Id: "c1", FilledQuantity: "2", FilledNotional: "202", OrderType: "IOC"})
+	tca.AddFill(&OrderFillV2Info{Id: "c2", FilledQuantity: "2", FilledNotional: "198"})
+
+	got := tca.Snapshot()
+	// Arrival falls back to the first fill (101); selling at 100 is adverse.
+	if !tcaApprox(got.ArrivalPrice, 101) || !tcaApprox(got.Slippage, -1) || !tcaApprox(got.ExecutionRate, 0.4) {
+		t.Fatalf("snapshot = %#v", got)
+	}
+
+	server := *got
+	server.MakerRate = 0.6
+	server.TwapSlippagePct = 0.3 // not computed locally, must be ignored
+	diffs := CompareTCA(got, &server, 0.001)
+	if len(diffs) != 1 || diffs[0].Field != "makerRate" || !tcaApprox(diffs[0].Local, 198.0/400) {
+		t.Fatalf("CompareTCA() = %#v", diffs)
+	}
+}
+
+func TestLocalTCAKeepsFillsWithoutId(t *testing.T) {
+	tca := NewLocalTCA(&MasterOrderV2Info{MasterOrderId: "mo_1", Side: "buy"})
+	// An id-less fill must not overwrite a child whose id happens to be
+	// the number of children seen so far.
+	tca.AddFills([]OrderFillV2Info{{Id: "1", FilledQuantity: "1", FilledNotional: "100"}})
+	tca.AddFills([]OrderFillV2Info{{FilledQuantity: "2", FilledNotional: "200"}})
+	tca.AddFills([]OrderFillV2Info{{FilledQuantity: "3", FilledNotional: "300"}})
+
+	got := tca.Snapshot()
+	if got.ChildOrderCount != 3 || !tcaApprox(got.FilledQuantity, 6) {
+		t.Fatalf("count %d qty %v, want 3 children and qty 6", got.ChildOrderCount, got.FilledQuantity)
+	}
+}
@@ -0,0 +1,224 @@
+package tcareport
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultOutlierThreshold is the robust z-score above which an order is
+// reported as an outlier within its group.
+const DefaultOutlierThreshold = 3.5
+
+// Stats summarises one metric within a group.
+type Stats struct {
+	Count        int
+	WeightedMean float64
+	Mean         float64
+	StdDev       float64
+	Min          float64
+	Max          float64
+	P10          float64
+	P50          float64
+	P90          float64
+}
+
+// Outlier is an order whose metric value sits far from its group's median.
+type Outlier struct {
+	MasterOrderId string
+	Metric        string
+	Value         float64
+	// Score is the robust z-score |x - median| / (1.4826 * MAD).
+	Score float64
+}
+
+// Group is the aggregate of all rows sharing the same dimension values.
+type Group struct {
+	// Key holds one value per Report.Dimensions entry.
+	Key            []string
+	Orders         int
+	FilledNotional float64
+	// Metrics is keyed by Metric.Name.
+	Metrics  map[string]Stats
+	Outliers []Outlier
+}
+
+// Report is the result of Aggregator.Aggregate.
+type Report struct {
+	Dimensions []Dimension
+	Metrics    []Metric
+	Groups     []Group
+}
+
+// Aggregator groups TCA rows and computes per-group statistics.
+type Aggregator struct {
+	dimensions       []Dimension
+	metrics          []Metric
+	outlierThreshold float64
+}
+
+// New creates an aggregator grouping by dims. With no dims every row lands
+// in a single group.
+func New(dims ...Dimension) *Aggregator {
+	return &Aggregator{
+		dimensions:       dims,
+		metrics:          DefaultMetrics,
+		outlierThreshold: DefaultOutlierThreshold,
+	}
+}
+
+// Metrics replaces the metrics to compute.
+func (a *Aggregator) Metrics(metrics ...Metric) *Aggregator {
+	a.metrics = metrics
+	return a
+}
+
+// OutlierThreshold sets the robust z-score threshold; 0 disables outlier
+// detection.
+func (a *Aggregator) OutlierThreshold(z float64) *Aggregator {
+	a.outlierThreshold = z
+	return a
+}
+
+// Aggregate groups rows and returns the report with groups sorted by key.
+func (a *Aggregator) Aggregate(rows []Row) *Report {
+	byKey := map[string][]Row{}
+	keys := map[string][]string{}
+	for _, r := range rows {
+		if r.TCAAnalysisV2Info == nil {
+			continue
+		}
+		key := make([]string, len(a.dimensions))
+		for i, d := range a.dimensions {
+			key[i] = d.value(r)
+		}
+		k := strings.Join(key, "\x00")
+		byKey[k] = append(byKey[k], r)
+		keys[k] = key
+	}
+
+	ids := make([]string, 0, len(byKey))
+	for k := range byKey {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+
+	report := &Report{Dimensions: a.dimensions, Metrics: a.metrics}
+	for _, k := range ids {
+		report.Groups = append(report.Groups, a.group(keys[k], byKey[k]))
+	}
+	return report
+}
+
+func (a *Aggregator) group(key []string, rows []Row) Group {
+	g := Group{Key: key, Orders: len(rows), Metrics: map[string]Stats{}}
+	for _, r := range rows {
+		g.FilledNotional += r.FilledNotional
+	}
+	for _, m := range a.metrics {
+		values := make([]float64, len(rows))
+		weights := make([]float64, len(rows))
+		for i, r := range rows {
+			values[i] = m.Value(r)
+			weights[i] = 1
+			if m.Weight != nil {
+				weights[i] = m.Weight(r)
+			}
+		}
+		g.Metrics[m.Name] = summarise(values, weights)
+		if a.outlierThreshold > 0 {
+			for i, score := range robustZ(values) {
+				if score > a.outlierThreshold {
+					g.Outliers = append(g.Outliers, Outlier{
+						MasterOrderId: rows[i].MasterOrderId,
+						Metric:        m.Name,
+						Value:         values[i],
+						Score:         score,
+					})
+				}
+			}
+		}
+	}
+	return g
+}
+
+func summarise(values, weights []float64) Stats {
+	s := Stats{Count: len(values)}
+	if len(values) == 0 {
+		return s
+	}
+	var sum, wsum, wtotal float64
+	s.Min, s.Max = math.Inf(1), math.Inf(-1)
+	for i, v := range values {
+		sum += v
+		wsum += v * weights[i]
+		wtotal += weights[i]
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+	}
+	s.Mean = sum / float64(len(values))
+	s.WeightedMean = s.Mean
+	if wtotal > 0 {
+		s.WeightedMean = wsum / wtotal
+	}
+	var sq float64
+	for _, v := range values {
+		sq += (v - s.Mean) * (v - s.Mean)
+	}
+	if len(values) > 1 {
+		s.StdDev = math.Sqrt(sq / float64(len(values)-1))
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	s.P10 = Percentile(sorted, 10)
+	s.P50 = Percentile(sorted, 50)
+	s.P90 = Percentile(sorted, 90)
+	return s
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted values using
+// linear interpolation between closest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 100 {
+		return sorted[len(sorted)-1]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	frac := rank - float64(lo)
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
+
+// robustZ scores each value by its distance from the median in units of the
+// scaled median absolute deviation. When MAD is zero (most values equal) no
+// value is scored.
+func robustZ(values []float64) []float64 {
+	scores := make([]float64, len(values))
+	if len(values) < 3 {
+		return scores
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	median := Percentile(sorted, 50)
+	dev := make([]float64, len(values))
+	for i, v := range values {
+		dev[i] = math.Abs(v - median)
+	}
+	sort.Float64s(dev)
+	mad := Percentile(dev, 50) * 1.4826
+	if mad == 0 {
+		return scores
+	}
+	for i, v := range values {
+		scores[i] = math.Abs(v-median) / mad
+	}
+	return scores
+}
@@ -0,0 +1,104 @@
+package tcareport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// table flattens the report into a header and rows shared by all writers:
+// one column per dimension, then orders, filled notional, then weighted
+// mean / p10 / p50 / p90 per metric and the outlier count.
+func (r *Report) table() ([]string, [][]string) {
+	header := make([]string, 0, len(r.Dimensions)+3+4*len(r.Metrics))
+	for _, d := range r.Dimensions {
+		header = append(header, string(d))
+	}
+	header = append(header, "orders", "filled_notional")
+	for _, m := range r.Metrics {
+		header = append(header, m.Name+"_wavg", m.Name+"_p10", m.Name+"_p50", m.Name+"_p90")
+	}
+	header = append(header, "outliers")
+
+	rows := make([][]string, 0, len(r.Groups))
+	for _, g := range r.Groups {
+		row := append([]string(nil), g.Key...)
+		row = append(row, strconv.Itoa(g.Orders), formatFloat(g.FilledNotional))
+		for _, m := range r.Metrics {
+			s := g.Metrics[m.Name]
+			row = append(row, formatFloat(s.WeightedMean), formatFloat(s.P10), formatFloat(s.P50), formatFloat(s.P90))
+		}
+		row = append(row, strconv.Itoa(len(g.Outliers)))
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// WriteCSV writes the report as CSV with a header row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	header, rows := r.table()
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WriteMarkdown writes the report as a GitHub-flavoured Markdown table.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	header, rows := r.table()
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+	line := func(cells []string) string {
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = escape.Replace(c)
+		}
+		return "| " + strings.Join(out, " | ") + " |\n"
+	}
+	var b strings.Builder
+	b.WriteString(line(header))
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+		if i >= len(r.Dimensions) {
+			sep[i] = "---:"
+		}
+	}
+	b.WriteString("|" + strings.Join(sep, "|") + "|\n")
+	for _, row := range rows {
+		b.WriteString(line(row))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHTML writes the report as a standalone HTML <table>.
+func (r *Report) WriteHTML(w io.Writer) error {
+	header, rows := r.table()
+	var b strings.Builder
+	b.WriteString("<table>\n<thead><tr>")
+	for _, h := range header {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(h))
+	}
+	b.WriteString("</tr></thead>\n<tbody>\n")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(c))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,163 @@
+// Package tcareport aggregates TCAAnalysisV2Info rows from many master
+// orders into grouped statistics for periodic execution reviews: notional
+// weighted slippage in bps, TWAP / VWAP slippage distributions, maker rate
+// and fee saving, grouped by strategy, category, symbol, api key and day,
+// with percentile and outlier detection. Reports export to CSV, Markdown and
+// HTML tables.
+//
+//	rows, err := tcareport.Fetch(ctx, client, tcareport.Query{
+//		Symbols: []string{"BTCUSDT", "ETHUSDT"},
+//		Start:   weekStart, End: weekEnd,
+//	})
+//	report := tcareport.New(tcareport.ByStrategy, tcareport.BySymbol).Aggregate(rows)
+//	err = report.WriteMarkdown(os.Stdout)
+package tcareport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+// Row is one TCA result plus the labels the TCA endpoint does not echo back
+// (symbol and api key are query filters, not response fields).
+type Row struct {
+	*qe.TCAAnalysisV2Info
+	Symbol   string
+	ApiKeyId string
+}
+
+// Tag wraps rows returned by a GetTCAAnalysisV2Service query that was
+// filtered by symbol and/or apiKeyId so they can be grouped on those labels.
+func Tag(infos []*qe.TCAAnalysisV2Info, symbol, apiKeyId string) []Row {
+	rows := make([]Row, 0, len(infos))
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		rows = append(rows, Row{TCAAnalysisV2Info: info, Symbol: symbol, ApiKeyId: apiKeyId})
+	}
+	return rows
+}
+
+// Query selects the TCA rows to fetch.
+type Query struct {
+	// Symbols and ApiKeyIds are queried one combination at a time so every
+	// row can be labelled; leave them empty to fetch without that filter
+	// (the label is then empty).
+	Symbols   []string
+	ApiKeyIds []string
+	Category  string
+	Strategy  string
+	Start     time.Time
+	End       time.Time
+}
+
+// Fetch runs GetTCAAnalysisV2Service for every symbol / api key combination
+// in q and returns the labelled rows.
+func Fetch(ctx context.Context, c *qe.Client, q Query, opts ...qe.RequestOption) ([]Row, error) {
+	symbols := q.Symbols
+	if len(symbols) == 0 {
+		symbols = []string{""}
+	}
+	apiKeyIds := q.ApiKeyIds
+	if len(apiKeyIds) == 0 {
+		apiKeyIds = []string{""}
+	}
+	var rows []Row
+	for _, symbol := range symbols {
+		for _, apiKeyId := range apiKeyIds {
+			svc := c.NewGetTCAAnalysisV2Service()
+			if symbol != "" {
+				svc.Symbol(symbol)
+			}
+			if apiKeyId != "" {
+				svc.ApiKeyId(apiKeyId)
+			}
+			if q.Category != "" {
+				svc.Category(q.Category)
+			}
+			if q.Strategy != "" {
+				svc.Strategy(q.Strategy)
+			}
+			if !q.Start.IsZero() {
+				svc.StartTime(q.Start.UnixMilli())
+			}
+			if !q.End.IsZero() {
+				svc.EndTime(q.End.UnixMilli())
+			}
+			infos, err := svc.Do(ctx, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("fetch tca symbol=%q apiKeyId=%q: %w", symbol, apiKeyId, err)
+			}
+			rows = append(rows, Tag(infos, symbol, apiKeyId)...)
+		}
+	}
+	return rows, nil
+}
+
+// Dimension is a group-by key.
+type Dimension string
+
+const (
+	ByStrategy Dimension = "strategy"
+	ByCategory Dimension = "category"
+	BySymbol   Dimension = "symbol"
+	ByApiKey   Dimension = "apiKeyId"
+	// ByDay groups on the row's `date` (YYYYMMDD), falling back to the UTC
+	// day of StartTime.
+	ByDay Dimension = "day"
+)
+
+func (d Dimension) value(r Row) string {
+	switch d {
+	case ByStrategy:
+		return r.Strategy
+	case ByCategory:
+		return r.Category
+	case BySymbol:
+		return r.Symbol
+	case ByApiKey:
+		return r.ApiKeyId
+	case ByDay:
+		if r.Date != "" {
+			return r.Date
+		}
+		if ts, err := time.Parse(time.RFC3339, r.StartTime); err == nil {
+			return ts.UTC().Format("20060102")
+		}
+	}
+	return ""
+}
+
+// Metric extracts one per-order value. Values are weighted by Weight when
+// computing the weighted mean; a nil Weight means equal weights.
+type Metric struct {
+	Name   string
+	Value  func(r Row) float64
+	Weight func(r Row) float64
+}
+
+func filledNotional(r Row) float64 { return r.FilledNotional }
+
+// Built-in metrics. *Pct fields are fractions, so bps = value * 1e4. All are
+// weighted by filled notional.
+var (
+	SlippageBps = Metric{Name: "slippage_bps", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.SlippagePct * 1e4 }}
+	TwapSlippageBps = Metric{Name: "twap_slippage_bps", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.TwapSlippagePct * 1e4 }}
+	VwapSlippageBps = Metric{Name: "vwap_slippage_bps", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.VwapSlippagePct * 1e4 }}
+	MakerRate = Metric{Name: "maker_rate", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.MakerRate }}
+	FeeSavingBps = Metric{Name: "fee_saving_bps", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.FeeSavingPct * 1e4 }}
+	ExecutionRate = Metric{Name: "execution_rate", Weight: filledNotional,
+		Value: func(r Row) float64 { return r.ExecutionRate }}
+)
+
+// DefaultMetrics is used when Aggregator.Metrics is not called.
+var DefaultMetrics = []Metric{SlippageBps, TwapSlippageBps, VwapSlippageBps, MakerRate, FeeSavingBps}
@@ -0,0 +1,123 @@
+package tcareport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qe "github.com/Quantum-Execute/qe-connector-go"
+)
+
+func tca(id, strategy string, notional, slippagePct, makerRate float64) *qe.TCAAnalysisV2Info {
+	return &qe.TCAAnalysisV2Info{
+		MasterOrderId:  id,
+		Strategy:       strategy,
+		Category:       "spot",
+		FilledNotional: notional,
+		SlippagePct:    slippagePct,
+		MakerRate:      makerRate,
+		Date:           "20261019",
+	}
+}
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+func TestAggregateWeightedMeansPercentilesAndOutliers(t *testing.T) {
+	rows := append(Tag([]*qe.TCAAnalysisV2Info{
+		tca("t1", "TWAP", 1000, 0.0001, 0.5),
+		tca("t2", "TWAP", 3000, 0.0003, 0.7),
+		tca("t3", "TWAP", 1000, 0.0002, 0.6),
+		tca("t4", "TWAP", 1000, 0.0002, 0.6),
+		tca("t5", "TWAP", 1000, -0.0050, 0.6),
+	}, "BTCUSDT", "k1"), Tag([]*qe.TCAAnalysisV2Info{
+		tca("v1", "VWAP", 2000, 0.0004, 0.9),
+	}, "ETHUSDT", "k1")...)
+
+	report := New(ByStrategy).Metrics(SlippageBps, MakerRate).Aggregate(rows)
+	if len(report.Groups) != 2 || report.Groups[0].Key[0] != "TWAP" || report.Groups[1].Key[0] != "VWAP" {
+		t.Fatalf("groups = %#v", report.Groups)
+	}
+	twap := report.Groups[0]
+	if twap.Orders != 5 || twap.FilledNotional != 7000 {
+		t.Fatalf("twap group = %#v", twap)
+	}
+	slip := twap.Metrics["slippage_bps"]
+	// (1*1000 + 3*3000 + 2*1000 + 2*1000 - 50*1000) / 7000
+	if !approx(slip.WeightedMean, -36.0/7) || !approx(slip.P50, 2) || !approx(slip.Min, -50) || slip.Count != 5 {
+		t.Fatalf("slippage stats = %#v", slip)
+	}
+	if !approx(twap.Metrics["maker_rate"].WeightedMean, (500+2100+600+600+600)/7000.0) {
+		t.Fatalf("maker rate = %#v", twap.Metrics["maker_rate"])
+	}
+	if len(twap.Outliers) != 1 || twap.Outliers[0].MasterOrderId != "t5" || twap.Outliers[0].Metric != "slippage_bps" {
+		t.Fatalf("outliers = %#v", twap.Outliers)
+	}
+
+	bySymbolDay := New(BySymbol, ByDay).Aggregate(rows)
+	if len(bySymbolDay.Groups) != 2 || strings.Join(bySymbolDay.Groups[1].Key, "/") != "ETHUSDT/20261019" {
+		t.Fatalf("symbol/day groups = %#v", bySymbolDay.Groups)
+	}
+
+	if got := Percentile([]float64{1, 2, 3, 4}, 90); !approx(got, 3.7) {
+		t.Fatalf("Percentile = %v", got)
+	}
+}
+
+func TestReportExports(t *testing.T) {
+	rows := Tag([]*qe.TCAAnalysisV2Info{tca("a", "TW|AP<x>", 100, 0.001, 1)}, "", "")
+	report := New(ByStrategy).Metrics(SlippageBps).Aggregate(rows)
+
+	var csvOut, md, htmlOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if err := report.WriteMarkdown(&md); err != nil {
+		t.Fatal(err)
+	}
+	if err := report.WriteHTML(&htmlOut); err != nil {
+		t.Fatal(err)
+	}
+	wantHeader := "strategy,orders,filled_notional,slippage_bps_wavg,slippage_bps_p10,slippage_bps_p50,slippage_bps_p90,outliers"
+	if !strings.HasPrefix(csvOut.String(), wantHeader+"\nTW|AP<x>,1,100.0000,10.0000,") {
+		t.Fatalf("csv = %q", csvOut.String())
+	}
+	if !strings.Contains(md.String(), "|---|---:|") || !strings.Contains(md.String(), `| TW\|AP<x> | 1 |`) {
+		t.Fatalf("markdown = %q", md.String())
+	}
+	if !strings.Contains(htmlOut.String(), "<td>TW|AP&lt;x&gt;</td>") {
+		t.Fatalf("html = %q", htmlOut.String())
+	}
+}
+
+func TestFetchLabelsRowsPerSymbolAndApiKey(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q.Get("symbol")+"/"+q.Get("apiKeyId")+"/"+q.Get("strategy"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":    200,
+			"message": []map[string]interface{}{{"masterOrderId": "mo_" + q.Get("symbol"), "filledNotional": 10}},
+		})
+	}))
+	defer srv.Close()
+
+	rows, err := Fetch(context.Background(), qe.NewClient("k", "s", srv.URL), Query{
+		Symbols:   []string{"BTCUSDT", "ETHUSDT"},
+		ApiKeyIds: []string{"k1"},
+		Strategy:  "TWAP",
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if strings.Join(queries, ",") != "BTCUSDT/k1/TWAP,ETHUSDT/k1/TWAP" {
+		t.Fatalf("queries = %v", queries)
+	}
+	if len(rows) != 2 || rows[1].Symbol != "ETHUSDT" || rows[1].ApiKeyId != "k1" || rows[1].MasterOrderId != "mo_ETHUSDT" {
+		t.Fatalf("rows = %#v", rows)
+	}
+}
@@ -2,7 +2,6 @@ package qe_connector
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -20,12 +19,7 @@ func (s *TimestampService) Do(ctx context.Context, opts ...RequestOption) (res i
 	}
 	m := params{}
 	r.setParams(m)
-	data, err := s.c.callAPI(ctx, r, opts...)
-	if err != nil {
-		return 0, err
-	}
-	resp := new(TimestampMessage)
-	err = json.Unmarshal(data, &resp)
+	resp, err := callAPIAs[TimestampMessage](ctx, s.c, r, opts...)
 	if err != nil {
 		return 0, err
 	}
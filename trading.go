@@ -0,0 +1,62 @@
+package qe_connector
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+// This file holds the handwritten parts of the V1 trading services; the
+// services themselves are generated into trading_gen.go from
+// api/strategy-api.yaml.
+
+// EndTime set endTime
+//
+// Deprecated: EndTime is deprecated and no longer used.
+// This method is kept for backward compatibility but does nothing.
+// The endTime field has been removed from the API.
+func (s *CreateMasterOrderService) EndTime(endTime string) *CreateMasterOrderService {
+	// No-op: endTime is deprecated and no longer sent to the API
+	return s
+}
+
+func (s *CreateMasterOrderService) check() error {
+	// Deribit special rules:
+	// - When trading BTCUSD/ETHUSD, only totalQuantity is allowed, and orderNotional is not allowed.
+	if s.exchange == trading_enums.ExchangeDeribit &&
+		(strings.EqualFold(s.symbol, "BTCUSD") || strings.EqualFold(s.symbol, "ETHUSD")) {
+		if s.orderNotional != nil {
+			return errors.New("orderNotional is not allowed when exchange is Deribit and symbol is BTCUSD or ETHUSD; use totalQuantity (unit: USD) instead")
+		}
+		if s.totalQuantity == nil {
+			return errors.New("totalQuantity is required when exchange is Deribit and symbol is BTCUSD or ETHUSD (unit: USD)")
+		}
+	}
+
+	// Binance coin-margined perp special rules:
+	// - When trading Binance PERP with marginType=C, only totalQuantity is allowed.
+	// - totalQuantity unit is contracts and must be an integer.
+	if s.exchange == trading_enums.ExchangeBinance &&
+		s.marketType == trading_enums.MarketTypePerp &&
+		s.marginType != nil &&
+		*s.marginType == trading_enums.MarginTypeC {
+		if s.orderNotional != nil {
+			return errors.New("orderNotional is not allowed when exchange is Binance and marginType is C for PERP orders; use totalQuantity (unit: contracts) instead")
+		}
+		if s.totalQuantity == nil {
+			return errors.New("totalQuantity is required when exchange is Binance and marginType is C for PERP orders (unit: contracts)")
+		}
+		if math.Trunc(*s.totalQuantity) != *s.totalQuantity {
+			return errors.New("totalQuantity must be an integer when exchange is Binance and marginType is C for PERP orders (unit: contracts)")
+		}
+	}
+
+	if s.isTargetPosition != nil && *s.isTargetPosition {
+		if s.totalQuantity == nil || s.orderNotional != nil {
+			return errors.New("totalQuantity is required and orderNotional not required when isTargetPosition is true")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,2643 @@
+// Code generated by svcgen from strategy-api.yaml; DO NOT EDIT.
+
+package qe_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+	"github.com/Quantum-Execute/qe-connector-go/dto/algorithm_dto"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// 交易类
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewListExchangeApisService creates a service for `GET /user/exchange-apis`.
+func (c *Client) NewListExchangeApisService() *ListExchangeApisService {
+	return &ListExchangeApisService{c: c}
+}
+
+// ListExchangeApisService list exchange APIs
+type ListExchangeApisService struct {
+	c        *Client
+	page     *int32
+	pageSize *int32
+	exchange *trading_enums.Exchange
+}
+
+// Page set page (optional)
+func (s *ListExchangeApisService) Page(v int32) *ListExchangeApisService {
+	s.page = &v
+	return s
+}
+
+// PageSize set pageSize (optional)
+func (s *ListExchangeApisService) PageSize(v int32) *ListExchangeApisService {
+	s.pageSize = &v
+	return s
+}
+
+// Exchange set exchange (optional)
+func (s *ListExchangeApisService) Exchange(v trading_enums.Exchange) *ListExchangeApisService {
+	s.exchange = &v
+	return s
+}
+
+// Do send request
+func (s *ListExchangeApisService) Do(ctx context.Context, opts ...RequestOption) (res *ListExchangeApisReply, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/exchange-apis",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.exchange != nil {
+		r.setParam("exchange", *s.exchange)
+	}
+	return callAPIAs[ListExchangeApisReply](ctx, s.c, r, opts...)
+}
+
+// ListExchangeApisReply list exchange APIs response
+type ListExchangeApisReply struct {
+	Items    []ExchangeApiInfo `json:"items"`
+	Total    int32             `json:"total"`
+	Page     int32             `json:"page"`
+	PageSize int32             `json:"pageSize"`
+}
+
+// ExchangeApiInfo exchange API info
+type ExchangeApiInfo struct {
+	Id                 string `json:"id"`
+	CreatedAt          string `json:"createdAt"`
+	AccountName        string `json:"accountName"`
+	Exchange           string `json:"exchange"`
+	ApiKey             string `json:"apiKey"`
+	VerificationMethod string `json:"verificationMethod"`
+	Status             string `json:"status"`
+	IsValid            bool   `json:"isValid"`
+	IsTradingEnabled   bool   `json:"isTradingEnabled"`
+	IsDefault          bool   `json:"isDefault"`
+	IsPm               bool   `json:"isPm"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrdersService creates a service for `GET /user/trading/master-orders`.
+func (c *Client) NewGetMasterOrdersService() *GetMasterOrdersService {
+	return &GetMasterOrdersService{c: c}
+}
+
+// GetMasterOrdersService get master orders
+type GetMasterOrdersService struct {
+	c         *Client
+	page      *int32
+	pageSize  *int32
+	status    *trading_enums.MasterOrderStatus
+	exchange  *string
+	symbol    *string
+	startTime *string
+	endTime   *string
+}
+
+// Page set page (optional)
+func (s *GetMasterOrdersService) Page(v int32) *GetMasterOrdersService {
+	s.page = &v
+	return s
+}
+
+// PageSize set pageSize (optional)
+func (s *GetMasterOrdersService) PageSize(v int32) *GetMasterOrdersService {
+	s.pageSize = &v
+	return s
+}
+
+// Status set status (optional)
+func (s *GetMasterOrdersService) Status(v trading_enums.MasterOrderStatus) *GetMasterOrdersService {
+	s.status = &v
+	return s
+}
+
+// Exchange set exchange (optional)
+func (s *GetMasterOrdersService) Exchange(v string) *GetMasterOrdersService {
+	s.exchange = &v
+	return s
+}
+
+// Symbol set symbol (optional)
+func (s *GetMasterOrdersService) Symbol(v string) *GetMasterOrdersService {
+	s.symbol = &v
+	return s
+}
+
+// StartTime set startTime (optional)
+func (s *GetMasterOrdersService) StartTime(v string) *GetMasterOrdersService {
+	s.startTime = &v
+	return s
+}
+
+// EndTime set endTime (optional)
+func (s *GetMasterOrdersService) EndTime(v string) *GetMasterOrdersService {
+	s.endTime = &v
+	return s
+}
+
+// Do send request
+func (s *GetMasterOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrdersReply, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/master-orders",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.status != nil {
+		r.setParam("status", *s.status)
+	}
+	if s.exchange != nil {
+		r.setParam("exchange", *s.exchange)
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	return callAPIAs[GetMasterOrdersReply](ctx, s.c, r, opts...)
+}
+
+// GetMasterOrdersReply get master orders response
+type GetMasterOrdersReply struct {
+	Items    []MasterOrderInfo `json:"items"`
+	Total    string            `json:"total"`
+	Page     int32             `json:"page"`
+	PageSize int32             `json:"pageSize"`
+}
+
+// MasterOrderInfo master order info
+type MasterOrderInfo struct {
+	MasterOrderId            string            `json:"masterOrderId"`
+	Algorithm                string            `json:"algorithm"`
+	AlgorithmType            string            `json:"algorithmType"`
+	Exchange                 string            `json:"exchange"`
+	Symbol                   string            `json:"symbol"`
+	MarketType               string            `json:"marketType"`
+	Side                     string            `json:"side"`
+	TotalQuantity            float64           `json:"totalQuantity"`
+	FilledQuantity           float64           `json:"filledQuantity"`
+	AveragePrice             float64           `json:"averagePrice"`
+	Status                   string            `json:"status"`
+	ExecutionDuration        int32             `json:"executionDuration"`
+	ExecutionDurationSeconds *int32            `json:"executionDurationSeconds,omitempty"`
+	PriceLimit               float64           `json:"priceLimit"`
+	StartTime                string            `json:"startTime"`
+	EndTime                  string            `json:"endTime"`
+	CreatedAt                string            `json:"createdAt"`
+	UpdatedAt                string            `json:"updatedAt"`
+	Notes                    string            `json:"notes"`
+	MarginType               string            `json:"marginType"`
+	ReduceOnly               bool              `json:"reduceOnly"`
+	StrategyType             string            `json:"strategyType"`
+	OrderNotional            float64           `json:"orderNotional"`
+	MustComplete             bool              `json:"mustComplete"`
+	MakerRateLimit           float64           `json:"makerRateLimit"`
+	PovLimit                 float64           `json:"povLimit"`
+	ClientId                 string            `json:"clientId"`
+	Date                     string            `json:"date"`
+	TicktimeInt              string            `json:"ticktimeInt"`
+	LimitPriceString         string            `json:"limitPriceString"`
+	UpTolerance              string            `json:"upTolerance"`
+	LowTolerance             string            `json:"lowTolerance"`
+	StrictUpBound            bool              `json:"strictUpBound"`
+	TicktimeMs               string            `json:"ticktimeMs"`
+	Category                 string            `json:"category"`
+	FilledAmount             float64           `json:"filledAmount"`
+	TotalValue               float64           `json:"totalValue"`
+	Base                     string            `json:"base"`
+	Quote                    string            `json:"quote"`
+	CompletionProgress       float64           `json:"completionProgress"`
+	Reason                   string            `json:"reason"`
+	TakerMakerRate           float64           `json:"takerMakerRate"`
+	MakerRate                float64           `json:"makerRate"`
+	TailOrderProtection      bool              `json:"tailOrderProtection"`
+	TradingAccount           string            `json:"tradingAccount"`
+	EnableMake               bool              `json:"enableMake"`
+	ClientOrderId            string            `json:"clientOrderId"`
+	FinishedMs               FlexInt64         `json:"finishedMs"`
+	WorstPrice               float64           `json:"worstPrice"`
+	PovMinLimit              float64           `json:"povMinLimit"`
+	Commission               map[string]string `json:"commission"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCreateMasterOrderService creates a service for `POST /user/trading/master-orders`.
+func (c *Client) NewCreateMasterOrderService() *CreateMasterOrderService {
+	return &CreateMasterOrderService{c: c}
+}
+
+// CreateMasterOrderService create master order
+type CreateMasterOrderService struct {
+	c                        *Client
+	algorithm                trading_enums.Algorithm
+	exchange                 trading_enums.Exchange
+	symbol                   string
+	marketType               trading_enums.MarketType
+	side                     trading_enums.OrderSide
+	totalQuantity            *float64
+	orderNotional            *float64
+	apiKeyId                 string
+	strategyType             *trading_enums.StrategyType
+	startTime                *string
+	executionDuration        *int32
+	executionDurationSeconds *int32
+	limitPrice               *float64
+	mustComplete             *bool
+	makerRateLimit           *float64
+	povLimit                 *float64
+	marginType               *trading_enums.MarginType
+	reduceOnly               *bool
+	notes                    *string
+	upTolerance              *string
+	lowTolerance             *string
+	strictUpBound            *bool
+	povMinLimit              *float64
+	tailOrderProtection      *bool
+	isTargetPosition         *bool
+	isMargin                 *bool
+	enableMake               *bool
+	clientOrderId            *string
+	worstPrice               *float64
+}
+
+// Algorithm set algorithm
+func (s *CreateMasterOrderService) Algorithm(v trading_enums.Algorithm) *CreateMasterOrderService {
+	s.algorithm = v
+	return s
+}
+
+// Exchange set exchange
+func (s *CreateMasterOrderService) Exchange(v trading_enums.Exchange) *CreateMasterOrderService {
+	s.exchange = v
+	return s
+}
+
+// Symbol set symbol
+func (s *CreateMasterOrderService) Symbol(v string) *CreateMasterOrderService {
+	s.symbol = v
+	return s
+}
+
+// MarketType set marketType
+func (s *CreateMasterOrderService) MarketType(v trading_enums.MarketType) *CreateMasterOrderService {
+	s.marketType = v
+	return s
+}
+
+// Side set side
+func (s *CreateMasterOrderService) Side(v trading_enums.OrderSide) *CreateMasterOrderService {
+	s.side = v
+	return s
+}
+
+// TotalQuantity set totalQuantity (optional)
+func (s *CreateMasterOrderService) TotalQuantity(v float64) *CreateMasterOrderService {
+	s.totalQuantity = &v
+	return s
+}
+
+// OrderNotional set orderNotional (optional)
+func (s *CreateMasterOrderService) OrderNotional(v float64) *CreateMasterOrderService {
+	s.orderNotional = &v
+	return s
+}
+
+// ApiKeyId set apiKeyId
+func (s *CreateMasterOrderService) ApiKeyId(v string) *CreateMasterOrderService {
+	s.apiKeyId = v
+	return s
+}
+
+// StrategyType set strategyType (optional)
+func (s *CreateMasterOrderService) StrategyType(v trading_enums.StrategyType) *CreateMasterOrderService {
+	s.strategyType = &v
+	return s
+}
+
+// StartTime set startTime (optional)
+func (s *CreateMasterOrderService) StartTime(v string) *CreateMasterOrderService {
+	s.startTime = &v
+	return s
+}
+
+// ExecutionDuration set executionDuration (optional)
+func (s *CreateMasterOrderService) ExecutionDuration(v int32) *CreateMasterOrderService {
+	s.executionDuration = &v
+	return s
+}
+
+// ExecutionDurationSeconds set executionDurationSeconds
+//
+// Note: Available for all strategies (TWAP-1 / POV). When provided and > 0, it takes precedence over executionDuration (minutes).
+// It must be greater than 10 seconds.
+func (s *CreateMasterOrderService) ExecutionDurationSeconds(v int32) *CreateMasterOrderService {
+	s.executionDurationSeconds = &v
+	return s
+}
+
+// LimitPrice set limitPrice
+//
+// Deprecated: Use WorstPrice instead. LimitPrice is kept for backward compatibility.
+func (s *CreateMasterOrderService) LimitPrice(v float64) *CreateMasterOrderService {
+	s.limitPrice = &v
+	return s
+}
+
+// MustComplete set mustComplete (optional)
+func (s *CreateMasterOrderService) MustComplete(v bool) *CreateMasterOrderService {
+	s.mustComplete = &v
+	return s
+}
+
+// MakerRateLimit set makerRateLimit (optional)
+func (s *CreateMasterOrderService) MakerRateLimit(v float64) *CreateMasterOrderService {
+	s.makerRateLimit = &v
+	return s
+}
+
+// PovLimit set povLimit (optional)
+func (s *CreateMasterOrderService) PovLimit(v float64) *CreateMasterOrderService {
+	s.povLimit = &v
+	return s
+}
+
+// MarginType set marginType (optional)
+func (s *CreateMasterOrderService) MarginType(v trading_enums.MarginType) *CreateMasterOrderService {
+	s.marginType = &v
+	return s
+}
+
+// ReduceOnly set reduceOnly (optional)
+func (s *CreateMasterOrderService) ReduceOnly(v bool) *CreateMasterOrderService {
+	s.reduceOnly = &v
+	return s
+}
+
+// Notes set notes (optional)
+func (s *CreateMasterOrderService) Notes(v string) *CreateMasterOrderService {
+	s.notes = &v
+	return s
+}
+
+// UpTolerance set upTolerance (optional)
+func (s *CreateMasterOrderService) UpTolerance(v string) *CreateMasterOrderService {
+	s.upTolerance = &v
+	return s
+}
+
+// LowTolerance set lowTolerance (optional)
+func (s *CreateMasterOrderService) LowTolerance(v string) *CreateMasterOrderService {
+	s.lowTolerance = &v
+	return s
+}
+
+// StrictUpBound set strictUpBound (optional)
+func (s *CreateMasterOrderService) StrictUpBound(v bool) *CreateMasterOrderService {
+	s.strictUpBound = &v
+	return s
+}
+
+// PovMinLimit set povMinLimit (optional)
+func (s *CreateMasterOrderService) PovMinLimit(v float64) *CreateMasterOrderService {
+	s.povMinLimit = &v
+	return s
+}
+
+// TailOrderProtection set tailOrderProtection (optional)
+func (s *CreateMasterOrderService) TailOrderProtection(v bool) *CreateMasterOrderService {
+	s.tailOrderProtection = &v
+	return s
+}
+
+// IsTargetPosition set isTargetPosition (optional)
+func (s *CreateMasterOrderService) IsTargetPosition(v bool) *CreateMasterOrderService {
+	s.isTargetPosition = &v
+	return s
+}
+
+// IsMargin set isMargin (optional)
+func (s *CreateMasterOrderService) IsMargin(v bool) *CreateMasterOrderService {
+	s.isMargin = &v
+	return s
+}
+
+// EnableMake set enableMake (optional)
+func (s *CreateMasterOrderService) EnableMake(v bool) *CreateMasterOrderService {
+	s.enableMake = &v
+	return s
+}
+
+// ClientOrderId set clientOrderId (optional)
+func (s *CreateMasterOrderService) ClientOrderId(v string) *CreateMasterOrderService {
+	s.clientOrderId = &v
+	return s
+}
+
+// WorstPrice set worstPrice (worst acceptable price)
+//
+// The worst acceptable trading price. For buy orders this is the maximum buy price;
+// for sell orders this is the minimum sell price. Use -1 for no limit.
+func (s *CreateMasterOrderService) WorstPrice(v float64) *CreateMasterOrderService {
+	s.worstPrice = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *CreateMasterOrderService) validate() error {
+	if s.algorithm == "" {
+		return errors.New("algorithm is required")
+	}
+	if s.exchange == "" {
+		return errors.New("exchange is required")
+	}
+	if s.symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if s.marketType == "" {
+		return errors.New("marketType is required")
+	}
+	if s.side == "" {
+		return errors.New("side is required")
+	}
+	if s.apiKeyId == "" {
+		return errors.New("apiKeyId is required")
+	}
+	return s.check()
+}
+
+// Do send request
+func (s *CreateMasterOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateMasterOrderReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/user/trading/master-orders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("algorithm", s.algorithm)
+	r.setParam("algorithmType", "TWAP")
+	r.setParam("exchange", s.exchange)
+	r.setParam("symbol", s.symbol)
+	r.setParam("marketType", s.marketType)
+	r.setParam("side", s.side)
+	if s.totalQuantity != nil {
+		r.setParam("totalQuantity", *s.totalQuantity)
+	}
+	if s.orderNotional != nil {
+		r.setParam("orderNotional", *s.orderNotional)
+	}
+	r.setParam("apiKeyId", s.apiKeyId)
+	if s.strategyType != nil {
+		r.setParam("strategyType", *s.strategyType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.executionDuration != nil {
+		r.setParam("executionDuration", *s.executionDuration)
+	}
+	if s.executionDurationSeconds != nil {
+		r.setParam("executionDurationSeconds", *s.executionDurationSeconds)
+	}
+	if s.limitPrice != nil {
+		r.setParam("limitPrice", *s.limitPrice)
+	}
+	if s.mustComplete != nil {
+		r.setParam("mustComplete", *s.mustComplete)
+	}
+	if s.makerRateLimit != nil {
+		r.setParam("makerRateLimit", *s.makerRateLimit)
+	}
+	if s.povLimit != nil {
+		r.setParam("povLimit", *s.povLimit)
+	}
+	if s.marginType != nil {
+		r.setParam("marginType", *s.marginType)
+	}
+	if s.reduceOnly != nil {
+		r.setParam("reduceOnly", *s.reduceOnly)
+	}
+	if s.notes != nil {
+		r.setParam("notes", *s.notes)
+	}
+	if s.upTolerance != nil {
+		r.setParam("upTolerance", *s.upTolerance)
+	}
+	if s.lowTolerance != nil {
+		r.setParam("lowTolerance", *s.lowTolerance)
+	}
+	if s.strictUpBound != nil {
+		r.setParam("strictUpBound", *s.strictUpBound)
+	}
+	if s.povMinLimit != nil {
+		r.setParam("povMinLimit", *s.povMinLimit)
+	}
+	if s.tailOrderProtection != nil {
+		r.setParam("tailOrderProtection", *s.tailOrderProtection)
+	} else {
+		r.setParam("tailOrderProtection", true)
+	}
+	if s.isTargetPosition != nil {
+		r.setParam("isTargetPosition", *s.isTargetPosition)
+	} else {
+		r.setParam("isTargetPosition", false)
+	}
+	if s.isMargin != nil {
+		r.setParam("isMargin", *s.isMargin)
+	}
+	if s.enableMake != nil {
+		r.setParam("enableMake", *s.enableMake)
+	} else {
+		r.setParam("enableMake", true)
+	}
+	if s.clientOrderId != nil {
+		r.setParam("clientOrderId", *s.clientOrderId)
+	}
+	if s.worstPrice != nil {
+		r.setParam("worstPrice", *s.worstPrice)
+	}
+	return callAPIAs[CreateMasterOrderReply](ctx, s.c, r, opts...)
+}
+
+// CreateMasterOrderReply create master order response
+type CreateMasterOrderReply struct {
+	MasterOrderId string `json:"masterOrderId"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrderDetailService creates a service for `GET /user/trading/master-orders/{masterOrderId}`.
+func (c *Client) NewGetMasterOrderDetailService() *GetMasterOrderDetailService {
+	return &GetMasterOrderDetailService{c: c}
+}
+
+// GetMasterOrderDetailService get master order detail
+type GetMasterOrderDetailService struct {
+	c             *Client
+	masterOrderId string
+}
+
+// MasterOrderId set masterOrderId
+func (s *GetMasterOrderDetailService) MasterOrderId(v string) *GetMasterOrderDetailService {
+	s.masterOrderId = v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetMasterOrderDetailService) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *GetMasterOrderDetailService) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrderDetailReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/%s", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[GetMasterOrderDetailReply](ctx, s.c, r, opts...)
+}
+
+// GetMasterOrderDetailReply get master order detail response
+type GetMasterOrderDetailReply struct {
+	MasterOrder MasterOrderInfo `json:"masterOrder"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrderDetailByClientOrderIdService creates a service for `GET /user/trading/master-orders/by-client-order-id/{clientOrderId}`.
+func (c *Client) NewGetMasterOrderDetailByClientOrderIdService() *GetMasterOrderDetailByClientOrderIdService {
+	return &GetMasterOrderDetailByClientOrderIdService{c: c}
+}
+
+// GetMasterOrderDetailByClientOrderIdService get master order detail by client order id
+type GetMasterOrderDetailByClientOrderIdService struct {
+	c             *Client
+	clientOrderId string
+}
+
+// ClientOrderId set clientOrderId
+func (s *GetMasterOrderDetailByClientOrderIdService) ClientOrderId(v string) *GetMasterOrderDetailByClientOrderIdService {
+	s.clientOrderId = v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetMasterOrderDetailByClientOrderIdService) validate() error {
+	if s.clientOrderId == "" {
+		return errors.New("clientOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *GetMasterOrderDetailByClientOrderIdService) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrderDetailReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/by-client-order-id/%s", s.clientOrderId),
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[GetMasterOrderDetailReply](ctx, s.c, r, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetOrderFillsService creates a service for `GET /user/trading/order-fills`.
+func (c *Client) NewGetOrderFillsService() *GetOrderFillsService {
+	return &GetOrderFillsService{c: c}
+}
+
+// GetOrderFillsService get order fills
+type GetOrderFillsService struct {
+	c             *Client
+	page          *int32
+	pageSize      *int32
+	masterOrderId *string
+	subOrderId    *string
+	orderId       *string
+	symbol        *string
+	status        *string
+	startTime     *string
+	endTime       *string
+}
+
+// Page set page (optional)
+func (s *GetOrderFillsService) Page(v int32) *GetOrderFillsService {
+	s.page = &v
+	return s
+}
+
+// PageSize set pageSize (optional)
+func (s *GetOrderFillsService) PageSize(v int32) *GetOrderFillsService {
+	s.pageSize = &v
+	return s
+}
+
+// MasterOrderId set masterOrderId (optional)
+func (s *GetOrderFillsService) MasterOrderId(v string) *GetOrderFillsService {
+	s.masterOrderId = &v
+	return s
+}
+
+// SubOrderId set subOrderId (optional)
+func (s *GetOrderFillsService) SubOrderId(v string) *GetOrderFillsService {
+	s.subOrderId = &v
+	return s
+}
+
+// OrderId set orderId (optional, exchange order ID filter)
+func (s *GetOrderFillsService) OrderId(v string) *GetOrderFillsService {
+	s.orderId = &v
+	return s
+}
+
+// Symbol set symbol (optional)
+func (s *GetOrderFillsService) Symbol(v string) *GetOrderFillsService {
+	s.symbol = &v
+	return s
+}
+
+// Status set status (optional)
+func (s *GetOrderFillsService) Status(v string) *GetOrderFillsService {
+	s.status = &v
+	return s
+}
+
+// StartTime set startTime (optional)
+func (s *GetOrderFillsService) StartTime(v string) *GetOrderFillsService {
+	s.startTime = &v
+	return s
+}
+
+// EndTime set endTime (optional)
+func (s *GetOrderFillsService) EndTime(v string) *GetOrderFillsService {
+	s.endTime = &v
+	return s
+}
+
+// Do send request
+func (s *GetOrderFillsService) Do(ctx context.Context, opts ...RequestOption) (res *GetOrderFillsReply, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/order-fills",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.masterOrderId != nil {
+		r.setParam("masterOrderId", *s.masterOrderId)
+	}
+	if s.subOrderId != nil {
+		r.setParam("subOrderId", *s.subOrderId)
+	}
+	if s.orderId != nil {
+		r.setParam("orderId", *s.orderId)
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.status != nil {
+		r.setParam("status", *s.status)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	return callAPIAs[GetOrderFillsReply](ctx, s.c, r, opts...)
+}
+
+// GetOrderFillsReply get order fills response
+type GetOrderFillsReply struct {
+	Items    []OrderFillInfo `json:"items"`
+	Total    string          `json:"total"`
+	Page     int32           `json:"page"`
+	PageSize int32           `json:"pageSize"`
+}
+
+// OrderFillInfo order fill info
+type OrderFillInfo struct {
+	Id               string  `json:"id"`
+	OrderCreatedTime string  `json:"orderCreatedTime"`
+	MasterOrderId    string  `json:"masterOrderId"`
+	Exchange         string  `json:"exchange"`
+	Category         string  `json:"category"`
+	Symbol           string  `json:"symbol"`
+	Side             string  `json:"side"`
+	FilledValue      float64 `json:"filledValue"`
+	FilledQuantity   float64 `json:"filledQuantity"`
+	AvgPrice         float64 `json:"avgPrice"`
+	Price            float64 `json:"price"`
+	Fee              float64 `json:"fee"`
+	TradingAccount   string  `json:"tradingAccount"`
+	Status           string  `json:"status"`
+	RejectReason     string  `json:"rejectReason"`
+	Base             string  `json:"base"`
+	Quote            string  `json:"quote"`
+	Type             string  `json:"type"`
+	OrderId          string  `json:"orderId"`
+	Quantity         float64 `json:"quantity"`
+	CreatedAt        string  `json:"createdAt"`
+	UpdatedAt        string  `json:"updatedAt"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCancelMasterOrderService creates a service for `PUT /user/trading/master-orders/{masterOrderId}/cancel`.
+func (c *Client) NewCancelMasterOrderService() *CancelMasterOrderService {
+	return &CancelMasterOrderService{c: c}
+}
+
+// CancelMasterOrderService cancel master order
+type CancelMasterOrderService struct {
+	c             *Client
+	masterOrderId string
+	reason        *string
+}
+
+// MasterOrderId set masterOrderId
+func (s *CancelMasterOrderService) MasterOrderId(v string) *CancelMasterOrderService {
+	s.masterOrderId = v
+	return s
+}
+
+// Reason set reason (optional)
+func (s *CancelMasterOrderService) Reason(v string) *CancelMasterOrderService {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *CancelMasterOrderService) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *CancelMasterOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelMasterOrderReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/%s/cancel", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	r.setParam("masterOrderId", s.masterOrderId)
+	if s.reason != nil {
+		r.setParam("reason", *s.reason)
+	}
+	return callAPIAs[CancelMasterOrderReply](ctx, s.c, r, opts...)
+}
+
+// CancelMasterOrderReply cancel master order response
+type CancelMasterOrderReply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewPauseMasterOrderService creates a service for `PUT /user/trading/master-orders/{masterOrderId}/pause`.
+func (c *Client) NewPauseMasterOrderService() *PauseMasterOrderService {
+	return &PauseMasterOrderService{c: c}
+}
+
+// PauseMasterOrderService pause a running master order
+type PauseMasterOrderService struct {
+	c             *Client
+	masterOrderId string
+	reason        *string
+}
+
+// MasterOrderId set masterOrderId
+func (s *PauseMasterOrderService) MasterOrderId(v string) *PauseMasterOrderService {
+	s.masterOrderId = v
+	return s
+}
+
+// Reason set reason (optional)
+func (s *PauseMasterOrderService) Reason(v string) *PauseMasterOrderService {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *PauseMasterOrderService) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *PauseMasterOrderService) Do(ctx context.Context, opts ...RequestOption) (res *PauseMasterOrderReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/%s/pause", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	r.setParam("masterOrderId", s.masterOrderId)
+	if s.reason != nil {
+		r.setParam("reason", *s.reason)
+	}
+	return callAPIAs[PauseMasterOrderReply](ctx, s.c, r, opts...)
+}
+
+// PauseMasterOrderReply pause master order response
+type PauseMasterOrderReply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewResumeMasterOrderService creates a service for `PUT /user/trading/master-orders/{masterOrderId}/resume`.
+func (c *Client) NewResumeMasterOrderService() *ResumeMasterOrderService {
+	return &ResumeMasterOrderService{c: c}
+}
+
+// ResumeMasterOrderService resume a paused master order
+type ResumeMasterOrderService struct {
+	c             *Client
+	masterOrderId string
+}
+
+// MasterOrderId set masterOrderId
+func (s *ResumeMasterOrderService) MasterOrderId(v string) *ResumeMasterOrderService {
+	s.masterOrderId = v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *ResumeMasterOrderService) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *ResumeMasterOrderService) Do(ctx context.Context, opts ...RequestOption) (res *ResumeMasterOrderReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/%s/resume", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	r.setParam("masterOrderId", s.masterOrderId)
+	return callAPIAs[ResumeMasterOrderReply](ctx, s.c, r, opts...)
+}
+
+// ResumeMasterOrderReply resume master order response
+type ResumeMasterOrderReply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewUpdateMasterOrderParamsService creates a service for `PUT /user/trading/master-orders/{masterOrderId}/update`.
+func (c *Client) NewUpdateMasterOrderParamsService() *UpdateMasterOrderParamsService {
+	return &UpdateMasterOrderParamsService{c: c}
+}
+
+// UpdateMasterOrderParamsService update parameters of a running master order
+type UpdateMasterOrderParamsService struct {
+	c                        *Client
+	masterOrderId            string
+	orderNotional            *float64
+	totalQuantity            *float64
+	upTolerance              *string
+	lowTolerance             *string
+	enableMake               *bool
+	makerRateLimit           *float64
+	strictUpBound            *bool
+	povLimit                 *float64
+	povMinLimit              *float64
+	limitPrice               *float64
+	worstPrice               *float64
+	tailOrderProtection      *bool
+	mustComplete             *bool
+	executionDurationSeconds *int32
+	executionDuration        *int32
+}
+
+// MasterOrderId set masterOrderId
+func (s *UpdateMasterOrderParamsService) MasterOrderId(v string) *UpdateMasterOrderParamsService {
+	s.masterOrderId = v
+	return s
+}
+
+// OrderNotional set orderNotional (optional)
+func (s *UpdateMasterOrderParamsService) OrderNotional(v float64) *UpdateMasterOrderParamsService {
+	s.orderNotional = &v
+	return s
+}
+
+// TotalQuantity set totalQuantity (optional)
+func (s *UpdateMasterOrderParamsService) TotalQuantity(v float64) *UpdateMasterOrderParamsService {
+	s.totalQuantity = &v
+	return s
+}
+
+// UpTolerance set upTolerance (optional)
+func (s *UpdateMasterOrderParamsService) UpTolerance(v string) *UpdateMasterOrderParamsService {
+	s.upTolerance = &v
+	return s
+}
+
+// LowTolerance set lowTolerance (optional)
+func (s *UpdateMasterOrderParamsService) LowTolerance(v string) *UpdateMasterOrderParamsService {
+	s.lowTolerance = &v
+	return s
+}
+
+// EnableMake set enableMake (optional)
+func (s *UpdateMasterOrderParamsService) EnableMake(v bool) *UpdateMasterOrderParamsService {
+	s.enableMake = &v
+	return s
+}
+
+// MakerRateLimit set makerRateLimit (optional)
+func (s *UpdateMasterOrderParamsService) MakerRateLimit(v float64) *UpdateMasterOrderParamsService {
+	s.makerRateLimit = &v
+	return s
+}
+
+// StrictUpBound set strictUpBound (optional)
+func (s *UpdateMasterOrderParamsService) StrictUpBound(v bool) *UpdateMasterOrderParamsService {
+	s.strictUpBound = &v
+	return s
+}
+
+// PovLimit set povLimit (optional)
+func (s *UpdateMasterOrderParamsService) PovLimit(v float64) *UpdateMasterOrderParamsService {
+	s.povLimit = &v
+	return s
+}
+
+// PovMinLimit set povMinLimit (optional)
+func (s *UpdateMasterOrderParamsService) PovMinLimit(v float64) *UpdateMasterOrderParamsService {
+	s.povMinLimit = &v
+	return s
+}
+
+// LimitPrice set limitPrice (optional)
+func (s *UpdateMasterOrderParamsService) LimitPrice(v float64) *UpdateMasterOrderParamsService {
+	s.limitPrice = &v
+	return s
+}
+
+// WorstPrice set worstPrice (optional)
+func (s *UpdateMasterOrderParamsService) WorstPrice(v float64) *UpdateMasterOrderParamsService {
+	s.worstPrice = &v
+	return s
+}
+
+// TailOrderProtection set tailOrderProtection (optional)
+func (s *UpdateMasterOrderParamsService) TailOrderProtection(v bool) *UpdateMasterOrderParamsService {
+	s.tailOrderProtection = &v
+	return s
+}
+
+// MustComplete set mustComplete (optional)
+func (s *UpdateMasterOrderParamsService) MustComplete(v bool) *UpdateMasterOrderParamsService {
+	s.mustComplete = &v
+	return s
+}
+
+// ExecutionDurationSeconds set executionDurationSeconds (in seconds, must be > 10).
+// Mutually exclusive with ExecutionDuration — only one of the two may be provided.
+func (s *UpdateMasterOrderParamsService) ExecutionDurationSeconds(v int32) *UpdateMasterOrderParamsService {
+	s.executionDurationSeconds = &v
+	return s
+}
+
+// ExecutionDuration set executionDuration (in minutes, must be >= 1).
+// Mutually exclusive with ExecutionDurationSeconds — only one of the two may be provided.
+func (s *UpdateMasterOrderParamsService) ExecutionDuration(v int32) *UpdateMasterOrderParamsService {
+	s.executionDuration = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *UpdateMasterOrderParamsService) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *UpdateMasterOrderParamsService) Do(ctx context.Context, opts ...RequestOption) (res *UpdateMasterOrderParamsReply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("/user/trading/master-orders/%s/update", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	r.setParam("masterOrderId", s.masterOrderId)
+	if s.orderNotional != nil {
+		r.setParam("orderNotional", *s.orderNotional)
+	}
+	if s.totalQuantity != nil {
+		r.setParam("totalQuantity", *s.totalQuantity)
+	}
+	if s.upTolerance != nil {
+		r.setParam("upTolerance", *s.upTolerance)
+	}
+	if s.lowTolerance != nil {
+		r.setParam("lowTolerance", *s.lowTolerance)
+	}
+	if s.enableMake != nil {
+		r.setParam("enableMake", *s.enableMake)
+	}
+	if s.makerRateLimit != nil {
+		r.setParam("makerRateLimit", *s.makerRateLimit)
+	}
+	if s.strictUpBound != nil {
+		r.setParam("strictUpBound", *s.strictUpBound)
+	}
+	if s.povLimit != nil {
+		r.setParam("povLimit", *s.povLimit)
+	}
+	if s.povMinLimit != nil {
+		r.setParam("povMinLimit", *s.povMinLimit)
+	}
+	if s.limitPrice != nil {
+		r.setParam("limitPrice", *s.limitPrice)
+	}
+	if s.worstPrice != nil {
+		r.setParam("worstPrice", *s.worstPrice)
+	}
+	if s.tailOrderProtection != nil {
+		r.setParam("tailOrderProtection", *s.tailOrderProtection)
+	}
+	if s.mustComplete != nil {
+		r.setParam("mustComplete", *s.mustComplete)
+	}
+	if s.executionDurationSeconds != nil {
+		r.setParam("executionDurationSeconds", *s.executionDurationSeconds)
+	}
+	if s.executionDuration != nil {
+		r.setParam("executionDuration", *s.executionDuration)
+	}
+	return callAPIAs[UpdateMasterOrderParamsReply](ctx, s.c, r, opts...)
+}
+
+// UpdateMasterOrderParamsReply update master order params response
+type UpdateMasterOrderParamsReply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCreateListenKeyService creates a service for `POST /user/trading/listen-key`.
+func (c *Client) NewCreateListenKeyService() *CreateListenKeyService {
+	return &CreateListenKeyService{c: c}
+}
+
+// CreateListenKeyService create listen key
+type CreateListenKeyService struct {
+	c *Client
+}
+
+// Do send request
+func (s *CreateListenKeyService) Do(ctx context.Context, opts ...RequestOption) (res *CreateListenKeyReply, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/user/trading/listen-key",
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[CreateListenKeyReply](ctx, s.c, r, opts...)
+}
+
+// CreateListenKeyReply create listen key response
+type CreateListenKeyReply struct {
+	ListenKey string `json:"listenKey"`
+	ExpireAt  string `json:"expireAt"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetTcaAnalysisService creates a service for `GET /user/trading/tca-analysis`.
+func (c *Client) NewGetTcaAnalysisService() *GetTcaAnalysisService {
+	return &GetTcaAnalysisService{c: c}
+}
+
+// GetTcaAnalysisService get TCA analysis full data list
+type GetTcaAnalysisService struct {
+	c         *Client
+	symbol    *string
+	category  *string
+	apikey    *string
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol set symbol (optional)
+func (s *GetTcaAnalysisService) Symbol(v string) *GetTcaAnalysisService {
+	s.symbol = &v
+	return s
+}
+
+// Category set category (optional)
+func (s *GetTcaAnalysisService) Category(v string) *GetTcaAnalysisService {
+	s.category = &v
+	return s
+}
+
+// Apikey set apikey (optional, comma-separated supported by server)
+func (s *GetTcaAnalysisService) Apikey(v string) *GetTcaAnalysisService {
+	s.apikey = &v
+	return s
+}
+
+// StartTime set startTime (optional, unix milli)
+func (s *GetTcaAnalysisService) StartTime(v int64) *GetTcaAnalysisService {
+	s.startTime = &v
+	return s
+}
+
+// EndTime set endTime (optional, unix milli)
+func (s *GetTcaAnalysisService) EndTime(v int64) *GetTcaAnalysisService {
+	s.endTime = &v
+	return s
+}
+
+// Do send request
+func (s *GetTcaAnalysisService) Do(ctx context.Context, opts ...RequestOption) (res []*algorithm_dto.TCAAnalysisResponse, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/tca-analysis",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.category != nil {
+		r.setParam("category", *s.category)
+	}
+	if s.apikey != nil {
+		r.setParam("apikey", *s.apikey)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	rows, err := callAPIAs[[]*algorithm_dto.TCAAnalysisResponse](ctx, s.c, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if *rows == nil {
+		return make([]*algorithm_dto.TCAAnalysisResponse, 0), nil
+	}
+	return *rows, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// 交易类V2
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCreateListenKeyV2Service creates a service for `POST /user/trading/v2/listen-key`.
+func (c *Client) NewCreateListenKeyV2Service() *CreateListenKeyV2Service {
+	return &CreateListenKeyV2Service{c: c}
+}
+
+// CreateListenKeyV2Service creates a listen key through the V2 route.
+type CreateListenKeyV2Service struct {
+	c *Client
+}
+
+// Do send request
+func (s *CreateListenKeyV2Service) Do(ctx context.Context, opts ...RequestOption) (res *CreateListenKeyReply, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/user/trading/v2/listen-key",
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[CreateListenKeyReply](ctx, s.c, r, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewListExchangeApisV2Service creates a service for `GET /user/exchange/v2/exchange-apis`.
+func (c *Client) NewListExchangeApisV2Service() *ListExchangeApisV2Service {
+	return &ListExchangeApisV2Service{c: c}
+}
+
+// ListExchangeApisV2Service queries V2 exchange API key bindings.
+type ListExchangeApisV2Service struct {
+	c        *Client
+	page     *int32
+	pageSize *int32
+	exchange *trading_enums.Exchange
+}
+
+// Page sets the 1-based page number.
+func (s *ListExchangeApisV2Service) Page(v int32) *ListExchangeApisV2Service {
+	s.page = &v
+	return s
+}
+
+// PageSize sets the number of items per page. Values above 100 are rejected.
+func (s *ListExchangeApisV2Service) PageSize(v int32) *ListExchangeApisV2Service {
+	s.pageSize = &v
+	return s
+}
+
+// Exchange filters by exchange name.
+func (s *ListExchangeApisV2Service) Exchange(v trading_enums.Exchange) *ListExchangeApisV2Service {
+	s.exchange = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *ListExchangeApisV2Service) validate() error {
+	return s.check()
+}
+
+// Do send request
+func (s *ListExchangeApisV2Service) Do(ctx context.Context, opts ...RequestOption) (res *ListExchangeApisV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/exchange/v2/exchange-apis",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.exchange != nil {
+		r.setParam("exchange", *s.exchange)
+	}
+	return callAPIAs[ListExchangeApisV2Reply](ctx, s.c, r, opts...)
+}
+
+// ListExchangeApisV2Reply is the response of `GET /user/exchange/v2/exchange-apis`.
+type ListExchangeApisV2Reply struct {
+	Items    []ExchangeApiV2Info `json:"items"`
+	Total    int32               `json:"total"`
+	Page     int32               `json:"page"`
+	PageSize int32               `json:"pageSize"`
+}
+
+// ExchangeApiV2Info is the per-row payload for V2 exchange API keys. V2 hides
+// `verificationMethod` and `balance` compared with V1.
+type ExchangeApiV2Info struct {
+	ApiKeyId         string `json:"apiKeyId"`
+	ApiKeyUuid       string `json:"-"` // Deprecated: use ApiKeyId.
+	Id               string `json:"-"` // Deprecated: use ApiKeyId.
+	CreatedAt        string `json:"createdAt"`
+	AccountName      string `json:"accountName"`
+	Exchange         string `json:"exchange"`
+	ApiKey           string `json:"apiKey"`
+	Status           string `json:"status"`
+	IsValid          bool   `json:"isValid"`
+	IsTradingEnabled bool   `json:"isTradingEnabled"`
+	IsDefault        bool   `json:"isDefault"`
+	IsPm             bool   `json:"isPm"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCreateMasterOrderV2Service creates a service for `POST /user/trading/v2/master-orders`.
+func (c *Client) NewCreateMasterOrderV2Service() *CreateMasterOrderV2Service {
+	return &CreateMasterOrderV2Service{c: c}
+}
+
+// CreateMasterOrderV2Service creates a V2 master order.
+//
+// Required fields are `apiKeyId`, `exchange`, `marketType`, `symbol`, `side`,
+// `algorithm`, and `executionDurationSeconds` (> 10). Exactly one of
+// `totalQuantity` or `orderNotional` must be set; when `isTargetPosition` is
+// true `totalQuantity` is mandatory.
+type CreateMasterOrderV2Service struct {
+	c                        *Client
+	apiKeyId                 string
+	exchange                 trading_enums.Exchange
+	marketType               trading_enums.MarketType
+	symbol                   string
+	side                     trading_enums.OrderSide
+	algorithm                trading_enums.Algorithm
+	executionDurationSeconds *int64
+	startTimeMs              *int64
+	totalQuantity            *string
+	orderNotional            *string
+	marginType               *trading_enums.MarginType
+	reduceOnly               *bool
+	isMargin                 *bool
+	worstPrice               *string
+	mustComplete             *bool
+	makerRateLimit           *string
+	povLimit                 *string
+	povMinLimit              *string
+	upTolerance              *string
+	lowTolerance             *string
+	strictUpBound            *bool
+	tailOrderProtection      *bool
+	enableMake               *bool
+	isTargetPosition         *bool
+	clientOrderId            *string
+	notes                    *string
+}
+
+// ApiKeyId sets the required exchange API Key binding ID.
+func (s *CreateMasterOrderV2Service) ApiKeyId(v string) *CreateMasterOrderV2Service {
+	s.apiKeyId = v
+	return s
+}
+
+// Exchange sets the required exchange.
+func (s *CreateMasterOrderV2Service) Exchange(v trading_enums.Exchange) *CreateMasterOrderV2Service {
+	s.exchange = v
+	return s
+}
+
+// MarketType sets the required market type (`SPOT` or `PERP`).
+func (s *CreateMasterOrderV2Service) MarketType(v trading_enums.MarketType) *CreateMasterOrderV2Service {
+	s.marketType = v
+	return s
+}
+
+// Symbol sets the required trading pair (e.g. `BTCUSDT`).
+func (s *CreateMasterOrderV2Service) Symbol(v string) *CreateMasterOrderV2Service {
+	s.symbol = v
+	return s
+}
+
+// Side sets the required side. In normal mode it's the trade direction; in
+// target-position mode it's the position side.
+func (s *CreateMasterOrderV2Service) Side(v trading_enums.OrderSide) *CreateMasterOrderV2Service {
+	s.side = v
+	return s
+}
+
+// Algorithm sets the required trading algorithm (`TWAP`, `VWAP`, `POV`).
+func (s *CreateMasterOrderV2Service) Algorithm(v trading_enums.Algorithm) *CreateMasterOrderV2Service {
+	s.algorithm = v
+	return s
+}
+
+// ExecutionDurationSeconds sets the maximum execution duration in seconds.
+// Must be > 10. V2 always uses seconds (V1 mixed minutes and seconds).
+func (s *CreateMasterOrderV2Service) ExecutionDurationSeconds(v int64) *CreateMasterOrderV2Service {
+	s.executionDurationSeconds = &v
+	return s
+}
+
+// StartTimeMs sets the execution start time in epoch milliseconds. Omit for
+// immediate execution.
+func (s *CreateMasterOrderV2Service) StartTimeMs(v int64) *CreateMasterOrderV2Service {
+	s.startTimeMs = &v
+	return s
+}
+
+// TotalQuantity sets the trade quantity as a decimal string (V2 sends Decimal
+// values as strings to avoid JS float precision issues).
+func (s *CreateMasterOrderV2Service) TotalQuantity(v string) *CreateMasterOrderV2Service {
+	s.totalQuantity = &v
+	return s
+}
+
+// OrderNotional sets the trade notional as a decimal string.
+func (s *CreateMasterOrderV2Service) OrderNotional(v string) *CreateMasterOrderV2Service {
+	s.orderNotional = &v
+	return s
+}
+
+// MarginType sets the contract margin type (`U` / `C`); required for `PERP`.
+func (s *CreateMasterOrderV2Service) MarginType(v trading_enums.MarginType) *CreateMasterOrderV2Service {
+	s.marginType = &v
+	return s
+}
+
+// ReduceOnly sets reduce-only mode (PERP only).
+func (s *CreateMasterOrderV2Service) ReduceOnly(v bool) *CreateMasterOrderV2Service {
+	s.reduceOnly = &v
+	return s
+}
+
+// IsMargin enables spot-margin mode (SPOT only).
+func (s *CreateMasterOrderV2Service) IsMargin(v bool) *CreateMasterOrderV2Service {
+	s.isMargin = &v
+	return s
+}
+
+// WorstPrice sets the worst acceptable price as a decimal string.
+func (s *CreateMasterOrderV2Service) WorstPrice(v string) *CreateMasterOrderV2Service {
+	s.worstPrice = &v
+	return s
+}
+
+// MustComplete toggles whether the order must finish within executionDurationSeconds.
+func (s *CreateMasterOrderV2Service) MustComplete(v bool) *CreateMasterOrderV2Service {
+	s.mustComplete = &v
+	return s
+}
+
+// MakerRateLimit sets the minimum maker fill ratio as a decimal string (0-1; -1 for auto).
+func (s *CreateMasterOrderV2Service) MakerRateLimit(v string) *CreateMasterOrderV2Service {
+	s.makerRateLimit = &v
+	return s
+}
+
+// PovLimit sets the participation rate cap as a decimal string. Defaults to
+// 0.05 for POV and 1 otherwise.
+func (s *CreateMasterOrderV2Service) PovLimit(v string) *CreateMasterOrderV2Service {
+	s.povLimit = &v
+	return s
+}
+
+// PovMinLimit sets the participation rate floor as a decimal string (POV only).
+func (s *CreateMasterOrderV2Service) PovMinLimit(v string) *CreateMasterOrderV2Service {
+	s.povMinLimit = &v
+	return s
+}
+
+// UpTolerance sets the upper progress tolerance as a decimal string.
+func (s *CreateMasterOrderV2Service) UpTolerance(v string) *CreateMasterOrderV2Service {
+	s.upTolerance = &v
+	return s
+}
+
+// LowTolerance sets the lower progress tolerance as a decimal string.
+func (s *CreateMasterOrderV2Service) LowTolerance(v string) *CreateMasterOrderV2Service {
+	s.lowTolerance = &v
+	return s
+}
+
+// StrictUpBound enables strict upper-bound enforcement.
+func (s *CreateMasterOrderV2Service) StrictUpBound(v bool) *CreateMasterOrderV2Service {
+	s.strictUpBound = &v
+	return s
+}
+
+// TailOrderProtection toggles tail-order protection (default true).
+func (s *CreateMasterOrderV2Service) TailOrderProtection(v bool) *CreateMasterOrderV2Service {
+	s.tailOrderProtection = &v
+	return s
+}
+
+// EnableMake toggles maker orders (default true; false → all taker).
+func (s *CreateMasterOrderV2Service) EnableMake(v bool) *CreateMasterOrderV2Service {
+	s.enableMake = &v
+	return s
+}
+
+// IsTargetPosition switches into target-position mode (forces TotalQuantity).
+func (s *CreateMasterOrderV2Service) IsTargetPosition(v bool) *CreateMasterOrderV2Service {
+	s.isTargetPosition = &v
+	return s
+}
+
+// ClientOrderId sets the user-defined order ID (for idempotency / tracking).
+func (s *CreateMasterOrderV2Service) ClientOrderId(v string) *CreateMasterOrderV2Service {
+	s.clientOrderId = &v
+	return s
+}
+
+// Notes sets a free-form note attached to the order.
+func (s *CreateMasterOrderV2Service) Notes(v string) *CreateMasterOrderV2Service {
+	s.notes = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *CreateMasterOrderV2Service) validate() error {
+	if s.apiKeyId == "" {
+		return errors.New("apiKeyId is required")
+	}
+	if s.exchange == "" {
+		return errors.New("exchange is required")
+	}
+	if s.marketType == "" {
+		return errors.New("marketType is required")
+	}
+	if s.symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if s.side == "" {
+		return errors.New("side is required")
+	}
+	if s.algorithm == "" {
+		return errors.New("algorithm is required")
+	}
+	return s.check()
+}
+
+// Do send request
+func (s *CreateMasterOrderV2Service) Do(ctx context.Context, opts ...RequestOption) (res *CreateMasterOrderV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	body["apiKeyId"] = s.apiKeyId
+	body["exchange"] = s.exchange
+	body["marketType"] = s.marketType
+	body["symbol"] = s.symbol
+	body["side"] = s.side
+	body["algorithm"] = s.algorithm
+	if s.executionDurationSeconds != nil {
+		body["executionDurationSeconds"] = *s.executionDurationSeconds
+	}
+	if s.startTimeMs != nil {
+		body["startTimeMs"] = *s.startTimeMs
+	}
+	if s.totalQuantity != nil {
+		body["totalQuantity"] = *s.totalQuantity
+	}
+	if s.orderNotional != nil {
+		body["orderNotional"] = *s.orderNotional
+	}
+	if s.marginType != nil {
+		body["marginType"] = *s.marginType
+	}
+	if s.reduceOnly != nil {
+		body["reduceOnly"] = *s.reduceOnly
+	}
+	if s.isMargin != nil {
+		body["isMargin"] = *s.isMargin
+	}
+	if s.worstPrice != nil {
+		body["worstPrice"] = *s.worstPrice
+	}
+	if s.mustComplete != nil {
+		body["mustComplete"] = *s.mustComplete
+	}
+	if s.makerRateLimit != nil {
+		body["makerRateLimit"] = *s.makerRateLimit
+	}
+	if s.povLimit != nil {
+		body["povLimit"] = *s.povLimit
+	} else {
+		body["povLimit"] = defaultPovLimitForAlgorithmV2(s.algorithm)
+	}
+	if s.povMinLimit != nil {
+		body["povMinLimit"] = *s.povMinLimit
+	}
+	if s.upTolerance != nil {
+		body["upTolerance"] = *s.upTolerance
+	}
+	if s.lowTolerance != nil {
+		body["lowTolerance"] = *s.lowTolerance
+	}
+	if s.strictUpBound != nil {
+		body["strictUpBound"] = *s.strictUpBound
+	}
+	if s.tailOrderProtection != nil {
+		body["tailOrderProtection"] = *s.tailOrderProtection
+	}
+	if s.enableMake != nil {
+		body["enableMake"] = *s.enableMake
+	}
+	if s.isTargetPosition != nil {
+		body["isTargetPosition"] = *s.isTargetPosition
+	}
+	if s.clientOrderId != nil {
+		body["clientOrderId"] = *s.clientOrderId
+	}
+	if s.notes != nil {
+		body["notes"] = *s.notes
+	}
+	return callAPIV2As[CreateMasterOrderV2Reply](ctx, s.c, http.MethodPost, "/user/trading/v2/master-orders", body, opts...)
+}
+
+// CreateMasterOrderV2Reply is the response of `POST /user/trading/v2/master-orders`.
+type CreateMasterOrderV2Reply struct {
+	MasterOrderId string              `json:"masterOrderId"`
+	Status        MasterOrderStatusV2 `json:"status"`
+	ClientOrderId string              `json:"clientOrderId"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrdersV2Service creates a service for `GET /user/trading/v2/master-orders`.
+func (c *Client) NewGetMasterOrdersV2Service() *GetMasterOrdersV2Service {
+	return &GetMasterOrdersV2Service{c: c}
+}
+
+// GetMasterOrdersV2Service queries the V2 master order list.
+type GetMasterOrdersV2Service struct {
+	c             *Client
+	page          *int32
+	pageSize      *int32
+	status        *MasterOrderStatusV2
+	exchange      *string
+	symbol        *string
+	algorithm     *trading_enums.Algorithm
+	apiKeyId      *string
+	startTime     *string
+	endTime       *string
+	masterOrderId *string
+}
+
+// Page sets the 1-based page number.
+func (s *GetMasterOrdersV2Service) Page(v int32) *GetMasterOrdersV2Service {
+	s.page = &v
+	return s
+}
+
+// PageSize sets the per-page count. Values above 100 are rejected.
+func (s *GetMasterOrdersV2Service) PageSize(v int32) *GetMasterOrdersV2Service {
+	s.pageSize = &v
+	return s
+}
+
+// Status filters by master order status.
+//
+// 注意：列表查询的过滤只接受聚合值 MasterOrderStatusV2New（=运行中所有状态）
+// 或 MasterOrderStatusV2Completed（=非运行中所有状态）。传其它细分状态会被
+// 后端按字面值匹配，结果通常为空——这一点与详情/推送里返回的 9 种细分状态
+// 不同（详见 `MasterOrderStatusV2` 类型注释）。
+func (s *GetMasterOrdersV2Service) Status(v MasterOrderStatusV2) *GetMasterOrdersV2Service {
+	s.status = &v
+	return s
+}
+
+// Exchange filters by exchange.
+func (s *GetMasterOrdersV2Service) Exchange(v string) *GetMasterOrdersV2Service {
+	s.exchange = &v
+	return s
+}
+
+// Symbol filters by trading pair.
+func (s *GetMasterOrdersV2Service) Symbol(v string) *GetMasterOrdersV2Service {
+	s.symbol = &v
+	return s
+}
+
+// Algorithm filters by algorithm.
+func (s *GetMasterOrdersV2Service) Algorithm(v trading_enums.Algorithm) *GetMasterOrdersV2Service {
+	s.algorithm = &v
+	return s
+}
+
+// ApiKeyId filters by exchange API key binding ID.
+func (s *GetMasterOrdersV2Service) ApiKeyId(v string) *GetMasterOrdersV2Service {
+	s.apiKeyId = &v
+	return s
+}
+
+// StartTime sets the lower bound (RFC3339 / ISO 8601).
+func (s *GetMasterOrdersV2Service) StartTime(v string) *GetMasterOrdersV2Service {
+	s.startTime = &v
+	return s
+}
+
+// EndTime sets the upper bound (RFC3339 / ISO 8601).
+func (s *GetMasterOrdersV2Service) EndTime(v string) *GetMasterOrdersV2Service {
+	s.endTime = &v
+	return s
+}
+
+// MasterOrderId filters by exact master order ID.
+func (s *GetMasterOrdersV2Service) MasterOrderId(v string) *GetMasterOrdersV2Service {
+	s.masterOrderId = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetMasterOrdersV2Service) validate() error {
+	return s.check()
+}
+
+// Do send request
+func (s *GetMasterOrdersV2Service) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrdersV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/v2/master-orders",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.status != nil {
+		r.setParam("status", *s.status)
+	}
+	if s.exchange != nil {
+		r.setParam("exchange", *s.exchange)
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.algorithm != nil {
+		r.setParam("algorithm", *s.algorithm)
+	}
+	if s.apiKeyId != nil {
+		r.setParam("apiKeyId", *s.apiKeyId)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.masterOrderId != nil {
+		r.setParam("masterOrderId", *s.masterOrderId)
+	}
+	return callAPIAs[GetMasterOrdersV2Reply](ctx, s.c, r, opts...)
+}
+
+// GetMasterOrdersV2Reply is the response of `GET /user/trading/v2/master-orders`.
+type GetMasterOrdersV2Reply struct {
+	Items    []MasterOrderV2Info `json:"items"`
+	Total    int32               `json:"total"`
+	Page     int32               `json:"page"`
+	PageSize int32               `json:"pageSize"`
+}
+
+// MasterOrderV2Info is the V2 master order DTO. Fields hidden by V2
+// (`apiKey`, `apiKeyName`, `ticktimeInt`, `ticktimeMs`, `submitTimeMs`,
+// `algoStartTimeMs`, ...) are intentionally absent.
+type MasterOrderV2Info struct {
+	CreatedAt                string                   `json:"createdAt"`
+	UpdatedAt                string                   `json:"updatedAt"`
+	MasterOrderId            string                   `json:"masterOrderId"`
+	ClientOrderId            string                   `json:"clientOrderId"`
+	ApiKeyId                 string                   `json:"apiKeyId"`
+	ApiKeyUuid               string                   `json:"-"` // Deprecated: use ApiKeyId.
+	TradingAccount           string                   `json:"tradingAccount"`
+	Exchange                 string                   `json:"exchange"`
+	MarketType               trading_enums.MarketType `json:"marketType"`
+	Category                 trading_enums.Category   `json:"category"`
+	Symbol                   string                   `json:"symbol"`
+	BaseCurrency             string                   `json:"baseCurrency"`
+	QuoteCurrency            string                   `json:"quoteCurrency"`
+	Side                     trading_enums.OrderSide  `json:"side"`
+	MarginType               *string                  `json:"marginType,omitempty"`
+	ReduceOnly               *bool                    `json:"reduceOnly,omitempty"`
+	IsMargin                 *bool                    `json:"isMargin,omitempty"`
+	Algorithm                trading_enums.Algorithm  `json:"algorithm"`
+	TotalQuantity            *string                  `json:"totalQuantity,omitempty"`
+	OrderNotional            *string                  `json:"orderNotional,omitempty"`
+	StartTimeMs              *FlexInt64               `json:"startTimeMs,omitempty"`
+	ExecutionDurationSeconds *FlexInt64               `json:"executionDurationSeconds,omitempty"`
+	WorstPrice               *string                  `json:"worstPrice,omitempty"`
+	MustComplete             *bool                    `json:"mustComplete,omitempty"`
+	MakerRateLimit           *string                  `json:"makerRateLimit,omitempty"`
+	PovLimit                 *string                  `json:"povLimit,omitempty"`
+	PovMinLimit              *string                  `json:"povMinLimit,omitempty"`
+	UpTolerance              *string                  `json:"upTolerance,omitempty"`
+	LowTolerance             *string                  `json:"lowTolerance,omitempty"`
+	StrictUpBound            *bool                    `json:"strictUpBound,omitempty"`
+	TailOrderProtection      *bool                    `json:"tailOrderProtection,omitempty"`
+	EnableMake               *bool                    `json:"enableMake,omitempty"`
+	IsTargetPosition         *bool                    `json:"isTargetPosition,omitempty"`
+	Notes                    string                   `json:"notes"`
+	Status                   MasterOrderStatusV2      `json:"status"`
+	RejectReason             string                   `json:"rejectReason"`
+	FinishedMs               *FlexInt64               `json:"finishedMs,omitempty"`
+	CumFilledQty             *string                  `json:"cumFilledQty,omitempty"`
+	CumFilledNotional        *string                  `json:"cumFilledNotional,omitempty"`
+	AvgFilledPrice           *string                  `json:"avgFilledPrice,omitempty"`
+	MakerRate                *string                  `json:"makerRate,omitempty"`
+	CompletedQuantity        *string                  `json:"completedQuantity,omitempty"`
+	Commission               map[string]string        `json:"commission"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrderDetailV2Service creates a service for `GET /user/trading/v2/master-orders/{masterOrderId}`.
+func (c *Client) NewGetMasterOrderDetailV2Service() *GetMasterOrderDetailV2Service {
+	return &GetMasterOrderDetailV2Service{c: c}
+}
+
+// GetMasterOrderDetailV2Service fetches a master order detail by `masterOrderId`.
+type GetMasterOrderDetailV2Service struct {
+	c             *Client
+	masterOrderId string
+}
+
+// MasterOrderId sets the path parameter.
+func (s *GetMasterOrderDetailV2Service) MasterOrderId(v string) *GetMasterOrderDetailV2Service {
+	s.masterOrderId = v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetMasterOrderDetailV2Service) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *GetMasterOrderDetailV2Service) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrderDetailV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/user/trading/v2/master-orders/%s", s.masterOrderId),
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[GetMasterOrderDetailV2Reply](ctx, s.c, r, opts...)
+}
+
+// GetMasterOrderDetailV2Reply is the response wrapper for V2 master order detail.
+type GetMasterOrderDetailV2Reply struct {
+	MasterOrder MasterOrderV2Info `json:"masterOrder"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetMasterOrderDetailByClientOrderIdV2Service creates a service for `GET /user/trading/v2/master-orders/by-client-order-id/{clientOrderId}`.
+func (c *Client) NewGetMasterOrderDetailByClientOrderIdV2Service() *GetMasterOrderDetailByClientOrderIdV2Service {
+	return &GetMasterOrderDetailByClientOrderIdV2Service{c: c}
+}
+
+// GetMasterOrderDetailByClientOrderIdV2Service fetches a master order by `clientOrderId` for idempotent lookups.
+type GetMasterOrderDetailByClientOrderIdV2Service struct {
+	c             *Client
+	clientOrderId string
+}
+
+// ClientOrderId sets the path parameter.
+func (s *GetMasterOrderDetailByClientOrderIdV2Service) ClientOrderId(v string) *GetMasterOrderDetailByClientOrderIdV2Service {
+	s.clientOrderId = v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetMasterOrderDetailByClientOrderIdV2Service) validate() error {
+	if s.clientOrderId == "" {
+		return errors.New("clientOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *GetMasterOrderDetailByClientOrderIdV2Service) Do(ctx context.Context, opts ...RequestOption) (res *GetMasterOrderDetailV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/user/trading/v2/master-orders/by-client-order-id/%s", s.clientOrderId),
+		secType:  secTypeSigned,
+	}
+	return callAPIAs[GetMasterOrderDetailV2Reply](ctx, s.c, r, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetOrderFillsV2Service creates a service for `GET /user/trading/v2/order-fills`.
+func (c *Client) NewGetOrderFillsV2Service() *GetOrderFillsV2Service {
+	return &GetOrderFillsV2Service{c: c}
+}
+
+// GetOrderFillsV2Service queries the V2 order fills (sub-orders) list.
+type GetOrderFillsV2Service struct {
+	c             *Client
+	page          *int32
+	pageSize      *int32
+	masterOrderId *string
+	orderId       *string
+	clientOrderId *string
+	symbol        *string
+	status        *string
+	startTime     *string
+	endTime       *string
+}
+
+// Page sets the 1-based page number.
+func (s *GetOrderFillsV2Service) Page(v int32) *GetOrderFillsV2Service {
+	s.page = &v
+	return s
+}
+
+// PageSize sets the per-page count. Values above 100 are rejected.
+func (s *GetOrderFillsV2Service) PageSize(v int32) *GetOrderFillsV2Service {
+	s.pageSize = &v
+	return s
+}
+
+// MasterOrderId filters by parent master order.
+func (s *GetOrderFillsV2Service) MasterOrderId(v string) *GetOrderFillsV2Service {
+	s.masterOrderId = &v
+	return s
+}
+
+// OrderId filters by exchange order ID. V2 replaces V1's `subOrderId`.
+func (s *GetOrderFillsV2Service) OrderId(v string) *GetOrderFillsV2Service {
+	s.orderId = &v
+	return s
+}
+
+// ClientOrderId filters by user-defined client order ID.
+func (s *GetOrderFillsV2Service) ClientOrderId(v string) *GetOrderFillsV2Service {
+	s.clientOrderId = &v
+	return s
+}
+
+// Symbol filters by trading pair.
+func (s *GetOrderFillsV2Service) Symbol(v string) *GetOrderFillsV2Service {
+	s.symbol = &v
+	return s
+}
+
+// Status filters by sub-order status.
+func (s *GetOrderFillsV2Service) Status(v string) *GetOrderFillsV2Service {
+	s.status = &v
+	return s
+}
+
+// StartTime sets the lower bound (RFC3339 / ISO 8601).
+func (s *GetOrderFillsV2Service) StartTime(v string) *GetOrderFillsV2Service {
+	s.startTime = &v
+	return s
+}
+
+// EndTime sets the upper bound (RFC3339 / ISO 8601).
+func (s *GetOrderFillsV2Service) EndTime(v string) *GetOrderFillsV2Service {
+	s.endTime = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *GetOrderFillsV2Service) validate() error {
+	return s.check()
+}
+
+// Do send request
+func (s *GetOrderFillsV2Service) Do(ctx context.Context, opts ...RequestOption) (res *GetOrderFillsV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/v2/order-fills",
+		secType:  secTypeSigned,
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	if s.masterOrderId != nil {
+		r.setParam("masterOrderId", *s.masterOrderId)
+	}
+	if s.orderId != nil {
+		r.setParam("orderId", *s.orderId)
+	}
+	if s.clientOrderId != nil {
+		r.setParam("clientOrderId", *s.clientOrderId)
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.status != nil {
+		r.setParam("status", *s.status)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	return callAPIAs[GetOrderFillsV2Reply](ctx, s.c, r, opts...)
+}
+
+// GetOrderFillsV2Reply is the response of `GET /user/trading/v2/order-fills`.
+type GetOrderFillsV2Reply struct {
+	Items    []OrderFillV2Info `json:"items"`
+	Total    int32             `json:"total"`
+	Page     int32             `json:"page"`
+	PageSize int32             `json:"pageSize"`
+}
+
+// OrderFillV2Info is the V2 sub-order DTO. V2 hides `fee` / `tradingAccount`,
+// renames `filledValue` → `filledNotional` and `subOrderId` → `orderId`,
+// `type` → `orderType`.
+//
+// 关于 decimal-shaped 字段：V2 后端契约是 *string*（避免 JS 精度丢失），但为了
+// 兼容历史/异常返回 number 的情况，这里统一使用 FlexDecimalString —— 它会接受
+// JSON string 或 JSON number，对外统一成 string。
+type OrderFillV2Info struct {
+	Id               string                  `json:"id"`
+	OrderCreatedTime string                  `json:"orderCreatedTime"`
+	MasterOrderId    string                  `json:"masterOrderId"`
+	Exchange         string                  `json:"exchange"`
+	Category         trading_enums.Category  `json:"category"`
+	Symbol           string                  `json:"symbol"`
+	Side             trading_enums.OrderSide `json:"side"`
+	FilledNotional   FlexDecimalString       `json:"filledNotional"`
+	FilledQuantity   FlexDecimalString       `json:"filledQuantity"`
+	AveragePrice     FlexDecimalString       `json:"averagePrice"`
+	Price            FlexDecimalString       `json:"price"`
+	Status           OrderFillStatusV2       `json:"status"`
+	RejectReason     string                  `json:"rejectReason"`
+	BaseCurrency     string                  `json:"baseCurrency"`
+	QuoteCurrency    string                  `json:"quoteCurrency"`
+	OrderType        string                  `json:"orderType"`
+	OrderId          string                  `json:"orderId"`
+	Quantity         FlexDecimalString       `json:"quantity"`
+	CreatedAt        string                  `json:"createdAt"`
+	UpdatedAt        string                  `json:"updatedAt"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewGetTCAAnalysisV2Service creates a service for `GET /user/trading/v2/tca-analysis`.
+func (c *Client) NewGetTCAAnalysisV2Service() *GetTCAAnalysisV2Service {
+	return &GetTCAAnalysisV2Service{c: c}
+}
+
+// GetTCAAnalysisV2Service queries post-trade TCA analysis results.
+//
+// Successful strategy-api responses return the TCA result array directly
+// from `message`.
+type GetTCAAnalysisV2Service struct {
+	c         *Client
+	symbol    *string
+	category  *string
+	strategy  *string
+	apiKeyId  *string
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol filters by trading pair.
+func (s *GetTCAAnalysisV2Service) Symbol(v string) *GetTCAAnalysisV2Service {
+	s.symbol = &v
+	return s
+}
+
+// Category filters by trading category: spot, perp, or perp_cm.
+func (s *GetTCAAnalysisV2Service) Category(v string) *GetTCAAnalysisV2Service {
+	s.category = &v
+	return s
+}
+
+// Strategy filters by execution algorithm: TWAP, VWAP, or POV.
+func (s *GetTCAAnalysisV2Service) Strategy(v string) *GetTCAAnalysisV2Service {
+	s.strategy = &v
+	return s
+}
+
+// ApiKeyId filters by exchange API key binding ID. Comma-separated IDs are
+// supported by the backend.
+func (s *GetTCAAnalysisV2Service) ApiKeyId(v string) *GetTCAAnalysisV2Service {
+	s.apiKeyId = &v
+	return s
+}
+
+// StartTime sets the lower bound in epoch milliseconds.
+func (s *GetTCAAnalysisV2Service) StartTime(v int64) *GetTCAAnalysisV2Service {
+	s.startTime = &v
+	return s
+}
+
+// EndTime sets the upper bound in epoch milliseconds.
+func (s *GetTCAAnalysisV2Service) EndTime(v int64) *GetTCAAnalysisV2Service {
+	s.endTime = &v
+	return s
+}
+
+// Do send request
+func (s *GetTCAAnalysisV2Service) Do(ctx context.Context, opts ...RequestOption) (res []*TCAAnalysisV2Info, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/user/trading/v2/tca-analysis",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.category != nil {
+		r.setParam("category", *s.category)
+	}
+	if s.strategy != nil {
+		r.setParam("strategy", *s.strategy)
+	}
+	if s.apiKeyId != nil {
+		r.setParam("apiKeyId", *s.apiKeyId)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	rows, err := callAPIAs[[]*TCAAnalysisV2Info](ctx, s.c, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if *rows == nil {
+		return make([]*TCAAnalysisV2Info, 0), nil
+	}
+	return *rows, nil
+}
+
+// TCAAnalysisV2Info is a single V2 TCA analysis row.
+type TCAAnalysisV2Info struct {
+	MasterOrderId           string  `json:"masterOrderId"`
+	StartTime               string  `json:"startTime"`
+	EndTime                 string  `json:"endTime"`
+	FinishedTime            string  `json:"finishedTime"`
+	Strategy                string  `json:"strategy"`
+	Category                string  `json:"category"`
+	OrderQuantity           float64 `json:"orderQuantity"`
+	OrderNotional           float64 `json:"orderNotional"`
+	ArrivalPrice            float64 `json:"arrivalPrice"`
+	ExecutionRate           float64 `json:"executionRate"`
+	FilledQuantity          float64 `json:"filledQuantity"`
+	TakerFilledNotional     float64 `json:"takerFilledNotional"`
+	MakerFilledNotional     float64 `json:"makerFilledNotional"`
+	FilledNotional          float64 `json:"filledNotional"`
+	MakerRate               float64 `json:"makerRate"`
+	ChildOrderCount         int32   `json:"childOrderCount"`
+	AverageFillPrice        float64 `json:"averageFillPrice"`
+	Slippage                float64 `json:"slippage"`
+	SlippagePct             float64 `json:"slippagePct"`
+	TwapSlippagePct         float64 `json:"twapSlippagePct"`
+	VwapSlippagePct         float64 `json:"vwapSlippagePct"`
+	Spread                  float64 `json:"spread"`
+	SlippagePctFartouch     float64 `json:"slippagePctFarTouch"`
+	TwapSlippagePctFartouch float64 `json:"twapSlippagePctFarTouch"`
+	VwapSlippagePctFartouch float64 `json:"vwapSlippagePctFarTouch"`
+	IntervalReturn          float64 `json:"intervalReturn"`
+	ParticipationRate       float64 `json:"participationRate"`
+	FeeSavingPct            float64 `json:"feeSavingPct"`
+	Date                    string  `json:"date"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewCancelMasterOrderV2Service creates a service for `PUT /user/trading/v2/master-orders/{masterOrderId}/cancel`.
+func (c *Client) NewCancelMasterOrderV2Service() *CancelMasterOrderV2Service {
+	return &CancelMasterOrderV2Service{c: c}
+}
+
+// CancelMasterOrderV2Service cancels a V2 master order.
+type CancelMasterOrderV2Service struct {
+	c             *Client
+	masterOrderId string
+	reason        *string
+}
+
+// MasterOrderId sets the path parameter.
+func (s *CancelMasterOrderV2Service) MasterOrderId(v string) *CancelMasterOrderV2Service {
+	s.masterOrderId = v
+	return s
+}
+
+// Reason sets the optional cancellation reason.
+func (s *CancelMasterOrderV2Service) Reason(v string) *CancelMasterOrderV2Service {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *CancelMasterOrderV2Service) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *CancelMasterOrderV2Service) Do(ctx context.Context, opts ...RequestOption) (res *MasterOrderActionV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	if s.reason != nil {
+		body["reason"] = *s.reason
+	}
+	return callAPIV2As[MasterOrderActionV2Reply](ctx, s.c, http.MethodPut, fmt.Sprintf("/user/trading/v2/master-orders/%s/cancel", s.masterOrderId), body, opts...)
+}
+
+// MasterOrderActionV2Reply is the standard reply for cancel/pause/resume/update.
+type MasterOrderActionV2Reply struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewPauseMasterOrderV2Service creates a service for `PUT /user/trading/v2/master-orders/{masterOrderId}/pause`.
+func (c *Client) NewPauseMasterOrderV2Service() *PauseMasterOrderV2Service {
+	return &PauseMasterOrderV2Service{c: c}
+}
+
+// PauseMasterOrderV2Service pauses a running V2 master order.
+type PauseMasterOrderV2Service struct {
+	c             *Client
+	masterOrderId string
+	reason        *string
+}
+
+// MasterOrderId sets the path parameter.
+func (s *PauseMasterOrderV2Service) MasterOrderId(v string) *PauseMasterOrderV2Service {
+	s.masterOrderId = v
+	return s
+}
+
+// Reason sets the optional pause reason.
+func (s *PauseMasterOrderV2Service) Reason(v string) *PauseMasterOrderV2Service {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *PauseMasterOrderV2Service) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *PauseMasterOrderV2Service) Do(ctx context.Context, opts ...RequestOption) (res *MasterOrderActionV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	if s.reason != nil {
+		body["reason"] = *s.reason
+	}
+	return callAPIV2As[MasterOrderActionV2Reply](ctx, s.c, http.MethodPut, fmt.Sprintf("/user/trading/v2/master-orders/%s/pause", s.masterOrderId), body, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewResumeMasterOrderV2Service creates a service for `PUT /user/trading/v2/master-orders/{masterOrderId}/resume`.
+func (c *Client) NewResumeMasterOrderV2Service() *ResumeMasterOrderV2Service {
+	return &ResumeMasterOrderV2Service{c: c}
+}
+
+// ResumeMasterOrderV2Service resumes a paused V2 master order.
+type ResumeMasterOrderV2Service struct {
+	c             *Client
+	masterOrderId string
+	reason        *string
+}
+
+// MasterOrderId sets the path parameter.
+func (s *ResumeMasterOrderV2Service) MasterOrderId(v string) *ResumeMasterOrderV2Service {
+	s.masterOrderId = v
+	return s
+}
+
+// Reason sets the optional resume reason.
+func (s *ResumeMasterOrderV2Service) Reason(v string) *ResumeMasterOrderV2Service {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *ResumeMasterOrderV2Service) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return nil
+}
+
+// Do send request
+func (s *ResumeMasterOrderV2Service) Do(ctx context.Context, opts ...RequestOption) (res *MasterOrderActionV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	if s.reason != nil {
+		body["reason"] = *s.reason
+	}
+	return callAPIV2As[MasterOrderActionV2Reply](ctx, s.c, http.MethodPut, fmt.Sprintf("/user/trading/v2/master-orders/%s/resume", s.masterOrderId), body, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewUpdateMasterOrderParamsV2Service creates a service for `PUT /user/trading/v2/master-orders/{masterOrderId}/update`.
+func (c *Client) NewUpdateMasterOrderParamsV2Service() *UpdateMasterOrderParamsV2Service {
+	return &UpdateMasterOrderParamsV2Service{c: c}
+}
+
+// UpdateMasterOrderParamsV2Service updates parameters of a running V2 master order.
+type UpdateMasterOrderParamsV2Service struct {
+	c                        *Client
+	masterOrderId            string
+	totalQuantity            *string
+	orderNotional            *string
+	upTolerance              *string
+	lowTolerance             *string
+	enableMake               *bool
+	makerRateLimit           *string
+	strictUpBound            *bool
+	povLimit                 *string
+	povMinLimit              *string
+	worstPrice               *string
+	tailOrderProtection      *bool
+	mustComplete             *bool
+	executionDurationSeconds *int64
+}
+
+// MasterOrderId sets the required path parameter.
+func (s *UpdateMasterOrderParamsV2Service) MasterOrderId(v string) *UpdateMasterOrderParamsV2Service {
+	s.masterOrderId = v
+	return s
+}
+
+// TotalQuantity updates the total trade quantity (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) TotalQuantity(v string) *UpdateMasterOrderParamsV2Service {
+	s.totalQuantity = &v
+	return s
+}
+
+// OrderNotional updates the order notional (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) OrderNotional(v string) *UpdateMasterOrderParamsV2Service {
+	s.orderNotional = &v
+	return s
+}
+
+// UpTolerance updates the upper progress tolerance (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) UpTolerance(v string) *UpdateMasterOrderParamsV2Service {
+	s.upTolerance = &v
+	return s
+}
+
+// LowTolerance updates the lower progress tolerance (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) LowTolerance(v string) *UpdateMasterOrderParamsV2Service {
+	s.lowTolerance = &v
+	return s
+}
+
+// EnableMake toggles maker orders.
+func (s *UpdateMasterOrderParamsV2Service) EnableMake(v bool) *UpdateMasterOrderParamsV2Service {
+	s.enableMake = &v
+	return s
+}
+
+// MakerRateLimit updates the minimum maker fill ratio (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) MakerRateLimit(v string) *UpdateMasterOrderParamsV2Service {
+	s.makerRateLimit = &v
+	return s
+}
+
+// StrictUpBound toggles strict upper-bound enforcement.
+func (s *UpdateMasterOrderParamsV2Service) StrictUpBound(v bool) *UpdateMasterOrderParamsV2Service {
+	s.strictUpBound = &v
+	return s
+}
+
+// PovLimit updates the participation rate cap (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) PovLimit(v string) *UpdateMasterOrderParamsV2Service {
+	s.povLimit = &v
+	return s
+}
+
+// PovMinLimit updates the participation rate floor (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) PovMinLimit(v string) *UpdateMasterOrderParamsV2Service {
+	s.povMinLimit = &v
+	return s
+}
+
+// WorstPrice updates the worst acceptable price (decimal string).
+func (s *UpdateMasterOrderParamsV2Service) WorstPrice(v string) *UpdateMasterOrderParamsV2Service {
+	s.worstPrice = &v
+	return s
+}
+
+// TailOrderProtection toggles tail-order protection.
+func (s *UpdateMasterOrderParamsV2Service) TailOrderProtection(v bool) *UpdateMasterOrderParamsV2Service {
+	s.tailOrderProtection = &v
+	return s
+}
+
+// MustComplete toggles must-complete behaviour.
+func (s *UpdateMasterOrderParamsV2Service) MustComplete(v bool) *UpdateMasterOrderParamsV2Service {
+	s.mustComplete = &v
+	return s
+}
+
+// ExecutionDurationSeconds updates the execution duration in seconds (must be > 10).
+func (s *UpdateMasterOrderParamsV2Service) ExecutionDurationSeconds(v int64) *UpdateMasterOrderParamsV2Service {
+	s.executionDurationSeconds = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *UpdateMasterOrderParamsV2Service) validate() error {
+	if s.masterOrderId == "" {
+		return errors.New("masterOrderId is required")
+	}
+	return s.check()
+}
+
+// Do send request
+func (s *UpdateMasterOrderParamsV2Service) Do(ctx context.Context, opts ...RequestOption) (res *MasterOrderActionV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	if s.totalQuantity != nil {
+		body["totalQuantity"] = *s.totalQuantity
+	}
+	if s.orderNotional != nil {
+		body["orderNotional"] = *s.orderNotional
+	}
+	if s.upTolerance != nil {
+		body["upTolerance"] = *s.upTolerance
+	}
+	if s.lowTolerance != nil {
+		body["lowTolerance"] = *s.lowTolerance
+	}
+	if s.enableMake != nil {
+		body["enableMake"] = *s.enableMake
+	}
+	if s.makerRateLimit != nil {
+		body["makerRateLimit"] = *s.makerRateLimit
+	}
+	if s.strictUpBound != nil {
+		body["strictUpBound"] = *s.strictUpBound
+	}
+	if s.povLimit != nil {
+		body["povLimit"] = *s.povLimit
+	}
+	if s.povMinLimit != nil {
+		body["povMinLimit"] = *s.povMinLimit
+	}
+	if s.worstPrice != nil {
+		body["worstPrice"] = *s.worstPrice
+	}
+	if s.tailOrderProtection != nil {
+		body["tailOrderProtection"] = *s.tailOrderProtection
+	}
+	if s.mustComplete != nil {
+		body["mustComplete"] = *s.mustComplete
+	}
+	if s.executionDurationSeconds != nil {
+		body["executionDurationSeconds"] = *s.executionDurationSeconds
+	}
+	return callAPIV2As[MasterOrderActionV2Reply](ctx, s.c, http.MethodPut, fmt.Sprintf("/user/trading/v2/master-orders/%s/update", s.masterOrderId), body, opts...)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+
+// NewBatchCancelMasterOrdersV2Service creates a service for `PUT /user/trading/v2/master-orders/batch-cancel`.
+func (c *Client) NewBatchCancelMasterOrdersV2Service() *BatchCancelMasterOrdersV2Service {
+	return &BatchCancelMasterOrdersV2Service{c: c}
+}
+
+// BatchCancelMasterOrdersV2Service cancels multiple master orders in one call.
+type BatchCancelMasterOrdersV2Service struct {
+	c              *Client
+	masterOrderIds []string
+	reason         *string
+}
+
+// MasterOrderIds sets the list of master order IDs to cancel.
+func (s *BatchCancelMasterOrdersV2Service) MasterOrderIds(v []string) *BatchCancelMasterOrdersV2Service {
+	s.masterOrderIds = v
+	return s
+}
+
+// Reason sets the optional batch cancellation reason.
+func (s *BatchCancelMasterOrdersV2Service) Reason(v string) *BatchCancelMasterOrdersV2Service {
+	s.reason = &v
+	return s
+}
+
+// validate reports the first parameter Do would reject.
+func (s *BatchCancelMasterOrdersV2Service) validate() error {
+	if len(s.masterOrderIds) == 0 {
+		return errors.New("masterOrderIds must not be empty")
+	}
+	return nil
+}
+
+// Do send request
+func (s *BatchCancelMasterOrdersV2Service) Do(ctx context.Context, opts ...RequestOption) (res *BatchCancelMasterOrdersV2Reply, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	body := params{}
+	body["masterOrderIds"] = s.masterOrderIds
+	if s.reason != nil {
+		body["reason"] = *s.reason
+	}
+	return callAPIV2As[BatchCancelMasterOrdersV2Reply](ctx, s.c, http.MethodPut, "/user/trading/v2/master-orders/batch-cancel", body, opts...)
+}
+
+// BatchCancelMasterOrdersV2Reply is the response of batch-cancel.
+type BatchCancelMasterOrdersV2Reply struct {
+	SuccessCount int32                          `json:"successCount"`
+	FailedOrders []BatchCancelV2FailedOrderInfo `json:"failedOrders"`
+}
+
+// BatchCancelV2FailedOrderInfo describes one failed cancellation attempt.
+type BatchCancelV2FailedOrderInfo struct {
+	MasterOrderId string `json:"masterOrderId"`
+	Reason        string `json:"reason"`
+}
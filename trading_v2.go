@@ -0,0 +1,310 @@
+package qe_connector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+)
+
+// This file holds the handwritten parts of the V2 trading services; the
+// services themselves are generated into trading_gen.go from
+// api/strategy-api.yaml.
+
+// MasterOrderStatusV2 enumerates the V2 master order statuses documented in
+// `frontend-v2-api-upgrade.md` §2.
+//
+// 母单状态在 V2 接口里的语义按用途分两类：
+//
+//  1. 详情 / 推送返回：保留全部 9 个细分状态（NEW / WAITING / PROCESSING /
+//     PAUSED / CANCELLED / COMPLETED / COMPLETED_WITHTAIL / REJECTED /
+//     EXPIRED），SDK 接收端按需展示。
+//  2. 列表查询过滤（GetMasterOrdersV2Service.Status）：后端只接受 2 个聚合
+//     值：
+//     - `NEW`       → 所有"运行中"母单（NEW / WAITING / PROCESSING / PAUSED
+//     以及内部的 CANCEL / CANCEL_REJECT / CLEANING 中间态）
+//     - `COMPLETED` → 所有"非运行中"母单（CANCELLED / COMPLETED /
+//     COMPLETED_WITHTAIL / REJECTED / EXPIRED）
+//     传其它细分状态在列表查询里会退化成"按字面值精确匹配"，结果通常为空，
+//     **不要这样用**。推荐显式使用 `MasterOrderStatusV2New` 或
+//     `MasterOrderStatusV2Completed`。
+//
+// 其它 V2 接口（batch-cancel / update 等）保持细分状态语义，不受影响。
+type MasterOrderStatusV2 string
+
+const (
+	// MasterOrderStatusV2New 在列表查询里表示"运行中"聚合（包含 NEW / WAITING
+	// / PROCESSING / PAUSED 等所有未终态状态）；在详情/推送里只代表"刚创建未
+	// 启动"。
+	MasterOrderStatusV2New        MasterOrderStatusV2 = "NEW"
+	MasterOrderStatusV2Waiting    MasterOrderStatusV2 = "WAITING"
+	MasterOrderStatusV2Processing MasterOrderStatusV2 = "PROCESSING"
+	MasterOrderStatusV2Paused     MasterOrderStatusV2 = "PAUSED"
+	MasterOrderStatusV2Cancelled  MasterOrderStatusV2 = "CANCELLED"
+	// MasterOrderStatusV2Completed 在列表查询里表示"非运行中"聚合（包含
+	// CANCELLED / COMPLETED / COMPLETED_WITHTAIL / REJECTED / EXPIRED）；
+	// 在详情/推送里只代表"正常完成"。
+	MasterOrderStatusV2Completed         MasterOrderStatusV2 = "COMPLETED"
+	MasterOrderStatusV2CompletedWithTail MasterOrderStatusV2 = "COMPLETED_WITHTAIL"
+	MasterOrderStatusV2Rejected          MasterOrderStatusV2 = "REJECTED"
+	MasterOrderStatusV2Expired           MasterOrderStatusV2 = "EXPIRED"
+)
+
+// IsTerminal reports whether the detail/push status is final, i.e. the
+// master order will not fill any further.
+func (s MasterOrderStatusV2) IsTerminal() bool {
+	switch s {
+	case MasterOrderStatusV2Cancelled, MasterOrderStatusV2Completed,
+		MasterOrderStatusV2CompletedWithTail, MasterOrderStatusV2Rejected,
+		MasterOrderStatusV2Expired:
+		return true
+	}
+	return false
+}
+
+var masterOrderStatusesV2 = []MasterOrderStatusV2{
+	MasterOrderStatusV2New, MasterOrderStatusV2Waiting, MasterOrderStatusV2Processing,
+	MasterOrderStatusV2Paused, MasterOrderStatusV2Cancelled, MasterOrderStatusV2Completed,
+	MasterOrderStatusV2CompletedWithTail, MasterOrderStatusV2Rejected, MasterOrderStatusV2Expired,
+}
+
+// MasterOrderStatusesV2 returns every MasterOrderStatusV2, in declaration
+// order.
+func MasterOrderStatusesV2() []MasterOrderStatusV2 {
+	return slices.Clone(masterOrderStatusesV2)
+}
+
+// ParseMasterOrderStatusV2 returns the MasterOrderStatusV2 equal to s
+// ignoring case.
+func ParseMasterOrderStatusV2(s string) (MasterOrderStatusV2, error) {
+	if v, ok := trading_enums.Match(s, masterOrderStatusesV2); ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("qe: unknown master order status %q", s)
+}
+
+// IsValid reports whether s is a known MasterOrderStatusV2.
+func (s MasterOrderStatusV2) IsValid() bool {
+	return slices.Contains(masterOrderStatusesV2, s)
+}
+
+// UnmarshalText decodes a known status case-insensitively and keeps an
+// unknown one as is, so statuses added by the server still decode.
+func (s *MasterOrderStatusV2) UnmarshalText(b []byte) error {
+	*s, _ = trading_enums.Match(string(b), masterOrderStatusesV2)
+	return nil
+}
+
+// OrderFillStatusV2 enumerates the statuses of child orders returned by
+// GetOrderFillsV2Service.
+type OrderFillStatusV2 string
+
+const (
+	OrderFillStatusV2Placed         OrderFillStatusV2 = "PLACED"          // 已下单
+	OrderFillStatusV2Rejected       OrderFillStatusV2 = "REJECTED"        // 已拒单
+	OrderFillStatusV2Cancelled      OrderFillStatusV2 = "CANCELLED"       // 算法已撤单
+	OrderFillStatusV2Filled         OrderFillStatusV2 = "FILLED"          // 完全成交
+	OrderFillStatusV2CancelAck      OrderFillStatusV2 = "Cancelack"       // 交易所已撤单
+	OrderFillStatusV2CancelRejected OrderFillStatusV2 = "CANCEL_REJECTED" // 拒绝撤单
+)
+
+var orderFillStatusesV2 = []OrderFillStatusV2{
+	OrderFillStatusV2Placed, OrderFillStatusV2Rejected, OrderFillStatusV2Cancelled,
+	OrderFillStatusV2Filled, OrderFillStatusV2CancelAck, OrderFillStatusV2CancelRejected,
+}
+
+// OrderFillStatusesV2 returns every OrderFillStatusV2, in declaration order.
+func OrderFillStatusesV2() []OrderFillStatusV2 {
+	return slices.Clone(orderFillStatusesV2)
+}
+
+// ParseOrderFillStatusV2 returns the OrderFillStatusV2 equal to s ignoring
+// case.
+func ParseOrderFillStatusV2(s string) (OrderFillStatusV2, error) {
+	if v, ok := trading_enums.Match(s, orderFillStatusesV2); ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("qe: unknown order fill status %q", s)
+}
+
+// IsValid reports whether s is a known OrderFillStatusV2.
+func (s OrderFillStatusV2) IsValid() bool {
+	return slices.Contains(orderFillStatusesV2, s)
+}
+
+// UnmarshalText decodes a known status case-insensitively and keeps an
+// unknown one as is.
+func (s *OrderFillStatusV2) UnmarshalText(b []byte) error {
+	*s, _ = trading_enums.Match(string(b), orderFillStatusesV2)
+	return nil
+}
+
+// pageSizeMaxV2 is the V2 list-endpoint page size cap. Values above this
+// limit are rejected by V2 APIs instead of being silently clamped.
+const pageSizeMaxV2 = 100
+
+func validatePageSizeV2(pageSize *int32) error {
+	if pageSize != nil && *pageSize > pageSizeMaxV2 {
+		return fmt.Errorf("pageSize %d exceeds V2 limit %d", *pageSize, pageSizeMaxV2)
+	}
+	return nil
+}
+
+func defaultPovLimitForAlgorithmV2(algorithm trading_enums.Algorithm) string {
+	if algorithm == trading_enums.AlgorithmPOV {
+		return "0.05"
+	}
+	return "1"
+}
+
+func validatePovLimitV2(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || f > 1 {
+		return errors.New("povLimit must be between 0 and 1")
+	}
+	return nil
+}
+
+func (s *ListExchangeApisV2Service) check() error {
+	return validatePageSizeV2(s.pageSize)
+}
+
+// UnmarshalJSON fills ApiKeyId from the legacy `apiKeyUuid` / `id` fields
+// when the server omits it, and keeps the deprecated fields populated.
+func (i *ExchangeApiV2Info) UnmarshalJSON(data []byte) error {
+	type alias ExchangeApiV2Info
+	var aux struct {
+		*alias
+		LegacyApiKeyUuid string `json:"apiKeyUuid"`
+		LegacyId         string `json:"id"`
+	}
+	aux.alias = (*alias)(i)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if i.ApiKeyId == "" {
+		if aux.LegacyApiKeyUuid != "" {
+			i.ApiKeyId = aux.LegacyApiKeyUuid
+		} else {
+			i.ApiKeyId = aux.LegacyId
+		}
+	}
+	if i.ApiKeyUuid == "" {
+		i.ApiKeyUuid = i.ApiKeyId
+	}
+	if i.Id == "" {
+		if aux.LegacyId != "" {
+			i.Id = aux.LegacyId
+		} else {
+			i.Id = i.ApiKeyId
+		}
+	}
+	return nil
+}
+
+func (s *CreateMasterOrderV2Service) check() error {
+	if s.executionDurationSeconds == nil {
+		return errors.New("executionDurationSeconds is required (must be > 10)")
+	}
+	if *s.executionDurationSeconds <= 10 {
+		return errors.New("executionDurationSeconds must be greater than 10")
+	}
+	if s.povLimit != nil {
+		if err := validatePovLimitV2(*s.povLimit); err != nil {
+			return err
+		}
+	}
+	hasQty := s.totalQuantity != nil
+	hasNotional := s.orderNotional != nil
+	if hasQty == hasNotional {
+		return errors.New("exactly one of totalQuantity / orderNotional must be provided")
+	}
+	if s.isTargetPosition != nil && *s.isTargetPosition {
+		if !hasQty {
+			return errors.New("totalQuantity is required when isTargetPosition is true")
+		}
+		if hasNotional {
+			return errors.New("orderNotional is not allowed when isTargetPosition is true")
+		}
+	}
+	// Same Deribit / Binance perp_cm guards as the V1 service.
+	if s.exchange == trading_enums.ExchangeDeribit &&
+		(strings.EqualFold(s.symbol, "BTCUSD") || strings.EqualFold(s.symbol, "ETHUSD")) {
+		if hasNotional {
+			return errors.New("orderNotional is not allowed when exchange is Deribit and symbol is BTCUSD or ETHUSD; use totalQuantity (unit: USD) instead")
+		}
+		if !hasQty {
+			return errors.New("totalQuantity is required when exchange is Deribit and symbol is BTCUSD or ETHUSD (unit: USD)")
+		}
+	}
+	if s.exchange == trading_enums.ExchangeBinance &&
+		s.marketType == trading_enums.MarketTypePerp &&
+		s.marginType != nil && *s.marginType == trading_enums.MarginTypeC {
+		if hasNotional {
+			return errors.New("orderNotional is not allowed when exchange is Binance and marginType is C for PERP orders; use totalQuantity (unit: contracts) instead")
+		}
+		if !hasQty {
+			return errors.New("totalQuantity is required when exchange is Binance and marginType is C for PERP orders (unit: contracts)")
+		}
+	}
+	return nil
+}
+
+func (s *GetMasterOrdersV2Service) check() error {
+	return validatePageSizeV2(s.pageSize)
+}
+
+// ApiKeyUuid is kept for source compatibility. New code should use ApiKeyId.
+func (s *GetMasterOrdersV2Service) ApiKeyUuid(uuid string) *GetMasterOrdersV2Service {
+	return s.ApiKeyId(uuid)
+}
+
+// UnmarshalJSON fills ApiKeyId from the legacy `apiKeyUuid` field when the
+// server omits it, and keeps ApiKeyUuid populated.
+func (i *MasterOrderV2Info) UnmarshalJSON(data []byte) error {
+	type alias MasterOrderV2Info
+	var aux struct {
+		*alias
+		LegacyApiKeyUuid string `json:"apiKeyUuid"`
+	}
+	aux.alias = (*alias)(i)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if i.ApiKeyId == "" {
+		i.ApiKeyId = aux.LegacyApiKeyUuid
+	}
+	if i.ApiKeyUuid == "" {
+		i.ApiKeyUuid = i.ApiKeyId
+	}
+	return nil
+}
+
+func (s *GetOrderFillsV2Service) check() error {
+	return validatePageSizeV2(s.pageSize)
+}
+
+// ApiKeyUuid is kept for source compatibility. New code should use ApiKeyId.
+func (s *GetTCAAnalysisV2Service) ApiKeyUuid(uuid string) *GetTCAAnalysisV2Service {
+	return s.ApiKeyId(uuid)
+}
+
+func (s *UpdateMasterOrderParamsV2Service) check() error {
+	if s.executionDurationSeconds != nil && *s.executionDurationSeconds <= 10 {
+		return errors.New("executionDurationSeconds must be greater than 10")
+	}
+	if s.povLimit != nil {
+		return validatePovLimitV2(*s.povLimit)
+	}
+	return nil
+}
+
+// AddMasterOrderId appends a master order ID to the batch.
+func (s *BatchCancelMasterOrdersV2Service) AddMasterOrderId(id string) *BatchCancelMasterOrdersV2Service {
+	s.masterOrderIds = append(s.masterOrderIds, id)
+	return s
+}
@@ -8,6 +8,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io"
 	"math"
 	"net/http"
@@ -30,6 +33,15 @@ import (
 //
 // Keep this in sync with backend `internal/server/gin/middleware.go`.
 func signLikeBackend(t *testing.T, secret, rawQuery string, bodyBytes []byte) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(canonicalLikeBackend(t, rawQuery, bodyBytes)))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+// canonicalLikeBackend returns merged.Encode() from signLikeBackend, the
+// string every signature mode signs.
+func canonicalLikeBackend(t *testing.T, rawQuery string, bodyBytes []byte) string {
 	t.Helper()
 	merged := url.Values{}
 	if rawQuery != "" {
@@ -66,9 +78,7 @@ func signLikeBackend(t *testing.T, secret, rawQuery string, bodyBytes []byte) st
 			}
 		}
 	}
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(merged.Encode()))
-	return fmt.Sprintf("%x", mac.Sum(nil))
+	return merged.Encode()
 }
 
 func backendScalarToString(v interface{}) (string, bool) {
@@ -706,3 +716,120 @@ func TestMasterOrderV2InfoOptionalFieldsPreserveMissingVsZero(t *testing.T) {
 		t.Fatalf("ExecutionDurationSeconds = %#v, want 15", info.ExecutionDurationSeconds)
 	}
 }
+
+func TestV2DTOsDecodeEnumsCaseInsensitively(t *testing.T) {
+	var order MasterOrderV2Info
+	raw := `{"status":"processing","side":"BUY","algorithm":"twap","marketType":"perp","category":"PERP_CM"}`
+	if err := json.Unmarshal([]byte(raw), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != MasterOrderStatusV2Processing || order.Side != trading_enums.OrderSideBuy ||
+		order.Algorithm != trading_enums.AlgorithmTWAP || order.MarketType != trading_enums.MarketTypePerp ||
+		order.Category != trading_enums.CategoryPerpCm {
+		t.Fatalf("decoded %+v", order)
+	}
+
+	var fill OrderFillV2Info
+	if err := json.Unmarshal([]byte(`{"status":"CANCELACK","side":"Sell","category":"Spot"}`), &fill); err != nil {
+		t.Fatal(err)
+	}
+	if fill.Status != OrderFillStatusV2CancelAck || fill.Side != trading_enums.OrderSideSell || fill.Category != trading_enums.CategorySpot {
+		t.Fatalf("decoded %+v", fill)
+	}
+
+	var fillPush WsOrderFillDetail
+	if err := json.Unmarshal([]byte(`{"status":"filled","side":"buy","category":"perp"}`), &fillPush); err != nil {
+		t.Fatal(err)
+	}
+	if fillPush.Status != OrderFillStatusV2Filled || fillPush.Side != trading_enums.OrderSideBuy || fillPush.Category != trading_enums.CategoryPerp {
+		t.Fatalf("decoded %+v", fillPush)
+	}
+
+	var created CreateMasterOrderV2Reply
+	if err := json.Unmarshal([]byte(`{"masterOrderId":"mo_1","status":"new"}`), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != MasterOrderStatusV2New {
+		t.Fatalf("decoded %+v", created)
+	}
+
+	// Values the SDK does not know yet are kept, not rejected.
+	var push WsMasterOrderDetail
+	raw = `{"status":"SUSPENDED","side":"buy","algorithm":"ICEBERG","marketType":"OPTION","category":"option"}`
+	if err := json.Unmarshal([]byte(raw), &push); err != nil {
+		t.Fatal(err)
+	}
+	if push.Status != "SUSPENDED" || push.Status.IsValid() || push.Algorithm != "ICEBERG" || push.Algorithm.IsValid() ||
+		push.MarketType != "OPTION" || push.Category != "option" || !push.Side.IsValid() {
+		t.Fatalf("decoded %+v", push)
+	}
+}
+
+func TestV2StatusEnumParse(t *testing.T) {
+	if s, err := ParseMasterOrderStatusV2("completed_withtail"); err != nil || s != MasterOrderStatusV2CompletedWithTail {
+		t.Fatalf("ParseMasterOrderStatusV2 = %q, %v", s, err)
+	}
+	if s, err := ParseOrderFillStatusV2("cancel_rejected"); err != nil || s != OrderFillStatusV2CancelRejected {
+		t.Fatalf("ParseOrderFillStatusV2 = %q, %v", s, err)
+	}
+	if _, err := ParseMasterOrderStatusV2("SUSPENDED"); err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+	if _, err := ParseOrderFillStatusV2(""); err == nil {
+		t.Fatal("expected an error for an empty status")
+	}
+
+	// The lists hold every declared constant, so tests can range over them
+	// to check that a switch handles each value.
+	declared := declaredConsts(t, "trading_v2.go")
+	for typ, list := range map[string][]string{
+		"MasterOrderStatusV2": enumNames(MasterOrderStatusesV2()),
+		"OrderFillStatusV2":   enumNames(OrderFillStatusesV2()),
+	} {
+		if fmt.Sprint(list) != fmt.Sprint(declared[typ]) {
+			t.Errorf("%s list = %v, declared %v", typ, list, declared[typ])
+		}
+	}
+	for _, s := range MasterOrderStatusesV2() {
+		if !s.IsValid() {
+			t.Errorf("%s is not valid", s)
+		}
+	}
+}
+
+func enumNames[T ~string](vs []T) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// declaredConsts returns the values of the typed string constants of file,
+// by type, in declaration order.
+func declaredConsts(t *testing.T, file string) map[string][]string {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := map[string][]string{}
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			typ, ok := vs.Type.(*ast.Ident)
+			if !ok || len(vs.Values) != 1 {
+				continue
+			}
+			if lit, ok := vs.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				v, _ := strconv.Unquote(lit.Value)
+				out[typ.Name] = append(out[typ.Name], v)
+			}
+		}
+	}
+	return out
+}
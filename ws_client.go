@@ -3,8 +3,10 @@ package qe_connector
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -43,7 +45,7 @@ func NewWebSocketService(c *Client, host ...string) *WebSocketService {
 		cancel:         cancel,
 	}
 
-	// 如果提供了host参数，设置自定义host
+	// 如果提供了host参数，设置自定义host；否则连接时使用客户端的 WSHosts 或默认 host
 	if len(host) > 0 && host[0] != "" {
 		ws.host = host[0]
 	}
@@ -79,7 +81,68 @@ func (ws *WebSocketService) Connect(listenKey string) error {
 	ws.listenKey = listenKey
 	ws.mu.Unlock()
 
-	return ws.connect()
+	if err := ws.connect(); err != nil {
+		return err
+	}
+	ws.c.trackSocket(ws, true)
+	return nil
+}
+
+// rekey moves the service to a listen key created with the client's
+// current credentials. The new connection is dialed before the old one is
+// closed; if that fails the service stays on the old listen key.
+func (ws *WebSocketService) rekey(ctx context.Context) error {
+	ws.mu.RLock()
+	version := ws.version
+	ws.mu.RUnlock()
+	var (
+		lk  *CreateListenKeyReply
+		err error
+	)
+	if version == ClientProtocolV1 {
+		lk, err = ws.c.NewCreateListenKeyService().Do(ctx)
+	} else {
+		lk, err = ws.c.NewCreateListenKeyV2Service().Do(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Dial without holding ws.mu so reads, reconnects and Close are not
+	// blocked behind the handshake; lock again only to swap.
+	ws.mu.Lock()
+	ep := ws.endpoint()
+	if !ws.isConnected || ws.conn == nil {
+		// The next (re)connect uses the new listen key.
+		ws.listenKey = lk.ListenKey
+		ws.mu.Unlock()
+		return nil
+	}
+	ws.mu.Unlock()
+	ep.listenKey = lk.ListenKey
+	conn, err := ws.dial(ep)
+	if err != nil {
+		return err
+	}
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(ws.pongTimeout))
+		return nil
+	})
+
+	ws.mu.Lock()
+	ws.listenKey = lk.ListenKey
+	prev := ws.conn
+	if !ws.isConnected || prev == nil {
+		// Closed while dialing.
+		ws.mu.Unlock()
+		conn.Close()
+		return nil
+	}
+	ws.conn = conn
+	ws.mu.Unlock()
+	prev.Close()
+	ws.log(slog.LevelInfo, "qe ws moved to new listen key")
+	return nil
 }
 
 // connect 内部连接方法
@@ -91,16 +154,21 @@ func (ws *WebSocketService) connect() error {
 		return nil
 	}
 
-	// 构建 WebSocket URL
-	wsURL := ws.getWebSocketURL()
-
-	ws.c.debug("Connecting to WebSocket: %s", wsURL)
+	// 回放模式：不建立网络连接，按顺序投递录制的消息
+	if cs := ws.c.cassette; cs != nil {
+		ws.isConnected = true
+		if ws.handlers.OnConnected != nil {
+			ws.handlers.OnConnected()
+		}
+		ws.wg.Add(1)
+		go ws.replayMessages(cs.nextFrames())
+		return nil
+	}
 
 	// 创建 WebSocket 连接
-	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	conn, err := ws.dial(ws.endpoint())
 	if err != nil {
-		ws.c.debug("Failed to connect WebSocket: %v", err)
-		return fmt.Errorf("failed to connect websocket: %w", err)
+		return err
 	}
 
 	ws.conn = conn
@@ -124,20 +192,87 @@ func (ws *WebSocketService) connect() error {
 	return nil
 }
 
+// errNoWSHost is returned when a client made by New for EnvProd has no
+// WebSocket host to dial.
+var errNoWSHost = errors.New("qe: no WebSocket host for EnvProd; set one with WithWSHost")
+
+// wsEndpoint is what a connection is dialed with, copied under ws.mu so it
+// can be dialed without holding the lock.
+type wsEndpoint struct {
+	host      string
+	listenKey string
+	version   ClientProtocolVersion
+}
+
+// endpoint returns the service's current endpoint; ws.mu must be held.
+func (ws *WebSocketService) endpoint() wsEndpoint {
+	return wsEndpoint{host: ws.host, listenKey: ws.listenKey, version: ws.version}
+}
+
+// dial connects to ep's host or, when none is set, to the client's
+// failover WSHosts in order of preference, falling back to the client's
+// default host.
+func (ws *WebSocketService) dial(ep wsEndpoint) (*websocket.Conn, error) {
+	hosts := []string{ep.host}
+	pool := ws.c.wsHosts
+	if ep.host != "" {
+		pool = nil
+	}
+	if pool != nil {
+		hosts = pool.candidates()
+	} else if ep.host == "" {
+		if ws.c.wsHost == "" && ws.c.needWSHost {
+			return nil, errNoWSHost
+		}
+		hosts = []string{ws.c.wsHost}
+	}
+	var err error
+	for _, host := range hosts {
+		wsURL := ep.url(host)
+		ws.log(slog.LevelDebug, "qe ws connecting", slog.String("url", wsURL))
+		_, end := ws.c.startWebSocket(ws.ctx, WebSocketOpConnect)
+		var conn *websocket.Conn
+		conn, _, err = websocket.DefaultDialer.Dial(wsURL, nil)
+		end(err)
+		if err == nil {
+			if pool != nil {
+				pool.success(host)
+			}
+			return conn, nil
+		}
+		ws.log(slog.LevelError, "qe ws connect failed", slog.String("url", wsURL), slog.Any("error", err))
+		if pool != nil {
+			pool.failure(host)
+		}
+	}
+	return nil, fmt.Errorf("failed to connect websocket: %w", err)
+}
+
 // getWebSocketURL 获取 WebSocket URL
 func (ws *WebSocketService) getWebSocketURL() string {
+	host := ws.host
+	if host == "" && ws.c.wsHosts != nil {
+		host = ws.c.wsHosts.candidates()[0]
+	} else if host == "" {
+		host = ws.c.wsHost
+	}
+	return ws.endpoint().url(host)
+}
+
+// url returns the WebSocket URL of ep on host.
+func (ep wsEndpoint) url(host string) string {
 	baseURL := "wss://test.quantumexecute.com"
 
 	// 如果设置了自定义host，使用自定义host
-	if ws.host != "" {
-		baseURL = ws.host
+	if host != "" {
+		baseURL = host
 	}
 
 	path := "/api/ws/v2"
-	if ws.version == ClientProtocolV1 {
+	if ep.version == ClientProtocolV1 {
 		path = "/api/ws"
 	}
-	return fmt.Sprintf("%s%s?listen_key=%s", baseURL, path, ws.listenKey)
+	return fmt.Sprintf("%s%s?listen_key=%s", baseURL, path, ep.listenKey)
 }
 
 // readMessages 读取消息
@@ -158,12 +293,24 @@ func (ws *WebSocketService) readMessages() {
 			}
 			_, message, err := conn.ReadMessage()
 			if err != nil {
-				ws.c.debug("WebSocket read error: %v", err)
+				ws.mu.Lock()
+				swapped := ws.conn != nil && ws.conn != conn
+				ws.mu.Unlock()
+				if swapped {
+					// rekey replaced the connection.
+					continue
+				}
+				ws.log(slog.LevelWarn, "qe ws read error", slog.Any("error", err))
 				ws.reconnect()
 				continue
 			}
 
-			ws.c.debug("Received message: %s", string(message))
+			if rec := ws.c.recorder; rec != nil {
+				ws.mu.RLock()
+				wsURL := ws.getWebSocketURL()
+				ws.mu.RUnlock()
+				rec.recordFrame(wsURL, message)
+			}
 			if string(message) == "pong" {
 				return
 			}
@@ -174,14 +321,39 @@ func (ws *WebSocketService) readMessages() {
 	}
 }
 
+// replayMessages 回放模式下按录制顺序同步处理消息
+func (ws *WebSocketService) replayMessages(frames [][]byte) {
+	defer ws.wg.Done()
+
+	for _, message := range frames {
+		if ws.ctx.Err() != nil {
+			return
+		}
+		if string(message) == "pong" {
+			continue
+		}
+		ws.handleMessage(message)
+	}
+}
+
+// log 通过客户端的 slog logger 输出结构化日志（已脱敏）
+func (ws *WebSocketService) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if l := ws.c.logger(); l != nil {
+		l.LogAttrs(ws.ctx, level, msg, attrs...)
+	}
+}
+
 // handleMessage 处理消息
 func (ws *WebSocketService) handleMessage(data []byte) {
+	ws.log(slog.LevelDebug, "qe ws message", slog.String("frame", string(data)))
+	_, end := ws.c.startWebSocket(ws.ctx, WebSocketOpMessage)
+	var err error
+	defer func() { end(err) }()
 
-	ws.c.debug("Received message: %s", string(data))
 	// 首先解析客户端推送消息
 	var clientMsg ClientPushMessage
-	if err := json.Unmarshal(data, &clientMsg); err != nil {
-		ws.c.debug("Failed to unmarshal client message: %v", err)
+	if err = json.Unmarshal(data, &clientMsg); err != nil {
+		ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "client message"), slog.Any("error", err))
 		if ws.handlers.OnError != nil {
 			ws.handlers.OnError(err)
 		}
@@ -191,7 +363,7 @@ func (ws *WebSocketService) handleMessage(data []byte) {
 	// 调用原始消息处理器
 	if ws.handlers.OnRawMessage != nil {
 		if err := ws.handlers.OnRawMessage(&clientMsg); err != nil {
-			ws.c.debug("Raw message handler error: %v", err)
+			ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "raw message"), slog.Any("error", err))
 		}
 	}
 
@@ -200,7 +372,7 @@ func (ws *WebSocketService) handleMessage(data []byte) {
 	case ClientStatusType:
 		if ws.handlers.OnStatus != nil {
 			if err := ws.handlers.OnStatus(clientMsg.Data); err != nil {
-				ws.c.debug("Status handler error: %v", err)
+				ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "status"), slog.Any("error", err))
 			}
 		}
 
@@ -210,27 +382,42 @@ func (ws *WebSocketService) handleMessage(data []byte) {
 		}
 
 	case ClientMasterDetailType:
+		ws.observeLag(clientMsg.Type, clientMsg.Data)
 		ws.handleMasterDetailMessage(clientMsg.Data)
 
 	case ClientOrderFillDetailType:
+		ws.observeLag(clientMsg.Type, clientMsg.Data)
 		ws.handleOrderFillDetailMessage(clientMsg.Data)
 	}
 }
 
+// observeLag 上报推送的 updatedAt 到处理时刻的延迟
+func (ws *WebSocketService) observeLag(msgType ClientMessageType, data string) {
+	if ws.c.instr == nil {
+		return
+	}
+	var msg struct {
+		UpdatedAt string `json:"updatedAt"`
+	}
+	if json.Unmarshal([]byte(data), &msg) == nil {
+		ws.c.observeLag(ws.ctx, msgType, msg.UpdatedAt)
+	}
+}
+
 // handleMasterDetailMessage 处理 master_data 类型消息
 func (ws *WebSocketService) handleMasterDetailMessage(data string) {
 	// 服务端推送的是 MasterOrderDTO（无内层 type 字段），优先用 OnMasterOrderDetail 回调
 	if ws.handlers.OnMasterOrderDetail != nil {
 		var msg WsMasterOrderDetail
 		if err := json.Unmarshal([]byte(data), &msg); err != nil {
-			ws.c.debug("Failed to unmarshal master order detail: %v", err)
+			ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "master order detail"), slog.Any("error", err))
 			if ws.handlers.OnError != nil {
 				ws.handlers.OnError(err)
 			}
 			return
 		}
 		if err := ws.handlers.OnMasterOrderDetail(&msg); err != nil {
-			ws.c.debug("Master order detail handler error: %v", err)
+			ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "master order detail"), slog.Any("error", err))
 		}
 		return
 	}
@@ -245,14 +432,14 @@ func (ws *WebSocketService) handleOrderFillDetailMessage(data string) {
 	if ws.handlers.OnOrderFillDetail != nil {
 		var msg WsOrderFillDetail
 		if err := json.Unmarshal([]byte(data), &msg); err != nil {
-			ws.c.debug("Failed to unmarshal order fill detail: %v", err)
+			ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "order fill detail"), slog.Any("error", err))
 			if ws.handlers.OnError != nil {
 				ws.handlers.OnError(err)
 			}
 			return
 		}
 		if err := ws.handlers.OnOrderFillDetail(&msg); err != nil {
-			ws.c.debug("Order fill detail handler error: %v", err)
+			ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "order fill detail"), slog.Any("error", err))
 		}
 		return
 	}
@@ -265,7 +452,7 @@ func (ws *WebSocketService) handleOrderFillDetailMessage(data string) {
 func (ws *WebSocketService) handleLegacyThirdPartyMessage(data string) {
 	var baseMsg BaseThirdPartyMessage
 	if err := json.Unmarshal([]byte(data), &baseMsg); err != nil {
-		ws.c.debug("Failed to unmarshal base message: %v", err)
+		ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "base message"), slog.Any("error", err))
 		if ws.handlers.OnError != nil {
 			ws.handlers.OnError(err)
 		}
@@ -277,14 +464,14 @@ func (ws *WebSocketService) handleLegacyThirdPartyMessage(data string) {
 		if ws.handlers.OnMasterOrder != nil {
 			var msg MasterOrderMessage
 			if err := json.Unmarshal([]byte(data), &msg); err != nil {
-				ws.c.debug("Failed to unmarshal master order message: %v", err)
+				ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "master order message"), slog.Any("error", err))
 				if ws.handlers.OnError != nil {
 					ws.handlers.OnError(err)
 				}
 				return
 			}
 			if err := ws.handlers.OnMasterOrder(&msg); err != nil {
-				ws.c.debug("Master order handler error: %v", err)
+				ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "master order"), slog.Any("error", err))
 			}
 		}
 
@@ -292,14 +479,14 @@ func (ws *WebSocketService) handleLegacyThirdPartyMessage(data string) {
 		if ws.handlers.OnOrder != nil {
 			var msg OrderMessage
 			if err := json.Unmarshal([]byte(data), &msg); err != nil {
-				ws.c.debug("Failed to unmarshal order message: %v", err)
+				ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "order message"), slog.Any("error", err))
 				if ws.handlers.OnError != nil {
 					ws.handlers.OnError(err)
 				}
 				return
 			}
 			if err := ws.handlers.OnOrder(&msg); err != nil {
-				ws.c.debug("Order handler error: %v", err)
+				ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "order"), slog.Any("error", err))
 			}
 		}
 
@@ -307,14 +494,14 @@ func (ws *WebSocketService) handleLegacyThirdPartyMessage(data string) {
 		if ws.handlers.OnFill != nil {
 			var msg FillMessage
 			if err := json.Unmarshal([]byte(data), &msg); err != nil {
-				ws.c.debug("Failed to unmarshal fill message: %v", err)
+				ws.log(slog.LevelWarn, "qe ws decode failed", slog.String("message", "fill message"), slog.Any("error", err))
 				if ws.handlers.OnError != nil {
 					ws.handlers.OnError(err)
 				}
 				return
 			}
 			if err := ws.handlers.OnFill(&msg); err != nil {
-				ws.c.debug("Fill handler error: %v", err)
+				ws.log(slog.LevelWarn, "qe ws handler error", slog.String("handler", "fill"), slog.Any("error", err))
 			}
 		}
 	}
@@ -353,12 +540,15 @@ func (ws *WebSocketService) reconnect() {
 		case <-ws.ctx.Done():
 			return
 		case <-time.After(ws.reconnectDelay):
-			ws.c.debug("Attempting to reconnect...")
-			if err := ws.connect(); err != nil {
-				ws.c.debug("Reconnect failed: %v", err)
+			ws.log(slog.LevelInfo, "qe ws reconnecting")
+			_, end := ws.c.startWebSocket(ws.ctx, WebSocketOpReconnect)
+			err := ws.connect()
+			end(err)
+			if err != nil {
+				ws.log(slog.LevelWarn, "qe ws reconnect failed", slog.Any("error", err))
 				continue
 			}
-			ws.c.debug("Reconnected successfully")
+			ws.log(slog.LevelInfo, "qe ws reconnected")
 			return
 		}
 	}
@@ -367,6 +557,7 @@ func (ws *WebSocketService) reconnect() {
 // Close 关闭连接
 func (ws *WebSocketService) Close() error {
 	ws.cancel()
+	ws.c.trackSocket(ws, false)
 
 	ws.mu.Lock()
 	if ws.conn != nil {
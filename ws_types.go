@@ -1,5 +1,7 @@
 package qe_connector
 
+import "github.com/Quantum-Execute/qe-connector-go/constant/enums/trading_enums"
+
 // ClientMessageType 客户端消息类型
 type ClientMessageType string
 type ClientProtocolVersion string
@@ -104,73 +106,73 @@ type WebSocketHandler func(data []byte) error
 // WsMasterOrderDetail 服务端通过 WS 推送的 V2 母单详情（master_data）。
 // 字段与 V2 API 契约一致，使用 lowerCamelCase JSON tag。
 type WsMasterOrderDetail struct {
-	CreatedAt                string            `json:"createdAt"`
-	UpdatedAt                string            `json:"updatedAt"`
-	MasterOrderID            string            `json:"masterOrderId"`
-	ClientOrderID            string            `json:"clientOrderId"`
-	ApiKeyID                 string            `json:"apiKeyId"`
-	TradingAccount           string            `json:"tradingAccount"`
-	Exchange                 string            `json:"exchange"`
-	MarketType               string            `json:"marketType"`
-	Category                 string            `json:"category"`
-	Symbol                   string            `json:"symbol"`
-	BaseCurrency             string            `json:"baseCurrency"`
-	QuoteCurrency            string            `json:"quoteCurrency"`
-	Side                     string            `json:"side"`
-	MarginType               string            `json:"marginType"`
-	ReduceOnly               bool              `json:"reduceOnly"`
-	IsMargin                 bool              `json:"isMargin"`
-	Algorithm                string            `json:"algorithm"`
-	TotalQuantity            FlexDecimalString `json:"totalQuantity"`
-	OrderNotional            FlexDecimalString `json:"orderNotional"`
-	StartTimeMs              FlexInt64         `json:"startTimeMs"`
-	ExecutionDurationSeconds FlexInt64         `json:"executionDurationSeconds"`
-	WorstPrice               FlexDecimalString `json:"worstPrice"`
-	MustComplete             bool              `json:"mustComplete"`
-	MakerRateLimit           FlexDecimalString `json:"makerRateLimit"`
-	POVLimit                 FlexDecimalString `json:"povLimit"`
-	POVMinLimit              FlexDecimalString `json:"povMinLimit"`
-	UpTolerance              FlexDecimalString `json:"upTolerance"`
-	LowTolerance             FlexDecimalString `json:"lowTolerance"`
-	StrictUpBound            bool              `json:"strictUpBound"`
-	TailOrderProtection      bool              `json:"tailOrderProtection"`
-	EnableMake               bool              `json:"enableMake"`
-	IsTargetPosition         bool              `json:"isTargetPosition"`
-	Notes                    string            `json:"notes"`
-	Status                   string            `json:"status"`
-	RejectReason             string            `json:"rejectReason"`
-	FinishedMs               FlexInt64         `json:"finishedMs"`
-	CumFilledQty             FlexDecimalString `json:"cumFilledQty"`
-	CumFilledNotional        FlexDecimalString `json:"cumFilledNotional"`
-	AvgFilledPrice           FlexDecimalString `json:"avgFilledPrice"`
-	MakerRate                FlexDecimalString `json:"makerRate"`
-	CompletedQuantity        FlexDecimalString `json:"completedQuantity"`
-	Commission               map[string]string `json:"commission,omitempty"`
+	CreatedAt                string                   `json:"createdAt"`
+	UpdatedAt                string                   `json:"updatedAt"`
+	MasterOrderID            string                   `json:"masterOrderId"`
+	ClientOrderID            string                   `json:"clientOrderId"`
+	ApiKeyID                 string                   `json:"apiKeyId"`
+	TradingAccount           string                   `json:"tradingAccount"`
+	Exchange                 string                   `json:"exchange"`
+	MarketType               trading_enums.MarketType `json:"marketType"`
+	Category                 trading_enums.Category   `json:"category"`
+	Symbol                   string                   `json:"symbol"`
+	BaseCurrency             string                   `json:"baseCurrency"`
+	QuoteCurrency            string                   `json:"quoteCurrency"`
+	Side                     trading_enums.OrderSide  `json:"side"`
+	MarginType               string                   `json:"marginType"`
+	ReduceOnly               bool                     `json:"reduceOnly"`
+	IsMargin                 bool                     `json:"isMargin"`
+	Algorithm                trading_enums.Algorithm  `json:"algorithm"`
+	TotalQuantity            FlexDecimalString        `json:"totalQuantity"`
+	OrderNotional            FlexDecimalString        `json:"orderNotional"`
+	StartTimeMs              FlexInt64                `json:"startTimeMs"`
+	ExecutionDurationSeconds FlexInt64                `json:"executionDurationSeconds"`
+	WorstPrice               FlexDecimalString        `json:"worstPrice"`
+	MustComplete             bool                     `json:"mustComplete"`
+	MakerRateLimit           FlexDecimalString        `json:"makerRateLimit"`
+	POVLimit                 FlexDecimalString        `json:"povLimit"`
+	POVMinLimit              FlexDecimalString        `json:"povMinLimit"`
+	UpTolerance              FlexDecimalString        `json:"upTolerance"`
+	LowTolerance             FlexDecimalString        `json:"lowTolerance"`
+	StrictUpBound            bool                     `json:"strictUpBound"`
+	TailOrderProtection      bool                     `json:"tailOrderProtection"`
+	EnableMake               bool                     `json:"enableMake"`
+	IsTargetPosition         bool                     `json:"isTargetPosition"`
+	Notes                    string                   `json:"notes"`
+	Status                   MasterOrderStatusV2      `json:"status"`
+	RejectReason             string                   `json:"rejectReason"`
+	FinishedMs               FlexInt64                `json:"finishedMs"`
+	CumFilledQty             FlexDecimalString        `json:"cumFilledQty"`
+	CumFilledNotional        FlexDecimalString        `json:"cumFilledNotional"`
+	AvgFilledPrice           FlexDecimalString        `json:"avgFilledPrice"`
+	MakerRate                FlexDecimalString        `json:"makerRate"`
+	CompletedQuantity        FlexDecimalString        `json:"completedQuantity"`
+	Commission               map[string]string        `json:"commission,omitempty"`
 }
 
 // WsOrderFillDetail 服务端通过 WS 推送的 V2 子单/成交详情（order_data）。
 // 字段与 V2 API 契约一致，使用 lowerCamelCase JSON tag。
 type WsOrderFillDetail struct {
-	ID               string            `json:"id"`
-	OrderCreatedTime string            `json:"orderCreatedTime"`
-	MasterOrderID    string            `json:"masterOrderId"`
-	Exchange         string            `json:"exchange"`
-	Category         string            `json:"category"`
-	Symbol           string            `json:"symbol"`
-	Side             string            `json:"side"`
-	FilledNotional   FlexDecimalString `json:"filledNotional"`
-	FilledQuantity   FlexDecimalString `json:"filledQuantity"`
-	AveragePrice     FlexDecimalString `json:"averagePrice"`
-	Price            FlexDecimalString `json:"price"`
-	Status           string            `json:"status"`
-	RejectReason     string            `json:"rejectReason"`
-	BaseCurrency     string            `json:"baseCurrency"`
-	QuoteCurrency    string            `json:"quoteCurrency"`
-	OrderType        string            `json:"orderType"`
-	OrderID          string            `json:"orderId"`
-	Quantity         FlexDecimalString `json:"quantity"`
-	CreatedAt        string            `json:"createdAt"`
-	UpdatedAt        string            `json:"updatedAt"`
+	ID               string                  `json:"id"`
+	OrderCreatedTime string                  `json:"orderCreatedTime"`
+	MasterOrderID    string                  `json:"masterOrderId"`
+	Exchange         string                  `json:"exchange"`
+	Category         trading_enums.Category  `json:"category"`
+	Symbol           string                  `json:"symbol"`
+	Side             trading_enums.OrderSide `json:"side"`
+	FilledNotional   FlexDecimalString       `json:"filledNotional"`
+	FilledQuantity   FlexDecimalString       `json:"filledQuantity"`
+	AveragePrice     FlexDecimalString       `json:"averagePrice"`
+	Price            FlexDecimalString       `json:"price"`
+	Status           OrderFillStatusV2       `json:"status"`
+	RejectReason     string                  `json:"rejectReason"`
+	BaseCurrency     string                  `json:"baseCurrency"`
+	QuoteCurrency    string                  `json:"quoteCurrency"`
+	OrderType        string                  `json:"orderType"`
+	OrderID          string                  `json:"orderId"`
+	Quantity         FlexDecimalString       `json:"quantity"`
+	CreatedAt        string                  `json:"createdAt"`
+	UpdatedAt        string                  `json:"updatedAt"`
 }
 
 // WebSocketEventHandlers 事件处理器集合
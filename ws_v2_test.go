@@ -1,7 +1,13 @@
 package qe_connector
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -29,12 +35,98 @@ func TestWebSocketServiceCanUseLegacyV1URL(t *testing.T) {
 	}
 }
 
+func TestWebSocketServiceDefaultHostPerEnvironment(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts []ClientOption
+		want string
+	}{
+		"test":   {[]ClientOption{WithEnvironment(EnvTest)}, TestWSHost},
+		"custom": {[]ClientOption{WithEnvironment(EnvTest), WithWSHost("wss://custom.example.test/")}, "wss://custom.example.test"},
+		"prod":   {[]ClientOption{WithWSHost("wss://prod.example.test")}, "wss://prod.example.test"},
+	} {
+		c, err := New(tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ws := c.NewWebSocketService()
+		ws.listenKey = "lk"
+		if got, want := ws.getWebSocketURL(), tc.want+"/api/ws/v2?listen_key=lk"; got != want {
+			t.Errorf("%s: getWebSocketURL() = %s, want %s", name, got, want)
+		}
+	}
+
+	// EnvProd has no default host; connecting without one fails instead of
+	// reaching the test environment.
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := c.NewWebSocketService()
+	defer ws.Close()
+	if err := ws.Connect("lk"); !errors.Is(err, errNoWSHost) {
+		t.Fatalf("Connect() = %v, want errNoWSHost", err)
+	}
+}
+
+func TestWebSocketServiceUsesRegistryProfileHost(t *testing.T) {
+	t.Setenv("QE_PROFILE_ENVONLY_API_KEY", "key")
+	t.Setenv("QE_PROFILE_ENVONLY_SECRET_KEY", "secret")
+	t.Setenv("QE_PROFILE_ENVONLY_WS_HOST", "wss://env.example.test")
+	cfg := RegistryConfig{Profiles: map[string]*Profile{
+		"single":   {APIKey: "key", SecretKey: "secret", WSHost: "wss://single.example.test"},
+		"failover": {APIKey: "key", SecretKey: "secret", WSHost: "wss://primary.example.test", WSHosts: []string{"wss://backup.example.test"}},
+	}}
+	applyProfileEnv(&cfg, os.Environ())
+	reg, err := NewClientRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"single":   "wss://single.example.test/api/ws/v2?listen_key=lk",
+		"envonly":  "wss://env.example.test/api/ws/v2?listen_key=lk",
+		"failover": "wss://primary.example.test/api/ws/v2?listen_key=lk",
+	} {
+		c, err := reg.Client(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ws := c.NewWebSocketService()
+		ws.listenKey = "lk"
+		if got := ws.getWebSocketURL(); got != want {
+			t.Errorf("%s: getWebSocketURL() = %s, want %s", name, got, want)
+		}
+	}
+
+	// EnvProd has no default host; connecting without one fails instead of
+	// reaching the test environment.
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := c.NewWebSocketService()
+	defer ws.Close()
+	if err := ws.Connect("lk"); !errors.Is(err, errNoWSHost) {
+		t.Fatalf("Connect() = %v, want errNoWSHost", err)
+	}
+}
+
 func TestCreateListenKeyV2ServiceUsesV2Route(t *testing.T) {
-	client := NewClient("key", "secret")
-	svc := client.NewCreateListenKeyV2Service()
+	client := NewClient("key", "secret", "https://example.com")
+	var path string
+	client.do = func(req *http.Request) (*http.Response, error) {
+		path = req.URL.Path
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"code":200,"message":{"listenKey":"lk"}}`)),
+			Header:     http.Header{},
+		}, nil
+	}
 
-	if svc.endpoint() != "/user/trading/v2/listen-key" {
-		t.Fatalf("endpoint() = %s", svc.endpoint())
+	if _, err := client.NewCreateListenKeyV2Service().Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/user/trading/v2/listen-key" {
+		t.Fatalf("path = %s", path)
 	}
 }
 